@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"os"
@@ -8,16 +9,38 @@ import (
 	"time"
 
 	"github.com/MaKcm14/pr-service/internal/config/cfg"
+	"github.com/MaKcm14/pr-service/internal/controller/cgrpc"
 	"github.com/MaKcm14/pr-service/internal/controller/chttp"
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/metrics"
+	"github.com/MaKcm14/pr-service/internal/repo/memory"
 	"github.com/MaKcm14/pr-service/internal/repo/postgres"
+	"github.com/MaKcm14/pr-service/internal/sender/shttp"
+	"github.com/MaKcm14/pr-service/internal/sender/slogger"
+	"github.com/MaKcm14/pr-service/internal/services"
 	"github.com/MaKcm14/pr-service/internal/services/usecase"
+	"github.com/MaKcm14/pr-service/internal/tracing"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// webhookDeliveryInterval defines how often the queued webhooks' deliveries are checked.
+	webhookDeliveryInterval = 5 * time.Second
+
+	// outboxDispatchInterval defines how often the outbox's unpublished events are checked.
+	outboxDispatchInterval = time.Second
+
+	// tracingShutdownTimeout defines how long the buffered spans are flushed on the service's stop.
+	tracingShutdownTimeout = 5 * time.Second
 )
 
 // Service defines the main service's structure with all dependencies in it.
 type Service struct {
 	log     *slog.Logger
 	logFile *os.File
+	tracing *tracing.Provider
 	contr   chttp.HttpController
+	useCase usecase.UseCase
 }
 
 func NewService() Service {
@@ -35,12 +58,30 @@ func NewService() Service {
 	config := cfg.Config{}
 	if err := config.Configure(log,
 		cfg.ConfigSocket("SERVICE_SOCKET"),
-		cfg.ConfigDSN("DSN")); err != nil {
+		cfg.ConfigGrpcSocket("GRPC_SOCKET"),
+		cfg.ConfigRepository("REPOSITORY"),
+		cfg.ConfigDSN("DSN"),
+		cfg.ConfigGithubWebhookSecret("GITHUB_WEBHOOK_SECRET"),
+		cfg.ConfigGitlabWebhook("GITLAB_WEBHOOK_TOKEN", "GITLAB_IDENTITY_MAPPING"),
+		cfg.ConfigOutboxSinks("OUTBOX_LOG_SINK", "OUTBOX_HTTP_SINK_URL"),
+		cfg.ConfigAuth("AUTH_ENABLED", "AUTH_BOOTSTRAP_TOKEN"),
+		cfg.ConfigTracing("TRACING_EXPORTER", "TRACING_FILE")); err != nil {
 		logFile.Close()
 		panic(fmt.Sprintf("error while configuring the service: %s", err))
 	}
 
-	contr, err := configureLayers(log, config)
+	var provider *tracing.Provider
+	if len(config.TracingExporter) != 0 {
+		log.Info(fmt.Sprintf("enabling the tracing with the %s exporter", config.TracingExporter))
+
+		provider, err = tracing.New(context.Background(), log, config.TracingExporter, config.TracingFile)
+		if err != nil {
+			logFile.Close()
+			panic(fmt.Sprintf("error while configuring the service: %s", err))
+		}
+	}
+
+	contr, useCase, err := configureLayers(log, config)
 	if err != nil {
 		logFile.Close()
 		panic(err.Error())
@@ -49,31 +90,131 @@ func NewService() Service {
 	return Service{
 		log:     log,
 		logFile: logFile,
+		tracing: provider,
 		contr:   contr,
+		useCase: useCase,
 	}
 }
 
-func configureLayers(log *slog.Logger, config cfg.Config) (chttp.HttpController, error) {
-	log.Info("configuring the DB")
+func configureLayers(log *slog.Logger, config cfg.Config) (chttp.HttpController, usecase.UseCase, error) {
+	log.Info(fmt.Sprintf("configuring the %s repository", config.Repository))
 
-	repo, err := postgres.New(log, config.DSN)
+	repo, err := configureRepository(log, config)
 	if err != nil {
-		return chttp.HttpController{}, fmt.Errorf("error while configuring the service: %s", err)
+		return chttp.HttpController{}, usecase.UseCase{}, fmt.Errorf("error while configuring the service: %s", err)
+	}
+
+	opts := make([]chttp.ControllerOpt, 0, 6)
+	if len(config.GithubWebhookSecret) != 0 {
+		log.Info("enabling the GitHub webhooks")
+		opts = append(opts, chttp.WithGithubWebhook(config.GithubWebhookSecret))
+	}
+
+	if len(config.GitlabWebhookToken) != 0 {
+		log.Info(fmt.Sprintf("enabling the GitLab webhooks with %d mapped identities", len(config.GitlabIdentities)))
+
+		identities := make(map[string]entities.UserID, len(config.GitlabIdentities))
+		for username, id := range config.GitlabIdentities {
+			identities[username] = entities.UserID(id)
+		}
+		opts = append(opts, chttp.WithGitlabWebhook(config.GitlabWebhookToken, identities))
+	}
+
+	sinks := make([]services.EventSink, 0, 2)
+	if config.OutboxLogSink {
+		log.Info("enabling the outbox's log sink")
+		sinks = append(sinks, slogger.New(log))
+	}
+
+	if len(config.OutboxHttpSinkURL) != 0 {
+		log.Info(fmt.Sprintf("enabling the outbox's http sink %s", config.OutboxHttpSinkURL))
+		sinks = append(sinks, shttp.NewEventSink(log, config.OutboxHttpSinkURL))
+	}
+
+	registry := metrics.New(log)
+
+	useCase := usecase.NewUseCase(log, usecase.Deps{
+		Tx:          repo,
+		TeamRepo:    repo,
+		PrRepo:      repo,
+		UserRepo:    repo,
+		StatsRepo:   repo,
+		IdemRepo:    repo,
+		WebhookRepo: repo,
+		OutboxRepo:  repo,
+		TokenRepo:   repo,
+		Sender:      shttp.New(log),
+		Sinks:       sinks,
+		Metrics:     registry,
+		Pingers: map[string]services.Pinger{
+			config.Repository: repo,
+		},
+	})
+
+	registry.Register(metrics.NewWorkloadCollector(log, useCase))
+	if pool, ok := repo.(poolStater); ok {
+		registry.Register(metrics.NewPoolCollector(pool.Stat))
 	}
+	opts = append(opts, chttp.WithMetrics(registry))
 
-	contr := chttp.New(
-		log,
-		config.Socket,
-		usecase.NewUseCase(log, repo, repo, repo),
-	)
+	if len(config.TracingExporter) != 0 {
+		opts = append(opts, chttp.WithTracing())
+	}
 
-	return contr, nil
+	grpcOpts := make([]cgrpc.ControllerOpt, 0, 1)
+	if config.AuthEnabled {
+		log.Info("enabling the bearer tokens' authentication")
+		opts = append(opts, chttp.WithTokenAuth(config.AuthBootstrapToken))
+		grpcOpts = append(grpcOpts, cgrpc.WithTokenAuth(config.AuthBootstrapToken))
+	} else {
+		log.Warn("the bearer tokens' authentication is disabled: the API is anonymous")
+	}
+
+	if len(config.GrpcSocket) != 0 {
+		log.Info(fmt.Sprintf("enabling the grpc-server on the %s", config.GrpcSocket))
+		opts = append(opts, chttp.WithServers(cgrpc.New(log, config.GrpcSocket, useCase, grpcOpts...)))
+	}
+
+	return chttp.New(log, config.Socket, useCase, opts...), useCase, nil
+}
+
+// repository defines the set of the repositories' abstractions implemented by every repo type.
+type repository interface {
+	services.TxManager
+	services.TeamRepository
+	services.UserRepository
+	services.PullRequestRepository
+	services.StatsRepository
+	services.IdempotencyRepository
+	services.WebhookRepository
+	services.OutboxRepository
+	services.TokenRepository
+	services.Pinger
+}
+
+// poolStater defines the repository keeping the connections' pool exposed in the metrics.
+type poolStater interface {
+	Stat() *pgxpool.Stat
+}
+
+func configureRepository(log *slog.Logger, config cfg.Config) (repository, error) {
+	if config.Repository == cfg.RepositoryMemory {
+		return memory.New(log), nil
+	}
+	return postgres.New(log, config.DSN)
 }
 
 func (s *Service) Start() error {
 	defer s.log.Info("STOP THE PULL-REQUEST SERVICE")
 	defer s.close()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.log.Info("start the outbox's dispatcher and the webhooks' deliveries")
+	go s.useCase.RunOutboxDispatcher(ctx, outboxDispatchInterval)
+	go s.useCase.RunWebhookDeliveries(ctx, webhookDeliveryInterval)
+
 	s.log.Info("start the pull-request service")
 	if err := s.contr.Run(); err != nil {
 		s.log.Error(fmt.Sprintf("error of starting the pull-request service: %s", err))
@@ -84,5 +225,11 @@ func (s *Service) Start() error {
 }
 
 func (s *Service) close() {
+	if s.tracing != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+
+		s.tracing.Shutdown(ctx)
+	}
 	s.logFile.Close()
 }
@@ -7,10 +7,36 @@ import (
 	"github.com/joho/godotenv"
 )
 
+const (
+	RepositoryPostgres = "postgres"
+	RepositoryMemory   = "memory"
+)
+
+const (
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+)
+
 // Config defines the service's configuration data-object.
 type Config struct {
-	Socket string
-	DSN    string
+	Socket     string
+	GrpcSocket string
+	DSN        string
+	Repository string
+
+	GithubWebhookSecret string
+
+	GitlabWebhookToken string
+	GitlabIdentities   map[string]string
+
+	OutboxLogSink     bool
+	OutboxHttpSinkURL string
+
+	AuthEnabled        bool
+	AuthBootstrapToken string
+
+	TracingExporter string
+	TracingFile     string
 }
 
 func (c *Config) Configure(log *slog.Logger, opts ...ConfigOpt) error {
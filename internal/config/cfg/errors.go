@@ -3,7 +3,12 @@ package cfg
 import "errors"
 
 var (
-	ErrSocketConfig = errors.New("cfg: error of the socket's configuration")
-	ErrEnvFile      = errors.New("cfg: error of the .env file")
-	ErrDsnConfig    = errors.New("cfg: error of the dsn's configuration")
+	ErrSocketConfig  = errors.New("cfg: error of the socket's configuration")
+	ErrEnvFile       = errors.New("cfg: error of the .env file")
+	ErrDsnConfig     = errors.New("cfg: error of the dsn's configuration")
+	ErrRepoConfig    = errors.New("cfg: error of the repository's configuration")
+	ErrGitlabConfig  = errors.New("cfg: error of the gitlab webhooks' configuration")
+	ErrOutboxConfig  = errors.New("cfg: error of the outbox sinks' configuration")
+	ErrAuthConfig    = errors.New("cfg: error of the authentication's configuration")
+	ErrTracingConfig = errors.New("cfg: error of the tracing's configuration")
 )
@@ -1,10 +1,16 @@
 package cfg
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
 )
 
+// minBootstrapTokenLen defines the min length of the bootstrap token making it hard to guess.
+const minBootstrapTokenLen = 32
+
 // ConfigOpt defines the function for configuration the option
 type ConfigOpt func(conf *Config) error
 
@@ -22,11 +28,30 @@ func ConfigSocket(key string) ConfigOpt {
 	}
 }
 
+// ConfigGrpcSocket defines the logic of the grpc-server socket's configuration.
+// The grpc-server is disabled if the socket is empty.
+func ConfigGrpcSocket(key string) ConfigOpt {
+	return func(conf *Config) error {
+		val := os.Getenv(key)
+
+		if len(val) != 0 && val == conf.Socket {
+			return fmt.Errorf("%w: the grpc-server's socket must differ from the http-server's one", ErrSocketConfig)
+		}
+		conf.GrpcSocket = val
+
+		return nil
+	}
+}
+
+// ConfigDSN defines the logic of the DSN's configuration.
+// The DSN is optional for the in-memory repository.
 func ConfigDSN(key string) ConfigOpt {
 	return func(conf *Config) error {
 		val := os.Getenv(key)
 
-		if len(val) == 0 {
+		if len(val) == 0 && conf.Repository == RepositoryMemory {
+			return nil
+		} else if len(val) == 0 {
 			return fmt.Errorf("%w: is empty", ErrDsnConfig)
 		}
 		conf.DSN = val
@@ -34,3 +59,145 @@ func ConfigDSN(key string) ConfigOpt {
 		return nil
 	}
 }
+
+// ConfigRepository defines the logic of the repository type's configuration.
+// The PostgreSQL repository is used by default.
+func ConfigRepository(key string) ConfigOpt {
+	return func(conf *Config) error {
+		val := os.Getenv(key)
+
+		switch val {
+		case "":
+			conf.Repository = RepositoryPostgres
+
+		case RepositoryPostgres, RepositoryMemory:
+			conf.Repository = val
+
+		default:
+			return fmt.Errorf("%w: unknown type '%s'", ErrRepoConfig, val)
+		}
+
+		return nil
+	}
+}
+
+// ConfigGithubWebhookSecret defines the logic of the GitHub webhooks' secret configuration.
+// The GitHub webhooks are disabled if the secret is empty.
+func ConfigGithubWebhookSecret(key string) ConfigOpt {
+	return func(conf *Config) error {
+		conf.GithubWebhookSecret = os.Getenv(key)
+		return nil
+	}
+}
+
+// ConfigGitlabWebhook defines the logic of the GitLab webhooks' token and identity mapping configuration.
+// The identity mapping is the JSON file mapping the GitLab's usernames to the users' ids.
+// The GitLab webhooks are disabled if the token is empty.
+func ConfigGitlabWebhook(tokenKey string, identitiesKey string) ConfigOpt {
+	return func(conf *Config) error {
+		conf.GitlabWebhookToken = os.Getenv(tokenKey)
+
+		path := os.Getenv(identitiesKey)
+		if len(path) == 0 {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrGitlabConfig, err)
+		}
+
+		if err := json.Unmarshal(data, &conf.GitlabIdentities); err != nil {
+			return fmt.Errorf("%w: wrong identity mapping: %w", ErrGitlabConfig, err)
+		}
+
+		return nil
+	}
+}
+
+// ConfigOutboxSinks defines the logic of the outbox's optional sinks configuration:
+// writing the domain events to the log and posting them to the HTTP endpoint.
+// The sinks are disabled if the values are empty.
+func ConfigOutboxSinks(logKey string, httpURLKey string) ConfigOpt {
+	return func(conf *Config) error {
+		if val := os.Getenv(logKey); len(val) != 0 {
+			enabled, err := strconv.ParseBool(val)
+			if err != nil {
+				return fmt.Errorf("%w: wrong log sink's flag: %w", ErrOutboxConfig, err)
+			}
+			conf.OutboxLogSink = enabled
+		}
+
+		val := os.Getenv(httpURLKey)
+		if len(val) == 0 {
+			return nil
+		}
+
+		if u, err := url.Parse(val); err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			return fmt.Errorf("%w: the http sink's url must be the absolute http(s) one", ErrOutboxConfig)
+		}
+		conf.OutboxHttpSinkURL = val
+
+		return nil
+	}
+}
+
+// ConfigAuth defines the logic of the bearer tokens' authentication configuration.
+// The authentication is enabled by default. The bootstrap token is the admin token issuing the first tokens:
+// it's required for the in-memory repository since its tokens aren't kept between the starts.
+func ConfigAuth(enabledKey string, bootstrapTokenKey string) ConfigOpt {
+	return func(conf *Config) error {
+		conf.AuthEnabled = true
+
+		if val := os.Getenv(enabledKey); len(val) != 0 {
+			enabled, err := strconv.ParseBool(val)
+			if err != nil {
+				return fmt.Errorf("%w: wrong auth's flag: %w", ErrAuthConfig, err)
+			}
+			conf.AuthEnabled = enabled
+		}
+
+		if !conf.AuthEnabled {
+			return nil
+		}
+
+		val := os.Getenv(bootstrapTokenKey)
+		if len(val) == 0 && conf.Repository == RepositoryMemory {
+			return fmt.Errorf("%w: the bootstrap token is required for the memory repository", ErrAuthConfig)
+		} else if len(val) != 0 && len(val) < minBootstrapTokenLen {
+			return fmt.Errorf("%w: the bootstrap token must be at least %d characters long", ErrAuthConfig, minBootstrapTokenLen)
+		}
+		conf.AuthBootstrapToken = val
+
+		return nil
+	}
+}
+
+// ConfigTracing defines the logic of the traces' exporter configuration.
+// The OTLP exporter is configured with the standard OTEL_EXPORTER_OTLP_* variables,
+// the stdout one writes the spans to the file if its path is set and to the stdout otherwise.
+// The tracing is disabled if the exporter is empty.
+func ConfigTracing(exporterKey string, fileKey string) ConfigOpt {
+	return func(conf *Config) error {
+		val := os.Getenv(exporterKey)
+
+		switch val {
+		case "":
+			return nil
+
+		case TracingExporterOTLP, TracingExporterStdout:
+			conf.TracingExporter = val
+
+		default:
+			return fmt.Errorf("%w: unknown exporter '%s'", ErrTracingConfig, val)
+		}
+
+		path := os.Getenv(fileKey)
+		if len(path) != 0 && conf.TracingExporter != TracingExporterStdout {
+			return fmt.Errorf("%w: the file is supported only by the stdout exporter", ErrTracingConfig)
+		}
+		conf.TracingFile = path
+
+		return nil
+	}
+}
@@ -0,0 +1,144 @@
+package cgrpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/controller/cgrpc/pb"
+	"github.com/MaKcm14/pr-service/internal/controller/chttp"
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/services"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// AuthMetadataKey defines the call's metadata key keeping the bearer token.
+	AuthMetadataKey = "authorization"
+
+	bearerPrefix = "Bearer "
+)
+
+// methodScopes defines the scopes the tokens must grant for the calls, the same as for the HTTP API's endpoints.
+// The calls of the other methods except the health checks require the admin scope.
+var methodScopes = map[string]entities.Scope{
+	pb.TeamService_GetTeam_FullMethodName:             entities.ScopeTeamsRead,
+	pb.TeamService_GetWebhooks_FullMethodName:         entities.ScopeTeamsRead,
+	pb.TeamService_AddTeam_FullMethodName:             entities.ScopeTeamsWrite,
+	pb.TeamService_SetReviewerStrategy_FullMethodName: entities.ScopeTeamsWrite,
+	pb.TeamService_SetReviewerPolicy_FullMethodName:   entities.ScopeTeamsWrite,
+	pb.TeamService_SetMergePolicy_FullMethodName:      entities.ScopeTeamsWrite,
+	pb.TeamService_DeactivateUsers_FullMethodName:     entities.ScopeTeamsWrite,
+	pb.TeamService_AddWebhook_FullMethodName:          entities.ScopeTeamsWrite,
+	pb.TeamService_RemoveWebhook_FullMethodName:       entities.ScopeTeamsWrite,
+	pb.UserService_SetIsActive_FullMethodName:         entities.ScopeTeamsWrite,
+
+	pb.UserService_GetReview_FullMethodName:                    entities.ScopePrsRead,
+	pb.UserService_StreamReview_FullMethodName:                 entities.ScopePrsRead,
+	pb.PullRequestService_GetPullRequestHistory_FullMethodName: entities.ScopePrsRead,
+	pb.PullRequestService_GetPullRequest_FullMethodName:        entities.ScopePrsRead,
+	pb.PullRequestService_ListPullRequests_FullMethodName:      entities.ScopePrsRead,
+	pb.StatsService_GetStats_FullMethodName:                    entities.ScopePrsRead,
+
+	pb.PullRequestService_CreatePullRequest_FullMethodName: entities.ScopePrsWrite,
+	pb.PullRequestService_MergePullRequest_FullMethodName:  entities.ScopePrsWrite,
+	pb.PullRequestService_ClosePullRequest_FullMethodName:  entities.ScopePrsWrite,
+	pb.PullRequestService_ReopenPullRequest_FullMethodName: entities.ScopePrsWrite,
+	pb.PullRequestService_ReadyPullRequest_FullMethodName:  entities.ScopePrsWrite,
+	pb.PullRequestService_ReviewPullRequest_FullMethodName: entities.ScopePrsWrite,
+	pb.PullRequestService_ReassignReviewer_FullMethodName:  entities.ScopePrsWrite,
+}
+
+// authUnaryInterceptor defines the logic of authorizing the call with the bearer token.
+// The token's user is the call's actor instead of the x-actor-id metadata, the actor of the token
+// without the user is unknown.
+func (g *GrpcController) authUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	ctx, err := g.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// authStreamInterceptor defines the logic of authorizing the streaming call with the bearer token.
+func (g *GrpcController) authStreamInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if _, err := g.authorize(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+// authorize checks the call's token grants the method's scope and returns the ctx with the token as the principal
+// and the token's user as the actor.
+// The calls aren't checked if the tokens' authentication is disabled.
+func (g *GrpcController) authorize(ctx context.Context, method string) (context.Context, error) {
+	const op = "cgrpc.authorize"
+
+	if !g.authEnabled || strings.HasPrefix(method, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") {
+		return ctx, nil
+	}
+
+	scope, ok := methodScopes[method]
+	if !ok {
+		scope = entities.ScopeAdmin
+	}
+
+	var header string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(AuthMetadataKey); len(values) != 0 {
+			header = values[0]
+		}
+	}
+
+	if !strings.HasPrefix(header, bearerPrefix) || len(header) == len(bearerPrefix) {
+		return nil, newStatusErr(chttp.Unauthorized, chttp.ErrRespQueryUnauthorized)
+	}
+
+	token, err := g.authenticateToken(ctx, strings.TrimPrefix(header, bearerPrefix))
+	if err != nil {
+		if errors.Is(err, services.ErrUnauthenticated) {
+			return nil, newStatusErr(chttp.Unauthorized, chttp.ErrRespQueryUnauthorized)
+		}
+		g.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+		return nil, newStatusErr(chttp.ServerErr, chttp.ErrRespQueryServerError)
+	}
+
+	if !token.HasScope(scope) {
+		return nil, newStatusErr(chttp.Forbidden, chttp.ErrRespQueryForbidden)
+	}
+
+	ctx = services.WithPrincipal(ctx, services.Principal{
+		TokenID: token.ID,
+		UserID:  token.UserID,
+		IsAdmin: token.HasScope(entities.ScopeAdmin),
+	})
+	return services.WithActor(ctx, token.UserID), nil
+}
+
+// authenticateToken returns the token of the secret: the configured bootstrap one or the issued one.
+func (g *GrpcController) authenticateToken(ctx context.Context, secret string) (entities.APIToken, error) {
+	if len(g.bootstrapToken) != 0 && subtle.ConstantTimeCompare([]byte(secret), g.bootstrapToken) == 1 {
+		return entities.NewBootstrapToken(), nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	return g.useCase.AuthenticateToken(ctx, secret)
+}
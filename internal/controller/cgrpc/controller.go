@@ -0,0 +1,140 @@
+package cgrpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+
+	"github.com/MaKcm14/pr-service/internal/controller/cgrpc/pb"
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/services"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+// ActorMetadataKey defines the call's metadata key keeping the id of the user making the call.
+const ActorMetadataKey = "x-actor-id"
+
+// GrpcController defines the logic of handling the gRPC calls with the same use-cases as the HTTP API.
+type GrpcController struct {
+	pb.UnimplementedTeamServiceServer
+	pb.UnimplementedUserServiceServer
+	pb.UnimplementedPullRequestServiceServer
+	pb.UnimplementedStatsServiceServer
+	pb.UnimplementedWebhookServiceServer
+	healthpb.UnimplementedHealthServer
+
+	log     *slog.Logger
+	socket  string
+	server  *grpc.Server
+	useCase services.Interactor
+
+	authEnabled    bool
+	bootstrapToken []byte
+}
+
+// ControllerOpt defines the function for configuration the controller's option.
+type ControllerOpt func(contr *GrpcController)
+
+// WithTokenAuth enables the bearer tokens' authentication of the calls.
+// The non-empty bootstrap token is accepted as the admin one.
+func WithTokenAuth(bootstrapToken string) ControllerOpt {
+	return func(contr *GrpcController) {
+		contr.authEnabled = true
+		contr.bootstrapToken = []byte(bootstrapToken)
+	}
+}
+
+func New(log *slog.Logger, socket string, interactor services.Interactor, opts ...ControllerOpt) *GrpcController {
+	contr := &GrpcController{
+		log:     log,
+		socket:  socket,
+		useCase: interactor,
+	}
+
+	for _, opt := range opts {
+		opt(contr)
+	}
+
+	contr.server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(actorInterceptor, contr.authUnaryInterceptor),
+		grpc.StreamInterceptor(contr.authStreamInterceptor))
+
+	pb.RegisterTeamServiceServer(contr.server, contr)
+	pb.RegisterUserServiceServer(contr.server, contr)
+	pb.RegisterPullRequestServiceServer(contr.server, contr)
+	pb.RegisterStatsServiceServer(contr.server, contr)
+	pb.RegisterWebhookServiceServer(contr.server, contr)
+	healthpb.RegisterHealthServer(contr.server, contr)
+
+	return contr
+}
+
+// Run starts the grpc-server and blocks until it's stopped.
+func (g *GrpcController) Run() error {
+	const op = "cgrpc.run"
+
+	g.log.Info("starting the grpc-server")
+
+	listener, err := net.Listen("tcp", g.socket)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %s", op, ErrStartingServer, err)
+		g.log.Error(retErr.Error())
+		return retErr
+	}
+
+	return g.Serve(listener)
+}
+
+// Serve defines the logic of handling the calls accepted by the listener until the server is stopped.
+func (g *GrpcController) Serve(listener net.Listener) error {
+	const op = "cgrpc.serve"
+
+	if err := g.server.Serve(listener); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %s", op, ErrStartingServer, err)
+		g.log.Error(retErr.Error())
+		return retErr
+	}
+	return nil
+}
+
+// Shutdown stops the grpc-server after the active calls are finished.
+// The calls are cancelled if they aren't finished until the ctx is done.
+func (g *GrpcController) Shutdown(ctx context.Context) error {
+	defer g.log.Info("stop the grpc-server")
+
+	// The open streams don't let the server stop gracefully, so they're closed first.
+	g.useCase.CloseReviewStreams()
+
+	stopped := make(chan struct{})
+	go func() {
+		g.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+
+	case <-ctx.Done():
+		g.server.Stop()
+		return ctx.Err()
+	}
+}
+
+// actorInterceptor defines the logic of passing the call's actor to the use-cases through the ctx.
+func actorInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if actor := md.Get(ActorMetadataKey); len(actor) != 0 && len(actor[0]) != 0 {
+			ctx = services.WithActor(ctx, entities.UserID(actor[0]))
+		}
+	}
+	return handler(ctx, req)
+}
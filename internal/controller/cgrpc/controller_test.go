@@ -0,0 +1,448 @@
+package cgrpc
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/controller/cgrpc/pb"
+	"github.com/MaKcm14/pr-service/internal/controller/chttp"
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/repo/memory"
+	"github.com/MaKcm14/pr-service/internal/sender/shttp"
+	"github.com/MaKcm14/pr-service/internal/services"
+	"github.com/MaKcm14/pr-service/internal/services/usecase"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// makeTestClient returns the connection to the test grpc-server and the server's use-cases.
+func makeTestClient(t *testing.T, opts ...ControllerOpt) (*grpc.ClientConn, usecase.UseCase) {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := memory.New(log)
+
+	useCase := usecase.NewUseCase(log, usecase.Deps{
+		Tx:          repo,
+		TeamRepo:    repo,
+		PrRepo:      repo,
+		UserRepo:    repo,
+		StatsRepo:   repo,
+		IdemRepo:    repo,
+		WebhookRepo: repo,
+		OutboxRepo:  repo,
+		TokenRepo:   repo,
+		Sender:      shttp.New(log),
+		Pingers:     map[string]services.Pinger{"memory": repo},
+	})
+
+	contr := New(log, "", useCase, opts...)
+	listener := bufconn.Listen(1 << 20)
+
+	go contr.Serve(listener)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		contr.Shutdown(ctx)
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("couldn't connect to the test server: %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn, useCase
+}
+
+// addTestTeam creates the team with the members active.
+func addTestTeam(t *testing.T, conn *grpc.ClientConn, name string, policy *pb.MergePolicy, ids ...string) {
+	t.Helper()
+
+	team := &pb.Team{TeamName: name, MergePolicy: policy}
+	for _, id := range ids {
+		team.Members = append(team.Members, &pb.TeamMember{UserId: id, Username: id, IsActive: true})
+	}
+
+	if _, err := pb.NewTeamServiceClient(conn).AddTeam(context.Background(), &pb.AddTeamRequest{Team: team}); err != nil {
+		t.Fatalf("couldn't create the test team: %s", err)
+	}
+}
+
+// checkStatusErr checks the call's error has the code and the HTTP API's error code as the reason.
+func checkStatusErr(t *testing.T, err error, code codes.Code, reason chttp.ErrCode) *status.Status {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != code {
+		t.Fatalf("expected the %s status, got %v", code, err)
+	}
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetReason() == string(reason) {
+			return st
+		}
+	}
+	t.Fatalf("expected the %s reason, got %v", reason, st.Details())
+
+	return nil
+}
+
+func TestGrpcPullRequestFlow(t *testing.T) {
+	conn, _ := makeTestClient(t)
+	addTestTeam(t, conn, "backend", &pb.MergePolicy{RequiredApprovals: 1}, "alice", "bob", "carol")
+
+	teams := pb.NewTeamServiceClient(conn)
+	pullReqs := pb.NewPullRequestServiceClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), ActorMetadataKey, "alice")
+
+	_, err := teams.AddTeam(ctx, &pb.AddTeamRequest{Team: &pb.Team{TeamName: "backend"}})
+	checkStatusErr(t, err, codes.AlreadyExists, chttp.TeamExists)
+
+	_, err = teams.GetTeam(ctx, &pb.GetTeamRequest{TeamName: "frontend"})
+	checkStatusErr(t, err, codes.NotFound, chttp.NotFound)
+
+	_, err = teams.SetReviewerStrategy(ctx, &pb.SetReviewerStrategyRequest{TeamName: "backend", ReviewerStrategy: "NEWEST"})
+	checkStatusErr(t, err, codes.InvalidArgument, chttp.RequestDataErr)
+
+	created, err := pullReqs.CreatePullRequest(ctx, &pb.CreatePullRequestRequest{
+		PullRequestId:   "pr-1",
+		PullRequestName: "Add search",
+		AuthorId:        "alice",
+	})
+	if err != nil {
+		t.Fatalf("couldn't create the PR: %s", err)
+	}
+
+	if pr := created.GetPr(); pr.GetStatus() != string(entities.Open) || len(pr.GetAssignedReviewers()) != 2 ||
+		pr.GetReviewersCount() != 2 || pr.GetCreatedAt() == nil {
+		t.Fatalf("unexpected created PR: %v", pr)
+	}
+
+	_, err = pullReqs.CreatePullRequest(ctx, &pb.CreatePullRequestRequest{PullRequestId: "pr-1", AuthorId: "alice"})
+	checkStatusErr(t, err, codes.AlreadyExists, chttp.PrExists)
+
+	_, err = pullReqs.MergePullRequest(ctx, &pb.ChangePullRequestStatusRequest{PullRequestId: "pr-1"})
+	st := checkStatusErr(t, err, codes.FailedPrecondition, chttp.MergeBlocked)
+
+	var unmet []*errdetails.PreconditionFailure_Violation
+	for _, detail := range st.Details() {
+		if failure, ok := detail.(*errdetails.PreconditionFailure); ok {
+			unmet = failure.GetViolations()
+		}
+	}
+	if len(unmet) != 1 || unmet[0].GetType() != string(entities.MinApprovalsRule) {
+		t.Fatalf("expected the unmet approvals rule, got %v", unmet)
+	}
+
+	reviewer := created.GetPr().GetAssignedReviewers()[0]
+	reviewed, err := pullReqs.ReviewPullRequest(ctx, &pb.ReviewPullRequestRequest{
+		PullRequestId: "pr-1",
+		ReviewerId:    reviewer,
+		Decision:      string(entities.Approved),
+	})
+	if err != nil {
+		t.Fatalf("couldn't review the PR: %s", err)
+	}
+
+	if decision := reviewed.GetPr().GetReviewDecisions()[reviewer]; decision != string(entities.Approved) {
+		t.Fatalf("expected the reviewer's approval, got %q", decision)
+	}
+
+	merged, err := pullReqs.MergePullRequest(ctx, &pb.ChangePullRequestStatusRequest{PullRequestId: "pr-1"})
+	if err != nil {
+		t.Fatalf("couldn't merge the PR: %s", err)
+	}
+
+	if pr := merged.GetPr(); pr.GetStatus() != string(entities.Merged) || pr.GetMergedAt() == nil {
+		t.Fatalf("unexpected merged PR: %v", pr)
+	}
+
+	_, err = pullReqs.ReassignReviewer(ctx, &pb.ReassignReviewerRequest{PullRequestId: "pr-1", OldReviewerId: reviewer})
+	checkStatusErr(t, err, codes.FailedPrecondition, chttp.PrMerged)
+
+	history, err := pullReqs.GetPullRequestHistory(ctx, &pb.GetPullRequestHistoryRequest{PullRequestId: "pr-1"})
+	if err != nil {
+		t.Fatalf("couldn't get the PR's history: %s", err)
+	}
+
+	if len(history.GetHistory()) != 2 || history.GetHistory()[0].GetActorId() != "alice" {
+		t.Fatalf("expected the 2 assignments made by the actor, got %v", history.GetHistory())
+	}
+}
+
+func TestGrpcReviewStream(t *testing.T) {
+	conn, useCase := makeTestClient(t)
+	addTestTeam(t, conn, "backend", nil, "alice", "bob")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The status of the server-streaming call is returned on receiving the first message.
+	unknown, err := pb.NewUserServiceClient(conn).StreamReview(ctx, &pb.StreamReviewRequest{UserId: "dave"})
+	if err == nil {
+		_, err = unknown.Recv()
+	}
+	checkStatusErr(t, err, codes.NotFound, chttp.NotFound)
+
+	stream, err := pb.NewUserServiceClient(conn).StreamReview(ctx, &pb.StreamReviewRequest{UserId: "bob"})
+	if err != nil {
+		t.Fatalf("couldn't open the stream: %s", err)
+	}
+
+	if _, err := stream.Header(); err != nil {
+		t.Fatalf("couldn't subscribe the stream: %s", err)
+	}
+
+	_, err = pb.NewPullRequestServiceClient(conn).CreatePullRequest(ctx, &pb.CreatePullRequestRequest{
+		PullRequestId:   "pr-1",
+		PullRequestName: "Add search",
+		AuthorId:        "alice",
+	})
+	if err != nil {
+		t.Fatalf("couldn't create the PR: %s", err)
+	}
+
+	if _, err := useCase.DispatchOutboxEvents(ctx); err != nil {
+		t.Fatalf("couldn't dispatch the outbox: %s", err)
+	}
+
+	event, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("couldn't receive the event: %s", err)
+	}
+
+	if event.GetEvent() != string(entities.ReviewAssigned) || event.GetPullRequest().GetPullRequestId() != "pr-1" {
+		t.Fatalf("unexpected event: %v", event)
+	}
+
+	useCase.CloseReviewStreams()
+
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("expected the stream to be finished, got %v", err)
+	}
+}
+
+func TestGrpcHealth(t *testing.T) {
+	conn, _ := makeTestClient(t)
+	client := healthpb.NewHealthClient(conn)
+
+	for _, service := range []string{"", HealthServiceLive} {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			t.Fatalf("expected the %q service serving, got %v %v", service, resp, err)
+		}
+	}
+
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected the unknown service not found, got %v", err)
+	}
+}
+
+func TestGrpcAuth(t *testing.T) {
+	const bootstrap = "bootstrap-token-of-the-tests-0123456789"
+
+	conn, useCase := makeTestClient(t, WithTokenAuth(bootstrap))
+	teams := pb.NewTeamServiceClient(conn)
+
+	withToken := func(token string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), AuthMetadataKey, "Bearer "+token)
+	}
+
+	_, err := teams.GetTeam(context.Background(), &pb.GetTeamRequest{TeamName: "backend"})
+	checkStatusErr(t, err, codes.Unauthenticated, chttp.Unauthorized)
+
+	team := &pb.Team{TeamName: "backend", Members: []*pb.TeamMember{{UserId: "alice", Username: "Alice", IsActive: true}}}
+	if _, err := teams.AddTeam(withToken(bootstrap), &pb.AddTeamRequest{Team: team}); err != nil {
+		t.Fatalf("couldn't create the team with the bootstrap token: %s", err)
+	}
+
+	reader, err := useCase.CreateAPIToken(context.Background(), entities.APIToken{
+		Name:   "dashboard",
+		Scopes: []entities.Scope{entities.ScopeTeamsRead},
+	})
+	if err != nil {
+		t.Fatalf("couldn't create the token: %s", err)
+	}
+
+	if _, err := teams.GetTeam(withToken(reader.Token), &pb.GetTeamRequest{TeamName: "backend"}); err != nil {
+		t.Fatalf("couldn't get the team with the token: %s", err)
+	}
+
+	_, err = teams.AddTeam(withToken(reader.Token), &pb.AddTeamRequest{Team: &pb.Team{TeamName: "frontend"}})
+	checkStatusErr(t, err, codes.PermissionDenied, chttp.Forbidden)
+
+	_, err = pb.NewWebhookServiceClient(conn).GetDeliveries(withToken(reader.Token), &pb.GetDeliveriesRequest{})
+	checkStatusErr(t, err, codes.PermissionDenied, chttp.Forbidden)
+
+	stream, err := pb.NewUserServiceClient(conn).StreamReview(withToken(reader.Token), &pb.StreamReviewRequest{UserId: "alice"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	checkStatusErr(t, err, codes.PermissionDenied, chttp.Forbidden)
+
+	resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil || resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("expected the health check without the token, got %v %v", resp, err)
+	}
+}
+
+func TestGrpcPullRequestGetAndList(t *testing.T) {
+	conn, _ := makeTestClient(t)
+	addTestTeam(t, conn, "backend", nil, "alice", "bob", "carol")
+
+	pullReqs := pb.NewPullRequestServiceClient(conn)
+	ctx := context.Background()
+
+	for _, id := range []string{"pr-1", "pr-2", "pr-3"} {
+		_, err := pullReqs.CreatePullRequest(ctx, &pb.CreatePullRequestRequest{PullRequestId: id, PullRequestName: id, AuthorId: "alice"})
+		if err != nil {
+			t.Fatalf("couldn't create the PR: %s", err)
+		}
+	}
+
+	got, err := pullReqs.GetPullRequest(ctx, &pb.GetPullRequestRequest{PullRequestId: "pr-1"})
+	if err != nil {
+		t.Fatalf("couldn't get the PR: %s", err)
+	}
+
+	if details := got.GetPr(); details.GetPr().GetPullRequestId() != "pr-1" || details.GetAuthorTeamName() != "backend" ||
+		len(details.GetReviewers()) != 2 || details.GetReviewers()[0].GetUsername() == "" {
+		t.Fatalf("unexpected PR's details: %v", details)
+	}
+
+	_, err = pullReqs.GetPullRequest(ctx, &pb.GetPullRequestRequest{PullRequestId: "pr-4"})
+	checkStatusErr(t, err, codes.NotFound, chttp.NotFound)
+
+	page, err := pullReqs.ListPullRequests(ctx, &pb.ListPullRequestsRequest{SortBy: "pull_request_id", Order: "asc", Limit: 2})
+	if err != nil {
+		t.Fatalf("couldn't list the PRs: %s", err)
+	}
+
+	if prs := page.GetPullRequests(); len(prs) != 2 || prs[0].GetPullRequestId() != "pr-1" || len(page.GetNextCursor()) == 0 {
+		t.Fatalf("unexpected first page: %v", page)
+	}
+
+	page, err = pullReqs.ListPullRequests(ctx, &pb.ListPullRequestsRequest{Limit: 2, Cursor: page.GetNextCursor()})
+	if err != nil {
+		t.Fatalf("couldn't list the next PRs: %s", err)
+	}
+
+	if prs := page.GetPullRequests(); len(prs) != 1 || prs[0].GetPullRequestId() != "pr-3" || len(page.GetNextCursor()) != 0 {
+		t.Fatalf("unexpected last page: %v", page)
+	}
+
+	_, err = pullReqs.ListPullRequests(ctx, &pb.ListPullRequestsRequest{Status: "REVIEWED"})
+	checkStatusErr(t, err, codes.InvalidArgument, chttp.RequestDataErr)
+}
+
+func TestGrpcStats(t *testing.T) {
+	conn, _ := makeTestClient(t)
+	addTestTeam(t, conn, "backend", nil, "alice", "bob", "carol")
+
+	_, err := pb.NewPullRequestServiceClient(conn).CreatePullRequest(context.Background(),
+		&pb.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "alice"})
+	if err != nil {
+		t.Fatalf("couldn't create the PR: %s", err)
+	}
+
+	stats := pb.NewStatsServiceClient(conn)
+
+	resp, err := stats.GetStats(context.Background(), &pb.GetStatsRequest{TeamName: "backend"})
+	if err != nil {
+		t.Fatalf("couldn't get the stats: %s", err)
+	}
+
+	assigned := 0
+	for _, user := range resp.GetUsers() {
+		assigned += int(user.GetAssignedPullRequests())
+	}
+
+	if assigned != 2 || len(resp.GetTeams()) != 1 || resp.GetTeams()[0].GetAssignedPullRequests() != 1 ||
+		resp.GetTeams()[0].MeanMergeTimeSeconds != nil {
+		t.Fatalf("unexpected stats: %v", resp)
+	}
+
+	now := time.Now()
+	_, err = stats.GetStats(context.Background(), &pb.GetStatsRequest{From: timestamppb.New(now), To: timestamppb.New(now)})
+	checkStatusErr(t, err, codes.InvalidArgument, chttp.RequestDataErr)
+
+	_, err = stats.GetStats(context.Background(), &pb.GetStatsRequest{TeamName: "frontend"})
+	checkStatusErr(t, err, codes.NotFound, chttp.NotFound)
+}
+
+func TestGrpcWebhooks(t *testing.T) {
+	conn, useCase := makeTestClient(t)
+	addTestTeam(t, conn, "backend", nil, "alice", "bob", "carol")
+
+	teams := pb.NewTeamServiceClient(conn)
+	ctx := context.Background()
+
+	added, err := teams.AddWebhook(ctx, &pb.AddWebhookRequest{Webhook: &pb.Webhook{
+		TeamName: "backend",
+		Url:      "http://127.0.0.1:1/hook",
+		Secret:   "webhook-secret",
+	}})
+	if err != nil {
+		t.Fatalf("couldn't add the webhook: %s", err)
+	}
+
+	if webhook := added.GetWebhook(); webhook.GetWebhookId() <= 0 || len(webhook.GetSecret()) != 0 {
+		t.Fatalf("expected the webhook's id without the secret, got %v", webhook)
+	}
+
+	_, err = teams.AddWebhook(ctx, &pb.AddWebhookRequest{Webhook: &pb.Webhook{TeamName: "backend", Url: "ftp://hooks"}})
+	checkStatusErr(t, err, codes.InvalidArgument, chttp.RequestDataErr)
+
+	_, err = pb.NewPullRequestServiceClient(conn).CreatePullRequest(ctx,
+		&pb.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "alice"})
+	if err != nil {
+		t.Fatalf("couldn't create the PR: %s", err)
+	}
+
+	if _, err := useCase.DispatchOutboxEvents(ctx); err != nil {
+		t.Fatalf("couldn't dispatch the outbox's events: %s", err)
+	}
+
+	webhooks, err := teams.GetWebhooks(ctx, &pb.GetWebhooksRequest{TeamName: "backend"})
+	if err != nil || len(webhooks.GetWebhooks()) != 1 {
+		t.Fatalf("expected the team's webhook, got %v %v", webhooks, err)
+	}
+
+	deliveries, err := pb.NewWebhookServiceClient(conn).GetDeliveries(ctx, &pb.GetDeliveriesRequest{TeamName: "backend"})
+	if err != nil {
+		t.Fatalf("couldn't get the deliveries: %s", err)
+	}
+
+	if res := deliveries.GetDeliveries(); len(res) != 1 || res[0].GetEvent() != string(entities.ReviewerAssignedEvent) ||
+		res[0].GetStatus() != string(entities.DeliveryPending) || len(res[0].GetPayload()) == 0 {
+		t.Fatalf("expected the pending delivery of the assignment, got %v", res)
+	}
+
+	_, err = pb.NewWebhookServiceClient(conn).GetDeliveries(ctx, &pb.GetDeliveriesRequest{Status: "SENT"})
+	checkStatusErr(t, err, codes.InvalidArgument, chttp.RequestDataErr)
+
+	removed := &pb.RemoveWebhookRequest{TeamName: "backend", WebhookId: added.GetWebhook().GetWebhookId()}
+	if _, err := teams.RemoveWebhook(ctx, removed); err != nil {
+		t.Fatalf("couldn't remove the webhook: %s", err)
+	}
+
+	_, err = teams.RemoveWebhook(ctx, removed)
+	checkStatusErr(t, err, codes.NotFound, chttp.NotFound)
+}
@@ -0,0 +1,260 @@
+package cgrpc
+
+import (
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/controller/cgrpc/pb"
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func teamToPb(team dto.TeamDTO) *pb.Team {
+	res := &pb.Team{
+		TeamName:         team.Name,
+		Members:          make([]*pb.TeamMember, 0, len(team.Members)),
+		ReviewerStrategy: string(team.ReviewerStrategy),
+		MergePolicy:      mergePolicyToPb(team.MergePolicy),
+	}
+
+	for _, member := range team.Members {
+		res.Members = append(res.Members, &pb.TeamMember{
+			UserId:   string(member.ID),
+			Username: member.Name,
+			IsActive: member.IsActive,
+			Role:     string(member.Role),
+		})
+	}
+
+	if team.ReviewerPolicy != nil {
+		res.ReviewerPolicy = &pb.ReviewerPolicy{
+			MinReviewers: int32(team.ReviewerPolicy.MinReviewers),
+			MaxReviewers: int32(team.ReviewerPolicy.MaxReviewers),
+		}
+	}
+	return res
+}
+
+func pbToTeam(team *pb.Team) entities.Team {
+	res := entities.Team{
+		Name:             team.GetTeamName(),
+		Members:          make([]entities.User, 0, len(team.GetMembers())),
+		ReviewerStrategy: entities.ReviewerStrategy(team.GetReviewerStrategy()),
+		ReviewerPolicy: entities.ReviewerPolicy{
+			MinReviewers: int(team.GetReviewerPolicy().GetMinReviewers()),
+			MaxReviewers: int(team.GetReviewerPolicy().GetMaxReviewers()),
+		},
+		MergePolicy: pbToMergePolicy(team.GetMergePolicy()),
+	}
+
+	for _, member := range team.GetMembers() {
+		res.Members = append(res.Members, entities.User{
+			ID:       entities.UserID(member.GetUserId()),
+			Name:     member.GetUsername(),
+			IsActive: member.GetIsActive(),
+			Role:     entities.UserRole(member.GetRole()),
+		})
+	}
+	return res
+}
+
+func mergePolicyToPb(policy entities.MergePolicy) *pb.MergePolicy {
+	return &pb.MergePolicy{
+		RequiredApprovals:      int32(policy.RequiredApprovals),
+		RequireResolvedChanges: policy.RequireResolvedChanges,
+		AllowAuthorApproval:    policy.AllowAuthorApproval,
+	}
+}
+
+func pbToMergePolicy(policy *pb.MergePolicy) entities.MergePolicy {
+	return entities.MergePolicy{
+		RequiredApprovals:      int(policy.GetRequiredApprovals()),
+		RequireResolvedChanges: policy.GetRequireResolvedChanges(),
+		AllowAuthorApproval:    policy.GetAllowAuthorApproval(),
+	}
+}
+
+func userToPb(user entities.User) *pb.User {
+	return &pb.User{
+		UserId:   string(user.ID),
+		Username: user.Name,
+		TeamName: user.TeamName,
+		IsActive: user.IsActive,
+	}
+}
+
+func pullRequestToPb(pullReq dto.PullRequestDTO) *pb.PullRequest {
+	res := &pb.PullRequest{
+		PullRequestId:     string(pullReq.ID),
+		PullRequestName:   pullReq.Name,
+		AuthorId:          string(pullReq.AuthorID),
+		Status:            string(pullReq.Status),
+		AssignedReviewers: make([]string, 0, len(pullReq.Reviewers)),
+	}
+
+	for _, id := range pullReq.Reviewers {
+		res.AssignedReviewers = append(res.AssignedReviewers, string(id))
+	}
+
+	if pullReq.ReviewersCount != nil {
+		res.ReviewersCount = new(int32)
+		(*res.ReviewersCount) = int32(*pullReq.ReviewersCount)
+	}
+
+	if len(pullReq.Decisions) != 0 {
+		res.ReviewDecisions = make(map[string]string, len(pullReq.Decisions))
+		for id, decision := range pullReq.Decisions {
+			res.ReviewDecisions[string(id)] = string(decision)
+		}
+	}
+
+	if pullReq.CreatedAt != nil {
+		res.CreatedAt = timestamppb.New(*pullReq.CreatedAt)
+	}
+
+	if pullReq.MergedAt != nil {
+		res.MergedAt = timestamppb.New(*pullReq.MergedAt)
+	}
+	return res
+}
+
+func pullRequestShortToPb(pullReq dto.PullRequestDTOShort) *pb.PullRequestShort {
+	return &pb.PullRequestShort{
+		PullRequestId:   string(pullReq.ID),
+		PullRequestName: pullReq.Name,
+		AuthorId:        string(pullReq.AuthorID),
+		Status:          string(pullReq.Status),
+		ReviewDecision:  string(pullReq.Decision),
+	}
+}
+
+func reviewerChangesToPb(changes []dto.ReviewerChangeDTO) []*pb.ReviewerChange {
+	res := make([]*pb.ReviewerChange, 0, len(changes))
+	for _, change := range changes {
+		res = append(res, &pb.ReviewerChange{
+			PullRequestId: string(change.ID),
+			OldReviewerId: string(change.OldReviewerID),
+			NewReviewerId: string(change.NewReviewerID),
+		})
+	}
+	return res
+}
+
+func reviewerAssignmentToPb(assignment dto.ReviewerAssignmentDTO) *pb.ReviewerAssignment {
+	return &pb.ReviewerAssignment{
+		PullRequestId: string(assignment.ID),
+		OldReviewerId: string(assignment.OldReviewerID),
+		NewReviewerId: string(assignment.NewReviewerID),
+		Reason:        string(assignment.Reason),
+		ActorId:       string(assignment.ActorID),
+		ChangedAt:     timestamppb.New(assignment.ChangedAt),
+	}
+}
+
+func userIDsToStrings(ids []entities.UserID) []string {
+	res := make([]string, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, string(id))
+	}
+	return res
+}
+
+func pullRequestDetailsToPb(pullReq dto.PullRequestDetailsDTO) *pb.PullRequestDetails {
+	res := &pb.PullRequestDetails{
+		Pr:                 pullRequestToPb(pullReq.PullRequestDTO),
+		AuthorTeamName:     pullReq.AuthorTeamName,
+		Reviewers:          make([]*pb.ReviewerDetails, 0, len(pullReq.ReviewersDetails)),
+		ReassignmentsCount: int32(pullReq.ReassignmentsCount),
+	}
+
+	for _, reviewer := range pullReq.ReviewersDetails {
+		res.Reviewers = append(res.Reviewers, &pb.ReviewerDetails{
+			UserId:         string(reviewer.ID),
+			Username:       reviewer.Name,
+			IsActive:       reviewer.IsActive,
+			ReviewDecision: string(reviewer.Decision),
+		})
+	}
+	return res
+}
+
+func webhookToPb(webhook entities.Webhook) *pb.Webhook {
+	return &pb.Webhook{
+		WebhookId: int64(webhook.ID),
+		TeamName:  webhook.TeamName,
+		Url:       webhook.URL,
+		Secret:    webhook.Secret,
+	}
+}
+
+func webhookDeliveryToPb(delivery entities.WebhookDelivery) *pb.WebhookDelivery {
+	res := &pb.WebhookDelivery{
+		DeliveryId:     int64(delivery.ID),
+		WebhookId:      int64(delivery.WebhookID),
+		EventId:        int64(delivery.EventID),
+		TeamName:       delivery.TeamName,
+		Url:            delivery.URL,
+		Event:          string(delivery.Event),
+		Payload:        string(delivery.Payload),
+		Status:         string(delivery.Status),
+		Attempts:       int32(delivery.Attempts),
+		NextAttemptAt:  timestamppb.New(delivery.NextAttemptAt),
+		LastStatusCode: int32(delivery.LastStatusCode),
+		LastError:      delivery.LastError,
+		CreatedAt:      timestamppb.New(delivery.CreatedAt),
+	}
+
+	if delivery.DeliveredAt != nil {
+		res.DeliveredAt = timestamppb.New(*delivery.DeliveredAt)
+	}
+	return res
+}
+
+func statsToPb(stats dto.StatsDTO) *pb.GetStatsResponse {
+	res := &pb.GetStatsResponse{
+		Users: make([]*pb.UserStats, 0, len(stats.Users)),
+		Teams: make([]*pb.TeamStats, 0, len(stats.Teams)),
+	}
+
+	for _, user := range stats.Users {
+		res.Users = append(res.Users, &pb.UserStats{
+			UserId:               string(user.ID),
+			TeamName:             user.TeamName,
+			AssignedPullRequests: int32(user.Assigned),
+			OpenPullRequests:     int32(user.Open),
+			MergedPullRequests:   int32(user.Merged),
+			Reassignments:        int32(user.Reassignments),
+			MeanMergeTimeSeconds: user.MeanMergeTime,
+		})
+	}
+
+	for _, team := range stats.Teams {
+		res.Teams = append(res.Teams, &pb.TeamStats{
+			TeamName:             team.TeamName,
+			AssignedPullRequests: int32(team.Assigned),
+			OpenPullRequests:     int32(team.Open),
+			MergedPullRequests:   int32(team.Merged),
+			Reassignments:        int32(team.Reassignments),
+			MeanMergeTimeSeconds: team.MeanMergeTime,
+		})
+	}
+
+	if stats.From != nil {
+		res.From = timestamppb.New(*stats.From)
+	}
+
+	if stats.To != nil {
+		res.To = timestamppb.New(*stats.To)
+	}
+	return res
+}
+
+// pbToTime returns the moment of the timestamp or nil if it isn't set.
+func pbToTime(timestamp *timestamppb.Timestamp) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+
+	moment := timestamp.AsTime()
+	return &moment
+}
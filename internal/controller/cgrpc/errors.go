@@ -0,0 +1,110 @@
+package cgrpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MaKcm14/pr-service/internal/controller/chttp"
+	"github.com/MaKcm14/pr-service/internal/services"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrStartingServer = errors.New("cgrpc: error of starting the server")
+)
+
+var (
+	ErrRequestParam = errors.New("cgrpc: error of the request param's domain")
+)
+
+// ErrDomain defines the domain of the errors' details returned with the calls' statuses.
+const ErrDomain = "pr-service"
+
+// statusCodes defines the gRPC status codes mirroring the HTTP status codes of the same errors.
+var statusCodes = map[chttp.ErrCode]codes.Code{
+	chttp.RequestDataErr:    codes.InvalidArgument,
+	chttp.NotFound:          codes.NotFound,
+	chttp.TeamExists:        codes.AlreadyExists,
+	chttp.PrExists:          codes.AlreadyExists,
+	chttp.PrMerged:          codes.FailedPrecondition,
+	chttp.NotAssigned:       codes.FailedPrecondition,
+	chttp.NoCandidate:       codes.FailedPrecondition,
+	chttp.InvalidTransition: codes.FailedPrecondition,
+	chttp.MergeBlocked:      codes.FailedPrecondition,
+	chttp.ServerErr:         codes.Internal,
+	chttp.Unauthorized:      codes.Unauthenticated,
+	chttp.Forbidden:         codes.PermissionDenied,
+}
+
+// newStatusErr returns the call's error with the same code and message as the HTTP API's error response.
+// The code is kept in the status' ErrorInfo as the reason.
+func newStatusErr(code chttp.ErrCode, message error) error {
+	st, err := status.New(statusCodes[code], message.Error()).WithDetails(&errdetails.ErrorInfo{
+		Reason: string(code),
+		Domain: ErrDomain,
+	})
+	if err != nil {
+		return status.Error(statusCodes[code], message.Error())
+	}
+	return st.Err()
+}
+
+// newMergeBlockedErr returns the call's error with the merge policy's unmet rules
+// kept in the status' PreconditionFailure.
+func newMergeBlockedErr(blocked *services.MergeBlockedError) error {
+	failure := &errdetails.PreconditionFailure{}
+	for _, violation := range blocked.Violations {
+		failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:        string(violation.Rule),
+			Description: violation.Message,
+		})
+	}
+
+	st, err := status.New(statusCodes[chttp.MergeBlocked], chttp.ErrRespQueryMergeBlocked.Error()).WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: string(chttp.MergeBlocked),
+			Domain: ErrDomain,
+		}, failure)
+	if err != nil {
+		return status.Error(statusCodes[chttp.MergeBlocked], chttp.ErrRespQueryMergeBlocked.Error())
+	}
+	return st.Err()
+}
+
+// toStatusErr defines the logic of converting the use-case's error to the call's error
+// the same way as the HTTP API responds with it.
+func (g *GrpcController) toStatusErr(op string, err error) error {
+	if errors.Is(err, services.ErrEntityNotFound) {
+		return newStatusErr(chttp.NotFound, chttp.ErrRespQueryNotFound)
+
+	} else if errors.Is(err, services.ErrEntityAlreadyExists) {
+		return newStatusErr(chttp.PrExists, chttp.ErrRespQueryAlreadyExists)
+
+	} else if errors.Is(err, services.ErrDomainRulesWithROState) {
+		return newStatusErr(chttp.PrMerged, chttp.ErrRespQueryOpIsRestrict)
+
+	} else if errors.Is(err, services.ErrDomainRulesNoCandidate) {
+		return newStatusErr(chttp.NoCandidate, chttp.ErrRespQueryNoCandidate)
+
+	} else if errors.Is(err, services.ErrWrongCandidate) {
+		return newStatusErr(chttp.NotAssigned, chttp.ErrRespQueryWrongCandidate)
+
+	} else if errors.Is(err, services.ErrDomainRulesTransition) {
+		return newStatusErr(chttp.InvalidTransition, chttp.ErrRespQueryInvalidTransition)
+
+	} else if errors.Is(err, services.ErrDomainRulesPolicy) {
+		return newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryReviewersCount)
+
+	} else if errors.Is(err, services.ErrForbidden) {
+		return newStatusErr(chttp.Forbidden, chttp.ErrRespQueryNotPermitted)
+	}
+
+	if blocked := new(services.MergeBlockedError); errors.As(err, &blocked) {
+		return newMergeBlockedErr(blocked)
+	}
+	g.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+	return newStatusErr(chttp.ServerErr, chttp.ErrRespQueryServerError)
+}
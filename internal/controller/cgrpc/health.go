@@ -0,0 +1,33 @@
+package cgrpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// HealthServiceLive defines the health-checking service's name for checking the service is alive.
+// The empty name checks the service is ready to serve the traffic as the /health/ready endpoint.
+const HealthServiceLive = "live"
+
+// Check defines the logic of handling the call of the standard health-checking protocol.
+func (g *GrpcController) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	switch req.GetService() {
+	case HealthServiceLive:
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+
+	case "":
+		ctx, cancel := context.WithTimeout(ctx, time.Second*2)
+		defer cancel()
+
+		if _, err := g.useCase.CheckReadiness(ctx); err != nil {
+			return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+		}
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+	}
+
+	return nil, status.Error(codes.NotFound, "unknown service")
+}
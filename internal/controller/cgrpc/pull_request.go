@@ -0,0 +1,285 @@
+package cgrpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/controller/cgrpc/pb"
+	"github.com/MaKcm14/pr-service/internal/controller/chttp"
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+)
+
+// CreatePullRequest defines the logic of handling the call for creating the pull-request.
+func (g *GrpcController) CreatePullRequest(
+	ctx context.Context,
+	req *pb.CreatePullRequestRequest,
+) (*pb.CreatePullRequestResponse, error) {
+	const op = "cgrpc.create-pull-request"
+
+	pullReq := dto.NewPullRequestDTO()
+	pullReq.ID = entities.PullRequestID(req.GetPullRequestId())
+	pullReq.Name = req.GetPullRequestName()
+	pullReq.AuthorID = entities.UserID(req.GetAuthorId())
+	pullReq.Status = entities.PullRequestStatus(req.GetStatus())
+
+	if req.ReviewersCount != nil {
+		pullReq.ReviewersCount = new(int)
+		(*pullReq.ReviewersCount) = int(req.GetReviewersCount())
+	}
+
+	if len(pullReq.Status) == 0 {
+		pullReq.Status = entities.Open
+	} else if pullReq.Status != entities.Open && pullReq.Status != entities.Draft {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongStatus)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	res, err := g.useCase.CreatePullRequest(ctx, pullReq)
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	return &pb.CreatePullRequestResponse{Pr: pullRequestToPb(res)}, nil
+}
+
+// MergePullRequest defines the logic of handling the call for merge the requested PR.
+func (g *GrpcController) MergePullRequest(
+	ctx context.Context,
+	req *pb.ChangePullRequestStatusRequest,
+) (*pb.ChangePullRequestStatusResponse, error) {
+	return g.changePullRequestStatus(ctx, "cgrpc.merge-pull-request", req, "", entities.Merged)
+}
+
+// ClosePullRequest defines the logic of handling the call for closing the requested PR without merge.
+func (g *GrpcController) ClosePullRequest(
+	ctx context.Context,
+	req *pb.ChangePullRequestStatusRequest,
+) (*pb.ChangePullRequestStatusResponse, error) {
+	return g.changePullRequestStatus(ctx, "cgrpc.close-pull-request", req, "", entities.Closed)
+}
+
+// ReopenPullRequest defines the logic of handling the call for reopening the closed PR.
+func (g *GrpcController) ReopenPullRequest(
+	ctx context.Context,
+	req *pb.ChangePullRequestStatusRequest,
+) (*pb.ChangePullRequestStatusResponse, error) {
+	return g.changePullRequestStatus(ctx, "cgrpc.reopen-pull-request", req, entities.Closed, entities.Open)
+}
+
+// ReadyPullRequest defines the logic of handling the call for marking the draft PR ready for the review.
+func (g *GrpcController) ReadyPullRequest(
+	ctx context.Context,
+	req *pb.ChangePullRequestStatusRequest,
+) (*pb.ChangePullRequestStatusResponse, error) {
+	return g.changePullRequestStatus(ctx, "cgrpc.ready-pull-request", req, entities.Draft, entities.Open)
+}
+
+// changePullRequestStatus defines the common logic of handling the calls for changing the PR's status.
+// The current PR's status must be equal to the from one if it's set.
+func (g *GrpcController) changePullRequestStatus(
+	ctx context.Context,
+	op string,
+	req *pb.ChangePullRequestStatusRequest,
+	from entities.PullRequestStatus,
+	to entities.PullRequestStatus,
+) (*pb.ChangePullRequestStatusResponse, error) {
+	pullReq := dto.NewPullRequestDTO()
+	pullReq.ID = entities.PullRequestID(req.GetPullRequestId())
+	pullReq.Status = from
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	res, err := g.useCase.SetPullRequestStatus(ctx, to, pullReq)
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	return &pb.ChangePullRequestStatusResponse{Pr: pullRequestToPb(res)}, nil
+}
+
+// ReviewPullRequest defines the logic of handling the call for keeping the reviewer's decision on the PR.
+func (g *GrpcController) ReviewPullRequest(
+	ctx context.Context,
+	req *pb.ReviewPullRequestRequest,
+) (*pb.ReviewPullRequestResponse, error) {
+	const op = "cgrpc.review-pull-request"
+
+	review := dto.PullRequestReviewDTO{
+		ID:         entities.PullRequestID(req.GetPullRequestId()),
+		ReviewerID: entities.UserID(req.GetReviewerId()),
+		Decision:   entities.ReviewDecision(req.GetDecision()),
+	}
+
+	if len(review.ID) == 0 || len(review.ReviewerID) == 0 {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongRequestData)
+	}
+
+	if !review.Decision.IsValid() {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongDecision)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	res, err := g.useCase.ReviewPullRequest(ctx, review)
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	return &pb.ReviewPullRequestResponse{Pr: pullRequestToPb(res)}, nil
+}
+
+// ReassignReviewer defines the logic of handling the call for reassigning the PR's reviewer.
+func (g *GrpcController) ReassignReviewer(
+	ctx context.Context,
+	req *pb.ReassignReviewerRequest,
+) (*pb.ReassignReviewerResponse, error) {
+	const op = "cgrpc.reassign-reviewer"
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	res, newID, err := g.useCase.ReassignUser(ctx, dto.PullRequestChangeReviewerDTO{
+		ID:            entities.PullRequestID(req.GetPullRequestId()),
+		OldReviewerID: entities.UserID(req.GetOldReviewerId()),
+	})
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	return &pb.ReassignReviewerResponse{
+		Pr:         pullRequestToPb(res),
+		ReplacedBy: string(newID),
+	}, nil
+}
+
+// GetPullRequestHistory defines the logic of handling the call for getting the PR's reviewers changes.
+func (g *GrpcController) GetPullRequestHistory(
+	ctx context.Context,
+	req *pb.GetPullRequestHistoryRequest,
+) (*pb.GetPullRequestHistoryResponse, error) {
+	const op = "cgrpc.get-pull-request-history"
+
+	if len(req.GetPullRequestId()) == 0 {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryEmptyParam)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	res, err := g.useCase.GetPullRequestHistory(ctx, entities.PullRequestID(req.GetPullRequestId()))
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	resp := &pb.GetPullRequestHistoryResponse{
+		PullRequestId: req.GetPullRequestId(),
+		History:       make([]*pb.ReviewerAssignment, 0, len(res)),
+	}
+	for _, assignment := range res {
+		resp.History = append(resp.History, reviewerAssignmentToPb(assignment))
+	}
+
+	return resp, nil
+}
+
+// GetPullRequest defines the logic of handling the call for getting the PR with the data of its participants.
+func (g *GrpcController) GetPullRequest(
+	ctx context.Context,
+	req *pb.GetPullRequestRequest,
+) (*pb.GetPullRequestResponse, error) {
+	const op = "cgrpc.get-pull-request"
+
+	if len(req.GetPullRequestId()) == 0 {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryEmptyParam)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	res, err := g.useCase.GetPullRequest(ctx, entities.PullRequestID(req.GetPullRequestId()))
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	return &pb.GetPullRequestResponse{Pr: pullRequestDetailsToPb(res)}, nil
+}
+
+// ListPullRequests defines the logic of handling the call for the page of the filtered PRs.
+func (g *GrpcController) ListPullRequests(
+	ctx context.Context,
+	req *pb.ListPullRequestsRequest,
+) (*pb.ListPullRequestsResponse, error) {
+	const op = "cgrpc.list-pull-requests"
+
+	filter, err := pbToPullRequestsFilter(req)
+	if err != nil {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongListFilter)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	res, err := g.useCase.ListPullRequests(ctx, filter)
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	resp := &pb.ListPullRequestsResponse{
+		PullRequests: make([]*pb.PullRequest, 0, len(res.PullRequests)),
+		NextCursor:   res.NextCursor,
+	}
+	for _, pullReq := range res.PullRequests {
+		resp.PullRequests = append(resp.PullRequests, pullRequestToPb(pullReq))
+	}
+
+	return resp, nil
+}
+
+// pbToPullRequestsFilter checks whether the params of the PRs' listing are correct
+// the same way as the HTTP API checks them.
+func pbToPullRequestsFilter(req *pb.ListPullRequestsRequest) (dto.PullRequestsFilterDTO, error) {
+	filter := dto.PullRequestsFilterDTO{
+		AuthorID:      entities.UserID(req.GetAuthorId()),
+		ReviewerID:    entities.UserID(req.GetReviewerId()),
+		TeamName:      req.GetTeamName(),
+		Status:        entities.PullRequestStatus(req.GetStatus()),
+		CreatedAfter:  pbToTime(req.GetCreatedAfter()),
+		CreatedBefore: pbToTime(req.GetCreatedBefore()),
+		SortBy:        dto.PullRequestsSortKey(req.GetSortBy()),
+		Order:         dto.SortOrder(req.GetOrder()),
+		Limit:         int(req.GetLimit()),
+	}
+
+	if len(filter.Status) != 0 && !filter.Status.IsValid() {
+		return dto.PullRequestsFilterDTO{}, fmt.Errorf("error of the %s: wrong status", ErrRequestParam)
+	} else if len(filter.SortBy) != 0 && !filter.SortBy.IsValid() {
+		return dto.PullRequestsFilterDTO{}, fmt.Errorf("error of the %s: wrong sort_by", ErrRequestParam)
+	} else if len(filter.Order) != 0 && !filter.Order.IsValid() {
+		return dto.PullRequestsFilterDTO{}, fmt.Errorf("error of the %s: wrong order", ErrRequestParam)
+	} else if filter.Limit < 0 {
+		return dto.PullRequestsFilterDTO{}, fmt.Errorf("error of the %s: wrong limit", ErrRequestParam)
+	}
+
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return dto.PullRequestsFilterDTO{}, fmt.Errorf("error of the %s: 'created_after' must be before 'created_before'", ErrRequestParam)
+	}
+
+	if len(req.GetCursor()) != 0 {
+		cursor, err := dto.DecodePullRequestsCursor(req.GetCursor())
+		if err != nil {
+			return dto.PullRequestsFilterDTO{}, fmt.Errorf("error of the %s: %w", ErrRequestParam, err)
+		}
+
+		if err := filter.SetAfter(cursor); err != nil {
+			return dto.PullRequestsFilterDTO{}, fmt.Errorf("error of the %s: %w", ErrRequestParam, err)
+		}
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,37 @@
+package cgrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/controller/cgrpc/pb"
+	"github.com/MaKcm14/pr-service/internal/controller/chttp"
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+)
+
+// GetStats defines the logic of handling the call for getting the review's statistics.
+func (g *GrpcController) GetStats(ctx context.Context, req *pb.GetStatsRequest) (*pb.GetStatsResponse, error) {
+	const op = "cgrpc.get-stats"
+
+	filter := dto.StatsFilterDTO{
+		TeamName: req.GetTeamName(),
+		UserID:   entities.UserID(req.GetUserId()),
+		From:     pbToTime(req.GetFrom()),
+		To:       pbToTime(req.GetTo()),
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongTimeWindow)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	res, err := g.useCase.GetStats(ctx, filter)
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	return statsToPb(res), nil
+}
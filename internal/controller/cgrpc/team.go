@@ -0,0 +1,203 @@
+package cgrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/controller/cgrpc/pb"
+	"github.com/MaKcm14/pr-service/internal/controller/chttp"
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+// AddTeam defines the logic of handling the call for adding the team.
+func (g *GrpcController) AddTeam(ctx context.Context, req *pb.AddTeamRequest) (*pb.AddTeamResponse, error) {
+	const op = "cgrpc.add-team"
+
+	if req.GetTeam() == nil {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongRequestData)
+	}
+	team := pbToTeam(req.GetTeam())
+
+	if len(team.ReviewerStrategy) != 0 && !team.ReviewerStrategy.IsValid() {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongStrategy)
+	}
+
+	if !team.ReviewerPolicy.IsZero() && !team.ReviewerPolicy.IsValid() {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongPolicy)
+	}
+
+	if !team.MergePolicy.IsValid() {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongMergePolicy)
+	}
+
+	for _, member := range team.Members {
+		if len(member.Role) != 0 && !member.Role.IsValid() {
+			return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongRole)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	res, err := g.useCase.CreateTeam(ctx, team)
+	if err != nil {
+		if errors.Is(err, repo.ErrCreateMultipleUniqueModels) || errors.Is(err, repo.ErrModelAlreadyExists) {
+			return nil, newStatusErr(chttp.TeamExists, chttp.ErrRespQueryAlreadyExists)
+
+		} else if errors.Is(err, services.ErrForbidden) {
+			return nil, newStatusErr(chttp.Forbidden, chttp.ErrRespQueryNotPermitted)
+		}
+		g.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+		return nil, newStatusErr(chttp.ServerErr, chttp.ErrRespQueryServerError)
+	}
+
+	return &pb.AddTeamResponse{Team: teamToPb(res)}, nil
+}
+
+// GetTeam defines the logic of handling the call for getting the team.
+func (g *GrpcController) GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.GetTeamResponse, error) {
+	const op = "cgrpc.get-team"
+
+	if len(req.GetTeamName()) == 0 {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryEmptyParam)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	team, err := g.useCase.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	return &pb.GetTeamResponse{Team: teamToPb(team)}, nil
+}
+
+// SetReviewerStrategy defines the logic of handling the call for changing the team's
+// strategy of choosing the reviewers.
+func (g *GrpcController) SetReviewerStrategy(
+	ctx context.Context,
+	req *pb.SetReviewerStrategyRequest,
+) (*pb.SetReviewerStrategyResponse, error) {
+	const op = "cgrpc.set-reviewer-strategy"
+
+	if len(req.GetTeamName()) == 0 {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongRequestData)
+	}
+
+	strategy := entities.ReviewerStrategy(req.GetReviewerStrategy())
+	if !strategy.IsValid() {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongStrategy)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	team, err := g.useCase.SetTeamReviewerStrategy(ctx, req.GetTeamName(), strategy)
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	return &pb.SetReviewerStrategyResponse{Team: teamToPb(team)}, nil
+}
+
+// SetReviewerPolicy defines the logic of handling the call for changing the team's
+// bounds of the PR's reviewers count.
+func (g *GrpcController) SetReviewerPolicy(
+	ctx context.Context,
+	req *pb.SetReviewerPolicyRequest,
+) (*pb.SetReviewerPolicyResponse, error) {
+	const op = "cgrpc.set-reviewer-policy"
+
+	if len(req.GetTeamName()) == 0 {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongRequestData)
+	}
+
+	policy := entities.ReviewerPolicy{
+		MinReviewers: int(req.GetMinReviewers()),
+		MaxReviewers: int(req.GetMaxReviewers()),
+	}
+	if !policy.IsValid() {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongPolicy)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	team, err := g.useCase.SetTeamReviewerPolicy(ctx, req.GetTeamName(), policy)
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	return &pb.SetReviewerPolicyResponse{Team: teamToPb(team)}, nil
+}
+
+// SetMergePolicy defines the logic of handling the call for changing the team's conditions of the PR's merge.
+func (g *GrpcController) SetMergePolicy(
+	ctx context.Context,
+	req *pb.SetMergePolicyRequest,
+) (*pb.SetMergePolicyResponse, error) {
+	const op = "cgrpc.set-merge-policy"
+
+	if len(req.GetTeamName()) == 0 {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongRequestData)
+	}
+
+	policy := pbToMergePolicy(req.GetMergePolicy())
+	if !policy.IsValid() {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongMergePolicy)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	team, err := g.useCase.SetTeamMergePolicy(ctx, req.GetTeamName(), policy)
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	return &pb.SetMergePolicyResponse{Team: teamToPb(team)}, nil
+}
+
+// DeactivateUsers defines the logic of handling the call for deactivating the team's members
+// with reassigning their open reviews.
+func (g *GrpcController) DeactivateUsers(
+	ctx context.Context,
+	req *pb.DeactivateUsersRequest,
+) (*pb.DeactivateUsersResponse, error) {
+	const op = "cgrpc.deactivate-users"
+
+	deactivation := dto.TeamDeactivateUsersDTO{
+		Name:    req.GetTeamName(),
+		UserIDs: make([]entities.UserID, 0, len(req.GetUserIds())),
+	}
+	for _, id := range req.GetUserIds() {
+		deactivation.UserIDs = append(deactivation.UserIDs, entities.UserID(id))
+	}
+
+	if len(deactivation.Name) == 0 || len(deactivation.UserIDs) == 0 || slices.Contains(deactivation.UserIDs, "") {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryEmptyParam)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	res, err := g.useCase.DeactivateTeamUsers(ctx, deactivation)
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	return &pb.DeactivateUsersResponse{
+		TeamName:            res.Name,
+		DeactivatedUsers:    userIDsToStrings(res.Deactivated),
+		ReassignedReviewers: reviewerChangesToPb(res.Reassigned),
+		UnassignedReviewers: reviewerChangesToPb(res.Unassigned),
+	}, nil
+}
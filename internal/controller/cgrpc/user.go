@@ -0,0 +1,101 @@
+package cgrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/controller/cgrpc/pb"
+	"github.com/MaKcm14/pr-service/internal/controller/chttp"
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// SetIsActive defines the logic of handling the call for changing the user's activity.
+func (g *GrpcController) SetIsActive(ctx context.Context, req *pb.SetIsActiveRequest) (*pb.SetIsActiveResponse, error) {
+	const op = "cgrpc.set-is-active"
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	user, err := g.useCase.SetUserIsActive(ctx, req.GetIsActive(), entities.UserID(req.GetUserId()))
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	return &pb.SetIsActiveResponse{User: userToPb(user)}, nil
+}
+
+// GetReview defines the logic of handling the call for getting the reviewer's PRs.
+func (g *GrpcController) GetReview(ctx context.Context, req *pb.GetReviewRequest) (*pb.GetReviewResponse, error) {
+	const op = "cgrpc.get-review"
+
+	if len(req.GetUserId()) == 0 {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryEmptyParam)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	res, err := g.useCase.GetUserPullRequests(ctx, entities.UserID(req.GetUserId()))
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	resp := &pb.GetReviewResponse{
+		UserId:       req.GetUserId(),
+		PullRequests: make([]*pb.PullRequestShort, 0, len(res)),
+	}
+	for _, pullReq := range res {
+		resp.PullRequests = append(resp.PullRequests, pullRequestShortToPb(pullReq))
+	}
+
+	return resp, nil
+}
+
+// StreamReview defines the logic of handling the call for streaming the reviewer's queue changes.
+// The headers are sent as soon as the stream is subscribed, so the client knows no change is missed after them.
+// The stream is finished when the client cancels the call or the server is stopped.
+func (g *GrpcController) StreamReview(
+	req *pb.StreamReviewRequest,
+	stream grpc.ServerStreamingServer[pb.ReviewQueueEvent],
+) error {
+	const op = "cgrpc.stream-review"
+
+	if len(req.GetUserId()) == 0 {
+		return newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryEmptyParam)
+	}
+
+	ctx := stream.Context()
+
+	events, unsubscribe, err := g.useCase.SubscribeReviewQueue(ctx, entities.UserID(req.GetUserId()))
+	if err != nil {
+		return g.toStatusErr(op, err)
+	}
+	defer unsubscribe()
+
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			err := stream.Send(&pb.ReviewQueueEvent{
+				EventId:     int64(event.EventID),
+				Event:       string(event.Event),
+				PullRequest: pullRequestShortToPb(event.PullRequest),
+			})
+			if err != nil {
+				return nil
+			}
+		}
+	}
+}
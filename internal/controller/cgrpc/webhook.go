@@ -0,0 +1,117 @@
+package cgrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/controller/cgrpc/pb"
+	"github.com/MaKcm14/pr-service/internal/controller/chttp"
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+)
+
+// AddWebhook defines the logic of handling the call for registering the team's webhook.
+func (g *GrpcController) AddWebhook(ctx context.Context, req *pb.AddWebhookRequest) (*pb.AddWebhookResponse, error) {
+	const op = "cgrpc.add-webhook"
+
+	webhook := entities.Webhook{
+		TeamName: req.GetWebhook().GetTeamName(),
+		URL:      req.GetWebhook().GetUrl(),
+		Secret:   req.GetWebhook().GetSecret(),
+	}
+
+	if len(webhook.TeamName) == 0 {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongRequestData)
+	}
+
+	if !webhook.IsValid() {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongWebhook)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	res, err := g.useCase.AddTeamWebhook(ctx, webhook)
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	return &pb.AddWebhookResponse{Webhook: webhookToPb(res)}, nil
+}
+
+// RemoveWebhook defines the logic of handling the call for removing the team's webhook.
+func (g *GrpcController) RemoveWebhook(ctx context.Context, req *pb.RemoveWebhookRequest) (*pb.RemoveWebhookResponse, error) {
+	const op = "cgrpc.remove-webhook"
+
+	if len(req.GetTeamName()) == 0 || req.GetWebhookId() <= 0 {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongRequestData)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	if err := g.useCase.RemoveTeamWebhook(ctx, req.GetTeamName(), entities.WebhookID(req.GetWebhookId())); err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	return &pb.RemoveWebhookResponse{}, nil
+}
+
+// GetWebhooks defines the logic of handling the call for getting the team's webhooks.
+func (g *GrpcController) GetWebhooks(ctx context.Context, req *pb.GetWebhooksRequest) (*pb.GetWebhooksResponse, error) {
+	const op = "cgrpc.get-webhooks"
+
+	if len(req.GetTeamName()) == 0 {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryEmptyParam)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	res, err := g.useCase.GetTeamWebhooks(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	resp := &pb.GetWebhooksResponse{
+		TeamName: req.GetTeamName(),
+		Webhooks: make([]*pb.Webhook, 0, len(res)),
+	}
+	for _, webhook := range res {
+		resp.Webhooks = append(resp.Webhooks, webhookToPb(webhook))
+	}
+
+	return resp, nil
+}
+
+// GetDeliveries defines the logic of handling the call for inspecting the webhooks' deliveries.
+func (g *GrpcController) GetDeliveries(ctx context.Context, req *pb.GetDeliveriesRequest) (*pb.GetDeliveriesResponse, error) {
+	const op = "cgrpc.get-deliveries"
+
+	filter := dto.WebhookDeliveriesFilterDTO{
+		TeamName: req.GetTeamName(),
+		Status:   entities.DeliveryStatus(req.GetStatus()),
+		Limit:    int(req.GetLimit()),
+	}
+
+	if (len(filter.Status) != 0 && !filter.Status.IsValid()) || filter.Limit < 0 {
+		return nil, newStatusErr(chttp.RequestDataErr, chttp.ErrRespQueryWrongDeliveryFilter)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	res, err := g.useCase.GetWebhookDeliveries(ctx, filter)
+	if err != nil {
+		return nil, g.toStatusErr(op, err)
+	}
+
+	resp := &pb.GetDeliveriesResponse{
+		Deliveries: make([]*pb.WebhookDelivery, 0, len(res)),
+	}
+	for _, delivery := range res {
+		resp.Deliveries = append(resp.Deliveries, webhookDeliveryToPb(delivery))
+	}
+
+	return resp, nil
+}
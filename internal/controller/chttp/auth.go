@@ -0,0 +1,165 @@
+package chttp
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+const bearerPrefix = "Bearer "
+
+// requireScope returns the middleware authenticating the request's bearer token and checking it grants the scope.
+// The token's user is the request's actor instead of the X-Actor-ID header, the actor of the token
+// without the user is unknown. The token is kept as the request's principal checked by the use-cases' policy.
+// The requests aren't checked if the tokens' authentication is disabled.
+func (h *HttpController) requireScope(scope entities.Scope) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if !h.authEnabled {
+			return next
+		}
+
+		return func(eCtx echo.Context) error {
+			const op = "chttp.require-scope"
+
+			req := eCtx.Request()
+
+			header := req.Header.Get(echo.HeaderAuthorization)
+			if !strings.HasPrefix(header, bearerPrefix) || len(header) == len(bearerPrefix) {
+				return unauthorized(eCtx)
+			}
+
+			token, err := h.authenticateToken(req.Context(), strings.TrimPrefix(header, bearerPrefix))
+			if err != nil {
+				if errors.Is(err, services.ErrUnauthenticated) {
+					return unauthorized(eCtx)
+				}
+				h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+				return eCtx.JSON(http.StatusInternalServerError,
+					NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+			}
+
+			if !token.HasScope(scope) {
+				return eCtx.JSON(http.StatusForbidden,
+					NewErrResponse(Forbidden, ErrRespQueryForbidden.Error()))
+			}
+
+			ctx := services.WithPrincipal(req.Context(), services.Principal{
+				TokenID: token.ID,
+				UserID:  token.UserID,
+				IsAdmin: token.HasScope(entities.ScopeAdmin),
+			})
+			eCtx.SetRequest(req.WithContext(services.WithActor(ctx, token.UserID)))
+
+			return next(eCtx)
+		}
+	}
+}
+
+// authenticateToken returns the token of the secret: the configured bootstrap one or the issued one.
+func (h *HttpController) authenticateToken(ctx context.Context, secret string) (entities.APIToken, error) {
+	if len(h.bootstrapToken) != 0 && subtle.ConstantTimeCompare([]byte(secret), h.bootstrapToken) == 1 {
+		return entities.NewBootstrapToken(), nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+
+	return h.useCase.AuthenticateToken(ctx, secret)
+}
+
+func unauthorized(eCtx echo.Context) error {
+	eCtx.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+	return eCtx.JSON(http.StatusUnauthorized,
+		NewErrResponse(Unauthorized, ErrRespQueryUnauthorized.Error()))
+}
+
+// handlerAdminCreateToken defines the logic of handling the request for issuing the API token.
+func (h *HttpController) handlerAdminCreateToken(eCtx echo.Context) error {
+	const op = "chttp.admin-create-token"
+
+	token := entities.APIToken{}
+	if err := eCtx.Bind(&token); err != nil {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
+	}
+
+	if !token.IsValid() {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongAPIToken.Error()))
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+
+	res, err := h.useCase.CreateAPIToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, services.ErrEntityNotFound) {
+			return eCtx.JSON(http.StatusNotFound,
+				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+		}
+		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+		return eCtx.JSON(http.StatusInternalServerError,
+			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	}
+
+	return eCtx.JSON(http.StatusCreated, res)
+}
+
+// handlerAdminRevokeToken defines the logic of handling the request for revoking the API token.
+func (h *HttpController) handlerAdminRevokeToken(eCtx echo.Context) error {
+	const op = "chttp.admin-revoke-token"
+
+	data := dto.APITokenRevokeDTO{}
+	if err := eCtx.Bind(&data); err != nil || data.ID <= 0 {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+
+	if err := h.useCase.RevokeAPIToken(ctx, data.ID); err != nil {
+		if errors.Is(err, services.ErrEntityNotFound) {
+			return eCtx.JSON(http.StatusNotFound,
+				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+		}
+		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+		return eCtx.JSON(http.StatusInternalServerError,
+			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	}
+
+	return eCtx.NoContent(http.StatusNoContent)
+}
+
+// handlerAdminGetTokens defines the logic of handling the request for getting the API tokens without their secrets.
+func (h *HttpController) handlerAdminGetTokens(eCtx echo.Context) error {
+	const op = "chttp.admin-get-tokens"
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+
+	res, err := h.useCase.GetAPITokens(ctx)
+	if err != nil {
+		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+		return eCtx.JSON(http.StatusInternalServerError,
+			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	}
+
+	return eCtx.JSON(http.StatusOK, struct {
+		Tokens []entities.APIToken `json:"tokens"`
+	}{
+		Tokens: res,
+	})
+}
@@ -0,0 +1,285 @@
+package chttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+)
+
+// makeTestAuthController returns the controller authenticating the requests with the bootstrap token
+// and the team of the users 'alice' and 'bob'.
+func makeTestAuthController(t *testing.T) HttpController {
+	t.Helper()
+
+	contr := makeTestController(t, WithTokenAuth(testBootstrapToken))
+	addTestTeams(t, contr, `{"team_name":"backend","members":[
+		{"user_id":"alice","username":"Alice","is_active":true},
+		{"user_id":"bob","username":"Bob","is_active":true}]}`)
+
+	return contr
+}
+
+func TestAuthScopes(t *testing.T) {
+	contr := makeTestAuthController(t)
+
+	if rec := serveTestAuthRequest(t, contr, "", http.MethodGet, "/health/live", ""); rec.Code != http.StatusOK {
+		t.Fatalf("expected the health check without the token, got %d", rec.Code)
+	}
+
+	rec := serveTestAuthRequest(t, contr, "", http.MethodGet, "/team/get?team_name=backend", "")
+	if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") != "Bearer" {
+		t.Fatalf("expected the request without the token unauthorized, got %d %v", rec.Code, rec.Header())
+	}
+
+	if rec := serveTestAuthRequest(t, contr, "prs_unknown", http.MethodGet, "/team/get?team_name=backend", ""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected the unknown token unauthorized, got %d", rec.Code)
+	}
+
+	reader := createTestToken(t, contr, `{"name":"dashboard","scopes":["prs:read","teams:read"]}`)
+	if !strings.HasPrefix(reader.Token, entities.TokenPrefix) || reader.ID == 0 {
+		t.Fatalf("unexpected created token: %+v", reader)
+	}
+
+	if rec := serveTestAuthRequest(t, contr, reader.Token, http.MethodGet, "/team/get?team_name=backend", ""); rec.Code != http.StatusOK {
+		t.Fatalf("expected the team read with the token, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = serveTestAuthRequest(t, contr, reader.Token, http.MethodPost, "/team/setReviewerStrategy",
+		`{"team_name":"backend","reviewer_strategy":"RANDOM"}`)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected the write without the scope forbidden, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := serveTestAuthRequest(t, contr, reader.Token, http.MethodGet, "/admin/getTokens", ""); rec.Code != http.StatusForbidden {
+		t.Fatalf("expected the tokens' listing without the admin scope forbidden, got %d", rec.Code)
+	}
+
+	rec = serveTestAuthRequest(t, contr, testBootstrapToken, http.MethodGet, "/admin/getTokens", "")
+	if rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), reader.Token) || !strings.Contains(rec.Body.String(), "dashboard") {
+		t.Fatalf("expected the tokens without their secrets, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	revoke := fmt.Sprintf(`{"token_id":%d}`, reader.ID)
+	if rec := serveTestAuthRequest(t, contr, testBootstrapToken, http.MethodPost, "/admin/revokeToken", revoke); rec.Code != http.StatusNoContent {
+		t.Fatalf("couldn't revoke the token: %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := serveTestAuthRequest(t, contr, reader.Token, http.MethodGet, "/team/get?team_name=backend", ""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected the revoked token unauthorized, got %d", rec.Code)
+	}
+
+	if rec := serveTestAuthRequest(t, contr, testBootstrapToken, http.MethodPost, "/admin/revokeToken", `{"token_id":100}`); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected the unknown token not found, got %d", rec.Code)
+	}
+}
+
+func TestAuthTokenUserIsActor(t *testing.T) {
+	contr := makeTestAuthController(t)
+
+	writer := createTestToken(t, contr, `{"name":"alice-cli","scopes":["prs:write","prs:read"],"user_id":"alice"}`)
+
+	pullReq := `{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"alice"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", strings.NewReader(pullReq))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+writer.Token)
+	req.Header.Set(ActorHeader, "bob")
+
+	rec := httptest.NewRecorder()
+	contr.server.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("couldn't create the PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = serveTestAuthRequest(t, contr, writer.Token, http.MethodGet, "/pullRequest/history?pull_request_id=pr-1", "")
+
+	res := struct {
+		History []dto.ReviewerAssignmentDTO `json:"history"`
+	}{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil || len(res.History) == 0 {
+		t.Fatalf("couldn't get the PR's history: %d %s", rec.Code, rec.Body.String())
+	}
+
+	if actor := res.History[0].ActorID; actor != "alice" {
+		t.Fatalf("expected the token's user as the actor, got %q", actor)
+	}
+}
+
+func TestAuthTokenWithoutUserIgnoresActor(t *testing.T) {
+	contr := makeTestAuthController(t)
+
+	service := createTestToken(t, contr, `{"name":"ci","scopes":["prs:write","prs:read","admin"]}`)
+
+	pullReq := `{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"alice"}`
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", strings.NewReader(pullReq))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+service.Token)
+	req.Header.Set(ActorHeader, "bob")
+
+	rec := httptest.NewRecorder()
+	contr.server.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("couldn't create the PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = serveTestAuthRequest(t, contr, service.Token, http.MethodGet, "/pullRequest/history?pull_request_id=pr-1", "")
+
+	res := struct {
+		History []dto.ReviewerAssignmentDTO `json:"history"`
+	}{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil || len(res.History) == 0 {
+		t.Fatalf("couldn't get the PR's history: %d %s", rec.Code, rec.Body.String())
+	}
+
+	if actor := res.History[0].ActorID; len(actor) != 0 {
+		t.Fatalf("expected the header's actor ignored for the token without the user, got %q", actor)
+	}
+}
+
+func TestAuthCreateTokenValidation(t *testing.T) {
+	contr := makeTestAuthController(t)
+
+	cases := []struct {
+		body string
+		code int
+	}{
+		{body: `{"scopes":["prs:read"]}`, code: http.StatusBadRequest},
+		{body: `{"name":"ci","scopes":[]}`, code: http.StatusBadRequest},
+		{body: `{"name":"ci","scopes":["prs:delete"]}`, code: http.StatusBadRequest},
+		{body: `{"name":"ci","scopes":["prs:read"],"user_id":"dave"}`, code: http.StatusNotFound},
+	}
+
+	for _, test := range cases {
+		rec := serveTestAuthRequest(t, contr, testBootstrapToken, http.MethodPost, "/admin/createToken", test.body)
+		if rec.Code != test.code {
+			t.Fatalf("expected %d for %s, got %d %s", test.code, test.body, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestAuthRoles(t *testing.T) {
+	contr := makeTestAuthController(t)
+
+	teams := []string{
+		`{"team_name":"platform","merge_policy":{"required_approvals":0,"require_resolved_changes":true},"members":[
+			{"user_id":"lena","username":"Lena","is_active":true,"role":"team_lead"},
+			{"user_id":"mark","username":"Mark","is_active":true},
+			{"user_id":"nina","username":"Nina","is_active":true},
+			{"user_id":"oleg","username":"Oleg","is_active":true}]}`,
+		`{"team_name":"web","members":[{"user_id":"erin","username":"Erin","is_active":true}]}`,
+	}
+	for _, team := range teams {
+		if rec := serveTestAuthRequest(t, contr, testBootstrapToken, http.MethodPost, "/team/add", team); rec.Code != http.StatusCreated {
+			t.Fatalf("couldn't create the test team: %d %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	tokens := make(map[string]string, 4)
+	for _, user := range []string{"lena", "mark", "nina", "erin"} {
+		tokens[user] = createTestToken(t, contr, fmt.Sprintf(
+			`{"name":"%s-cli","scopes":["prs:read","prs:write","teams:read","teams:write"],"user_id":"%s"}`, user, user)).Token
+	}
+
+	rec := serveTestAuthRequest(t, contr, testBootstrapToken, http.MethodGet, "/team/get?team_name=platform", "")
+	if !strings.Contains(rec.Body.String(), `"role":"team_lead"`) || !strings.Contains(rec.Body.String(), `"role":"member"`) {
+		t.Fatalf("expected the members' roles in the team, got %s", rec.Body.String())
+	}
+
+	rec = serveTestAuthRequest(t, contr, tokens["mark"], http.MethodPost, "/pullRequest/create",
+		`{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"mark"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("couldn't create the PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	pullReq := dto.PullRequestDTO{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &pullReq); err != nil || len(pullReq.Reviewers) == 0 {
+		t.Fatalf("couldn't decode the PR: %v %s", err, rec.Body.String())
+	}
+	reassign := fmt.Sprintf(`{"pull_request_id":"pr-1","old_reviewer_id":"%s"}`, pullReq.Reviewers[0])
+
+	cases := []struct {
+		name   string
+		token  string
+		target string
+		body   string
+		code   int
+	}{
+		{name: "reassign by the other team's user", token: tokens["erin"], target: "/pullRequest/reassign", body: reassign, code: http.StatusForbidden},
+		{name: "reassign by the author", token: tokens["mark"], target: "/pullRequest/reassign", body: reassign, code: http.StatusOK},
+		{name: "review on behalf of the other user", token: tokens["nina"], target: "/pullRequest/review",
+			body: `{"pull_request_id":"pr-1","reviewer_id":"oleg","decision":"APPROVED"}`, code: http.StatusForbidden},
+		{name: "merge by the member", token: tokens["nina"], target: "/pullRequest/merge", body: `{"pull_request_id":"pr-1"}`, code: http.StatusForbidden},
+		{name: "merge by the lead", token: tokens["lena"], target: "/pullRequest/merge", body: `{"pull_request_id":"pr-1"}`, code: http.StatusOK},
+		{name: "reviewer strategy by the member", token: tokens["nina"], target: "/team/setReviewerStrategy",
+			body: `{"team_name":"platform","reviewer_strategy":"RANDOM"}`, code: http.StatusForbidden},
+		{name: "merge policy by the other team's user", token: tokens["erin"], target: "/team/setMergePolicy",
+			body: `{"team_name":"platform","required_approvals":0}`, code: http.StatusForbidden},
+		{name: "merge policy of the unknown team", token: tokens["mark"], target: "/team/setMergePolicy",
+			body: `{"team_name":"unknown","required_approvals":0}`, code: http.StatusNotFound},
+		{name: "reviewer policy by the lead", token: tokens["lena"], target: "/team/setReviewerPolicy",
+			body: `{"team_name":"platform","min_reviewers":1,"max_reviewers":2}`, code: http.StatusOK},
+		{name: "deactivation by the member", token: tokens["mark"], target: "/team/deactivateUsers",
+			body: `{"team_name":"platform","user_ids":["nina"]}`, code: http.StatusForbidden},
+		{name: "activity change by the member", token: tokens["nina"], target: "/users/setIsActive",
+			body: `{"user_id":"oleg","is_active":false}`, code: http.StatusForbidden},
+		{name: "activity change by the other team's user", token: tokens["erin"], target: "/users/setIsActive",
+			body: `{"user_id":"mark","is_active":false}`, code: http.StatusForbidden},
+		{name: "activity change by the lead", token: tokens["lena"], target: "/users/setIsActive",
+			body: `{"user_id":"oleg","is_active":true}`, code: http.StatusOK},
+		{name: "deactivation in the unknown team", token: tokens["mark"], target: "/team/deactivateUsers",
+			body: `{"team_name":"unknown","user_ids":["nina"]}`, code: http.StatusNotFound},
+		{name: "membership edit by the member", token: tokens["mark"], target: "/team/add",
+			body: `{"team_name":"platform","members":[{"user_id":"mark","username":"Mark","is_active":true,"role":"team_lead"}]}`, code: http.StatusForbidden},
+		{name: "membership edit by the lead", token: tokens["lena"], target: "/team/add",
+			body: `{"team_name":"platform","members":[{"user_id":"mark","username":"Mark","is_active":true}]}`, code: http.StatusBadRequest},
+		{name: "membership edit by the lead without the roles", token: tokens["lena"], target: "/team/add",
+			body: `{"team_name":"platform","members":[{"user_id":"lena","username":"Lena","is_active":true},
+				{"user_id":"mark","username":"Mark","is_active":true,"role":"member"}]}`, code: http.StatusBadRequest},
+		{name: "role change by the lead", token: tokens["lena"], target: "/team/add",
+			body: `{"team_name":"platform","members":[{"user_id":"nina","username":"Nina","is_active":true,"role":"team_lead"}]}`, code: http.StatusForbidden},
+		{name: "new team's lead by the member", token: tokens["erin"], target: "/team/add",
+			body: `{"team_name":"mobile","members":[{"user_id":"paul","username":"Paul","is_active":true,"role":"team_lead"}]}`, code: http.StatusForbidden},
+		{name: "new team by the member", token: tokens["erin"], target: "/team/add",
+			body: `{"team_name":"mobile","members":[{"user_id":"paul","username":"Paul","is_active":true,"role":"member"}]}`, code: http.StatusCreated},
+		{name: "unknown role", token: testBootstrapToken, target: "/team/add",
+			body: `{"team_name":"data","members":[{"user_id":"rita","username":"Rita","is_active":true,"role":"owner"}]}`, code: http.StatusBadRequest},
+	}
+
+	for _, test := range cases {
+		rec := serveTestAuthRequest(t, contr, test.token, http.MethodPost, test.target, test.body)
+		if rec.Code != test.code {
+			t.Fatalf("%s: expected %d, got %d %s", test.name, test.code, rec.Code, rec.Body.String())
+		}
+	}
+
+	rec = serveTestAuthRequest(t, contr, testBootstrapToken, http.MethodGet, "/team/get?team_name=platform", "")
+
+	team := dto.TeamDTO{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &team); err != nil {
+		t.Fatalf("couldn't decode the team: %v", err)
+	}
+
+	for _, member := range team.Members {
+		if member.ID == "mark" && member.Role != entities.MemberRole {
+			t.Fatalf("expected the member's edit rejected, got %+v", member)
+		}
+
+		if member.ID == "lena" && member.Role != entities.TeamLeadRole {
+			t.Fatalf("expected the lead's omitted role kept, got %+v", member)
+		}
+
+		if member.ID == "nina" && member.Role != entities.MemberRole {
+			t.Fatalf("expected the lead's role change rejected, got %+v", member)
+		}
+	}
+
+	rec = serveTestAuthRequest(t, contr, testBootstrapToken, http.MethodGet, "/team/get?team_name=mobile", "")
+	if !strings.Contains(rec.Body.String(), `"role":"member"`) || strings.Contains(rec.Body.String(), `"role":"team_lead"`) {
+		t.Fatalf("expected the new team created with the member's role, got %s", rec.Body.String())
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 	"time"
 
@@ -24,22 +25,103 @@ type HttpController struct {
 	socket  string
 	server  *echo.Echo
 	useCase services.Interactor
+
+	githubSecret []byte
+
+	gitlabToken      []byte
+	gitlabIdentities map[string]entities.UserID
+
+	authEnabled    bool
+	bootstrapToken []byte
+
+	metrics Metrics
+	tracing bool
+
+	servers []Server
+}
+
+// Metrics defines the registry of the metrics exposed on the /metrics endpoint.
+type Metrics interface {
+	ObserveRequest(method string, route string, code int, duration time.Duration)
+	Handler() http.Handler
+}
+
+// Server defines the additional server run and shut down together with the http-server.
+type Server interface {
+	Run() error
+	Shutdown(ctx context.Context) error
+}
+
+// ControllerOpt defines the function for configuration the controller's option.
+type ControllerOpt func(contr *HttpController)
+
+// WithGithubWebhook enables the GitHub webhooks verified with the current secret.
+func WithGithubWebhook(secret string) ControllerOpt {
+	return func(contr *HttpController) {
+		contr.githubSecret = []byte(secret)
+	}
+}
+
+// WithGitlabWebhook enables the GitLab webhooks verified with the current token.
+// The identities map the GitLab's usernames to the users' ids.
+func WithGitlabWebhook(token string, identities map[string]entities.UserID) ControllerOpt {
+	return func(contr *HttpController) {
+		contr.gitlabToken = []byte(token)
+		contr.gitlabIdentities = identities
+	}
+}
+
+// WithTokenAuth enables the bearer tokens' authentication of the requests.
+// The non-empty bootstrap token is accepted as the admin one.
+func WithTokenAuth(bootstrapToken string) ControllerOpt {
+	return func(contr *HttpController) {
+		contr.authEnabled = true
+		contr.bootstrapToken = []byte(bootstrapToken)
+	}
 }
 
-func New(log *slog.Logger, socket string, interactor services.Interactor) HttpController {
+// WithMetrics enables the /metrics endpoint and the requests' metrics recorded to the metrics.
+func WithMetrics(metrics Metrics) ControllerOpt {
+	return func(contr *HttpController) {
+		contr.metrics = metrics
+	}
+}
+
+// WithTracing enables the requests' spans started with the global tracer provider.
+func WithTracing() ControllerOpt {
+	return func(contr *HttpController) {
+		contr.tracing = true
+	}
+}
+
+// WithServers runs the servers together with the http-server and shuts them down on its shutdown.
+func WithServers(servers ...Server) ControllerOpt {
+	return func(contr *HttpController) {
+		contr.servers = append(contr.servers, servers...)
+	}
+}
+
+func New(log *slog.Logger, socket string, interactor services.Interactor, opts ...ControllerOpt) HttpController {
 	contr := HttpController{
 		log:     log,
 		socket:  socket,
 		server:  echo.New(),
 		useCase: interactor,
 	}
+
+	for _, opt := range opts {
+		opt(&contr)
+	}
 	contr.configEndpoints()
 
+	// The open streams don't let the server shut down, so they're closed first.
+	contr.server.Server.RegisterOnShutdown(interactor.CloseReviewStreams)
+
 	return contr
 }
 
 func (h *HttpController) Run() error {
-	errCh := make(chan error)
+	errCh := make(chan error, len(h.servers)+1)
 	sigCh := make(chan os.Signal, 3)
 
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
@@ -50,6 +132,14 @@ func (h *HttpController) Run() error {
 		}
 	}()
 
+	for _, server := range h.servers {
+		go func() {
+			if err := server.Run(); err != nil {
+				errCh <- err
+			}
+		}()
+	}
+
 	var err error
 	select {
 	case <-sigCh:
@@ -76,23 +166,107 @@ func (h *HttpController) run() error {
 }
 
 func (h *HttpController) close() {
-	ctx, _ := context.WithTimeout(context.Background(), time.Second*5)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
 
 	defer h.useCase.Close()
+	defer h.shutdownServers(ctx)
 	defer h.server.Shutdown(ctx)
 	defer h.log.Info("stop the http-server")
 }
 
+// shutdownServers stops the additional servers before the use-cases are closed.
+func (h *HttpController) shutdownServers(ctx context.Context) {
+	const op = "chttp.shutdown-servers"
+
+	for _, server := range h.servers {
+		if err := server.Shutdown(ctx); err != nil {
+			h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+		}
+	}
+}
+
 // configEndpoints sets the endpoints for the current kernel server instance.
 func (h *HttpController) configEndpoints() {
-	h.server.GET("/team/get", h.handlerTeamGet)
-	h.server.GET("/users/getReview", h.handlerUsersGetReview)
+	if h.tracing {
+		h.server.Use(tracingMiddleware)
+	}
+	if h.metrics != nil {
+		h.server.Use(h.metricsMiddleware)
+	}
+	h.server.Use(actorMiddleware)
+
+	var (
+		teamsRead   = h.requireScope(entities.ScopeTeamsRead)
+		teamsWrite  = h.requireScope(entities.ScopeTeamsWrite)
+		prsRead     = h.requireScope(entities.ScopePrsRead)
+		prsWrite    = h.requireScope(entities.ScopePrsWrite)
+		metricsRead = h.requireScope(entities.ScopeMetricsRead)
+		admin       = h.requireScope(entities.ScopeAdmin)
+	)
+
+	if h.metrics != nil {
+		h.server.GET("/metrics", echo.WrapHandler(h.metrics.Handler()), metricsRead)
+	}
+
+	h.server.GET("/health/live", h.handlerHealthLive)
+	h.server.GET("/health/ready", h.handlerHealthReady)
+	h.server.GET("/team/get", h.handlerTeamGet, teamsRead)
+	h.server.GET("/team/getWebhooks", h.handlerTeamGetWebhooks, teamsRead)
+	h.server.GET("/users/getReview", h.handlerUsersGetReview, prsRead)
+	h.server.GET("/users/reviewStream", h.handlerUsersReviewStream, prsRead)
+	h.server.GET("/pullRequest/history", h.handlerPullRequestHistory, prsRead)
+	h.server.GET("/pullRequest/list", h.handlerPullRequestList, prsRead)
+	h.server.GET("/pullRequest/get", h.handlerPullRequestGet, prsRead)
+	h.server.GET("/stats", h.handlerStats, prsRead)
+	h.server.GET("/webhooks/deliveries", h.handlerWebhookDeliveries, admin)
+	h.server.GET("/admin/getTokens", h.handlerAdminGetTokens, admin)
+
+	h.server.POST("/team/add", h.handlerTeamAdd, teamsWrite)
+	h.server.POST("/team/setReviewerStrategy", h.handlerTeamSetReviewerStrategy, teamsWrite)
+	h.server.POST("/team/setReviewerPolicy", h.handlerTeamSetReviewerPolicy, teamsWrite)
+	h.server.POST("/team/setMergePolicy", h.handlerTeamSetMergePolicy, teamsWrite)
+	h.server.POST("/team/deactivateUsers", h.handlerTeamDeactivateUsers, teamsWrite)
+	h.server.POST("/team/addWebhook", h.handlerTeamAddWebhook, teamsWrite)
+	h.server.POST("/team/removeWebhook", h.handlerTeamRemoveWebhook, teamsWrite)
+	h.server.POST("/users/setIsActive", h.handlerUserSetIsActive, teamsWrite)
+	h.server.POST("/pullRequest/create", h.handlerPullRequestCreate, prsWrite, h.idempotencyMiddleware)
+	h.server.POST("/pullRequest/merge", h.handlerPullRequestMerge, prsWrite, h.idempotencyMiddleware)
+	h.server.POST("/pullRequest/close", h.handlerPullRequestClose, prsWrite)
+	h.server.POST("/pullRequest/reopen", h.handlerPullRequestReopen, prsWrite)
+	h.server.POST("/pullRequest/ready", h.handlerPullRequestReady, prsWrite)
+	h.server.POST("/pullRequest/review", h.handlerPullRequestReview, prsWrite)
+	h.server.POST("/pullRequest/reassign", h.handlerPullRequestReassign, prsWrite, h.idempotencyMiddleware)
+	h.server.POST("/admin/createToken", h.handlerAdminCreateToken, admin)
+	h.server.POST("/admin/revokeToken", h.handlerAdminRevokeToken, admin)
+
+	// The webhooks of the git hostings are authenticated with their own secrets.
+	if len(h.githubSecret) != 0 {
+		h.server.POST("/webhooks/github", h.handlerWebhookGithub)
+	}
 
-	h.server.POST("/team/add", h.handlerTeamAdd)
-	h.server.POST("/users/setIsActive", h.handlerUserSetIsActive)
-	h.server.POST("/pullRequest/create", h.handlerPullRequestCreate)
-	h.server.POST("/pullRequest/merge", h.handlerPullRequestMerge)
-	h.server.POST("/pullRequest/reassign", h.handlerPullRequestReassign)
+	if len(h.gitlabToken) != 0 {
+		h.server.POST("/webhooks/gitlab", h.handlerWebhookGitlab)
+	}
+}
+
+// handlerHealthLive defines the logic of handling the request for checking the service is alive.
+func (h *HttpController) handlerHealthLive(eCtx echo.Context) error {
+	return eCtx.JSON(http.StatusOK, dto.HealthDTO{Status: dto.HealthUp})
+}
+
+// handlerHealthReady defines the logic of handling the request for checking the service
+// is ready to serve the traffic: every dependency must be available.
+func (h *HttpController) handlerHealthReady(eCtx echo.Context) error {
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*2)
+	defer cancel()
+
+	res, err := h.useCase.CheckReadiness(ctx)
+	if err != nil {
+		return eCtx.JSON(http.StatusServiceUnavailable, res)
+	}
+
+	return eCtx.JSON(http.StatusOK, res)
 }
 
 // handlerTeamGet defines the logic of handling the request for getting the team.
@@ -105,7 +279,8 @@ func (h *HttpController) handlerTeamGet(eCtx echo.Context) error {
 			NewErrResponse(RequestDataErr, ErrRespQueryEmptyParam.Error()))
 	}
 
-	ctx, _ := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
 	dto, err := h.useCase.GetTeam(ctx, res.(string))
 
 	if err != nil {
@@ -132,7 +307,8 @@ func (h *HttpController) handlerUsersGetReview(eCtx echo.Context) error {
 			NewErrResponse(RequestDataErr, ErrRespQueryEmptyParam.Error()))
 	}
 
-	ctx, _ := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
 	res, err := h.useCase.GetUserPullRequests(ctx, entities.UserID(id.(string)))
 
 	if err != nil {
@@ -155,6 +331,103 @@ func (h *HttpController) handlerUsersGetReview(eCtx echo.Context) error {
 	})
 }
 
+// handlerPullRequestHistory defines the logic of handling the request for getting the PR's reviewers changes.
+func (h *HttpController) handlerPullRequestHistory(eCtx echo.Context) error {
+	const op = "chttp.pull-request-history"
+
+	id, err := validatePullRequestID(eCtx)
+	if err != nil {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryEmptyParam.Error()))
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+	res, err := h.useCase.GetPullRequestHistory(ctx, entities.PullRequestID(id.(string)))
+
+	if err != nil {
+		if errors.Is(err, services.ErrEntityNotFound) {
+			return eCtx.JSON(http.StatusNotFound,
+				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+		}
+		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+		return eCtx.JSON(http.StatusInternalServerError,
+			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	}
+
+	return eCtx.JSON(http.StatusOK, struct {
+		ID      entities.PullRequestID      `json:"pull_request_id"`
+		History []dto.ReviewerAssignmentDTO `json:"history"`
+	}{
+		ID:      entities.PullRequestID(id.(string)),
+		History: res,
+	})
+}
+
+// handlerPullRequestGet defines the logic of handling the request for getting the PR with its participants' data.
+func (h *HttpController) handlerPullRequestGet(eCtx echo.Context) error {
+	const op = "chttp.pull-request-get"
+
+	id, err := validatePullRequestID(eCtx)
+	if err != nil {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryEmptyParam.Error()))
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+	res, err := h.useCase.GetPullRequest(ctx, entities.PullRequestID(id.(string)))
+
+	if err != nil {
+		if errors.Is(err, services.ErrEntityNotFound) {
+			return eCtx.JSON(http.StatusNotFound,
+				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+		}
+		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+		return eCtx.JSON(http.StatusInternalServerError,
+			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	}
+
+	return eCtx.JSON(http.StatusOK, struct {
+		PullRequest dto.PullRequestDetailsDTO `json:"pr"`
+	}{
+		PullRequest: res,
+	})
+}
+
+// handlerStats defines the logic of handling the request for getting the review's statistics.
+func (h *HttpController) handlerStats(eCtx echo.Context) error {
+	const op = "chttp.stats"
+
+	from, to, err := validateTimeWindow(eCtx)
+	if err != nil {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongTimeWindow.Error()))
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+
+	res, err := h.useCase.GetStats(ctx, dto.StatsFilterDTO{
+		TeamName: eCtx.QueryParam("team_name"),
+		UserID:   entities.UserID(eCtx.QueryParam("user_id")),
+		From:     from,
+		To:       to,
+	})
+
+	if err != nil {
+		if errors.Is(err, services.ErrEntityNotFound) {
+			return eCtx.JSON(http.StatusNotFound,
+				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+		}
+		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+		return eCtx.JSON(http.StatusInternalServerError,
+			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	}
+
+	return eCtx.JSON(http.StatusOK, res)
+}
+
 // handlerTeamAdd defines the logic of handling the request for adding the team.
 func (h *HttpController) handlerTeamAdd(eCtx echo.Context) error {
 	const op = "chttp.team-add"
@@ -166,19 +439,199 @@ func (h *HttpController) handlerTeamAdd(eCtx echo.Context) error {
 			NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
 	}
 
-	ctx, _ := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	if len(team.ReviewerStrategy) != 0 && !team.ReviewerStrategy.IsValid() {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongStrategy.Error()))
+	}
 
-	if err := h.useCase.CreateTeam(ctx, team); err != nil {
-		if errors.Is(err, repo.ErrCreateMultipleUniqueModels) {
+	if !team.ReviewerPolicy.IsZero() && !team.ReviewerPolicy.IsValid() {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongPolicy.Error()))
+	}
+
+	if !team.MergePolicy.IsValid() {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongMergePolicy.Error()))
+	}
+
+	for _, member := range team.Members {
+		if len(member.Role) != 0 && !member.Role.IsValid() {
+			return eCtx.JSON(http.StatusBadRequest,
+				NewErrResponse(RequestDataErr, ErrRespQueryWrongRole.Error()))
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+
+	res, err := h.useCase.CreateTeam(ctx, team)
+	if err != nil {
+		if errors.Is(err, repo.ErrCreateMultipleUniqueModels) || errors.Is(err, repo.ErrModelAlreadyExists) {
 			return eCtx.JSON(http.StatusBadRequest,
 				NewErrResponse(TeamExists, ErrRespQueryAlreadyExists.Error()))
+
+		} else if errors.Is(err, services.ErrForbidden) {
+			return eCtx.JSON(http.StatusForbidden,
+				NewErrResponse(Forbidden, ErrRespQueryNotPermitted.Error()))
+		}
+		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+		return eCtx.JSON(http.StatusInternalServerError,
+			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	}
+
+	return eCtx.JSON(http.StatusCreated, res)
+}
+
+// handlerTeamSetReviewerStrategy defines the logic of handling the request for changing the team's
+// strategy of choosing the reviewers.
+func (h *HttpController) handlerTeamSetReviewerStrategy(eCtx echo.Context) error {
+	const op = "chttp.team-set-reviewer-strategy"
+
+	data := dto.TeamReviewerStrategyDTO{}
+	if err := eCtx.Bind(&data); err != nil || len(data.Name) == 0 {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
+	}
+
+	if !data.ReviewerStrategy.IsValid() {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongStrategy.Error()))
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+
+	team, err := h.useCase.SetTeamReviewerStrategy(ctx, data.Name, data.ReviewerStrategy)
+	if err != nil {
+		if errors.Is(err, services.ErrEntityNotFound) {
+			return eCtx.JSON(http.StatusNotFound,
+				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+
+		} else if errors.Is(err, services.ErrForbidden) {
+			return eCtx.JSON(http.StatusForbidden,
+				NewErrResponse(Forbidden, ErrRespQueryNotPermitted.Error()))
+		}
+		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+		return eCtx.JSON(http.StatusInternalServerError,
+			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	}
+
+	return eCtx.JSON(http.StatusOK, team)
+}
+
+// handlerTeamSetReviewerPolicy defines the logic of handling the request for changing the team's
+// bounds of the PR's reviewers count.
+func (h *HttpController) handlerTeamSetReviewerPolicy(eCtx echo.Context) error {
+	const op = "chttp.team-set-reviewer-policy"
+
+	data := dto.TeamReviewerPolicyDTO{}
+	if err := eCtx.Bind(&data); err != nil || len(data.Name) == 0 {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
+	}
+
+	if !data.ReviewerPolicy.IsValid() {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongPolicy.Error()))
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+
+	team, err := h.useCase.SetTeamReviewerPolicy(ctx, data.Name, data.ReviewerPolicy)
+	if err != nil {
+		if errors.Is(err, services.ErrEntityNotFound) {
+			return eCtx.JSON(http.StatusNotFound,
+				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+
+		} else if errors.Is(err, services.ErrForbidden) {
+			return eCtx.JSON(http.StatusForbidden,
+				NewErrResponse(Forbidden, ErrRespQueryNotPermitted.Error()))
+		}
+		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+		return eCtx.JSON(http.StatusInternalServerError,
+			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	}
+
+	return eCtx.JSON(http.StatusOK, team)
+}
+
+// handlerTeamSetMergePolicy defines the logic of handling the request for changing the team's
+// conditions of the PR's merge.
+func (h *HttpController) handlerTeamSetMergePolicy(eCtx echo.Context) error {
+	const op = "chttp.team-set-merge-policy"
+
+	data := dto.TeamMergePolicyDTO{}
+	if err := eCtx.Bind(&data); err != nil || len(data.Name) == 0 {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
+	}
+
+	if !data.MergePolicy.IsValid() {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongMergePolicy.Error()))
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+
+	team, err := h.useCase.SetTeamMergePolicy(ctx, data.Name, data.MergePolicy)
+	if err != nil {
+		if errors.Is(err, services.ErrEntityNotFound) {
+			return eCtx.JSON(http.StatusNotFound,
+				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+
+		} else if errors.Is(err, services.ErrForbidden) {
+			return eCtx.JSON(http.StatusForbidden,
+				NewErrResponse(Forbidden, ErrRespQueryNotPermitted.Error()))
+		}
+		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+		return eCtx.JSON(http.StatusInternalServerError,
+			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	}
+
+	return eCtx.JSON(http.StatusOK, team)
+}
+
+// handlerTeamDeactivateUsers defines the logic of handling the request for deactivating the team's members
+// with reassigning their open reviews.
+func (h *HttpController) handlerTeamDeactivateUsers(eCtx echo.Context) error {
+	const op = "chttp.team-deactivate-users"
+
+	var deactivation dto.TeamDeactivateUsersDTO
+
+	if err := eCtx.Bind(&deactivation); err != nil {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
+	}
+
+	if len(deactivation.Name) == 0 || len(deactivation.UserIDs) == 0 || slices.Contains(deactivation.UserIDs, "") {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryEmptyParam.Error()))
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*10)
+	defer cancel()
+
+	res, err := h.useCase.DeactivateTeamUsers(ctx, deactivation)
+	if err != nil {
+		if errors.Is(err, services.ErrEntityNotFound) {
+			return eCtx.JSON(http.StatusNotFound,
+				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+
+		} else if errors.Is(err, services.ErrForbidden) {
+			return eCtx.JSON(http.StatusForbidden,
+				NewErrResponse(Forbidden, ErrRespQueryNotPermitted.Error()))
 		}
 		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
 		return eCtx.JSON(http.StatusInternalServerError,
 			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
 	}
 
-	return eCtx.JSON(http.StatusCreated, dto.TeamToTeamDTO(team))
+	return eCtx.JSON(http.StatusOK, res)
 }
 
 // handlerUserSetIsActive defines the logic of handling the request for setting the user to the active.
@@ -191,13 +644,18 @@ func (h *HttpController) handlerUserSetIsActive(eCtx echo.Context) error {
 			NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
 	}
 
-	ctx, _ := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
 
 	user, err := h.useCase.SetUserIsActive(ctx, dto.IsActive, dto.ID)
 	if err != nil {
 		if errors.Is(err, services.ErrEntityNotFound) {
 			return eCtx.JSON(http.StatusNotFound,
 				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+
+		} else if errors.Is(err, services.ErrForbidden) {
+			return eCtx.JSON(http.StatusForbidden,
+				NewErrResponse(Forbidden, ErrRespQueryNotPermitted.Error()))
 		}
 		retErr := fmt.Errorf("error of the %s: %s: %s", op, ErrRespQueryServerError, err)
 		h.log.Warn(retErr.Error())
@@ -218,53 +676,181 @@ func (h *HttpController) handlerPullRequestCreate(eCtx echo.Context) error {
 		return eCtx.JSON(http.StatusBadRequest,
 			NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
 	}
-	pullReq.Status = entities.Open
 
-	ctx, _ := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
-	if err := h.useCase.CreatePullRequest(ctx, pullReq); err != nil {
-		if errors.Is(err, services.ErrEntityNotFound) {
-			return eCtx.JSON(http.StatusNotFound,
-				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+	if len(pullReq.Status) == 0 {
+		pullReq.Status = entities.Open
+	} else if pullReq.Status != entities.Open && pullReq.Status != entities.Draft {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongStatus.Error()))
+	}
 
-		} else if errors.Is(err, services.ErrEntityAlreadyExists) {
-			return eCtx.JSON(http.StatusConflict,
-				NewErrResponse(PrExists, ErrRespQueryAlreadyExists.Error()))
-		}
-		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
 
-		return eCtx.JSON(http.StatusInternalServerError,
-			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	res, err := h.useCase.CreatePullRequest(ctx, pullReq)
+	if err != nil {
+		return h.respondPullRequestCreateErr(eCtx, op, err)
 	}
 
-	return eCtx.JSON(http.StatusCreated, dto.MakePullRequestDTOShort(pullReq))
+	return eCtx.JSON(http.StatusCreated, res)
+}
+
+// respondPullRequestCreateErr defines the logic of responding with the error of the PR's creation.
+func (h *HttpController) respondPullRequestCreateErr(eCtx echo.Context, op string, err error) error {
+	if errors.Is(err, services.ErrEntityNotFound) {
+		return eCtx.JSON(http.StatusNotFound,
+			NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+
+	} else if errors.Is(err, services.ErrEntityAlreadyExists) {
+		return eCtx.JSON(http.StatusConflict,
+			NewErrResponse(PrExists, ErrRespQueryAlreadyExists.Error()))
+
+	} else if errors.Is(err, services.ErrDomainRulesNoCandidate) {
+		return eCtx.JSON(http.StatusConflict,
+			NewErrResponse(NoCandidate, ErrRespQueryNoCandidate.Error()))
+
+	} else if errors.Is(err, services.ErrDomainRulesPolicy) {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryReviewersCount.Error()))
+	}
+	h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+	return eCtx.JSON(http.StatusInternalServerError,
+		NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
 }
 
 // handlerPullRequestMerge defines the logic of handling the request for merge the requested PR.
 func (h *HttpController) handlerPullRequestMerge(eCtx echo.Context) error {
-	const op = "chttp.pull-request-merge"
+	return h.handlePullRequestStatus(eCtx, "chttp.pull-request-merge", "", entities.Merged)
+}
+
+// handlerPullRequestClose defines the logic of handling the request for closing the requested PR without merge.
+func (h *HttpController) handlerPullRequestClose(eCtx echo.Context) error {
+	return h.handlePullRequestStatus(eCtx, "chttp.pull-request-close", "", entities.Closed)
+}
+
+// handlerPullRequestReopen defines the logic of handling the request for reopening the closed PR.
+func (h *HttpController) handlerPullRequestReopen(eCtx echo.Context) error {
+	return h.handlePullRequestStatus(eCtx, "chttp.pull-request-reopen", entities.Closed, entities.Open)
+}
+
+// handlerPullRequestReady defines the logic of handling the request for marking the draft PR ready for the review.
+func (h *HttpController) handlerPullRequestReady(eCtx echo.Context) error {
+	return h.handlePullRequestStatus(eCtx, "chttp.pull-request-ready", entities.Draft, entities.Open)
+}
 
+// handlePullRequestStatus defines the common logic of handling the requests for changing the PR's status.
+// The current PR's status must be equal to the from one if it's set.
+func (h *HttpController) handlePullRequestStatus(
+	eCtx echo.Context,
+	op string,
+	from entities.PullRequestStatus,
+	to entities.PullRequestStatus,
+) error {
 	pullReq := dto.NewPullRequestDTO()
 	if err := eCtx.Bind(&pullReq); err != nil {
 		return eCtx.JSON(http.StatusBadRequest,
 			NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
 	}
 
-	ctx, _ := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
-	res, err := h.useCase.SetPullRequestStatus(ctx, entities.Merged, pullReq)
+	pullReq.Status = from
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+	res, err := h.useCase.SetPullRequestStatus(ctx, to, pullReq)
 	if err != nil {
-		if errors.Is(err, services.ErrEntityNotFound) {
-			return eCtx.JSON(http.StatusNotFound,
-				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
-		}
-		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+		return h.respondPullRequestStatusErr(eCtx, op, err)
+	}
 
-		return eCtx.JSON(http.StatusInternalServerError,
-			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	return eCtx.JSON(http.StatusOK, res)
+}
+
+// respondPullRequestStatusErr defines the logic of responding with the error of changing the PR's status.
+func (h *HttpController) respondPullRequestStatusErr(eCtx echo.Context, op string, err error) error {
+	if errors.Is(err, services.ErrEntityNotFound) {
+		return eCtx.JSON(http.StatusNotFound,
+			NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+
+	} else if errors.Is(err, services.ErrDomainRulesTransition) {
+		return eCtx.JSON(http.StatusConflict,
+			NewErrResponse(InvalidTransition, ErrRespQueryInvalidTransition.Error()))
+
+	} else if errors.Is(err, services.ErrDomainRulesNoCandidate) {
+		return eCtx.JSON(http.StatusConflict,
+			NewErrResponse(NoCandidate, ErrRespQueryNoCandidate.Error()))
+
+	} else if errors.Is(err, services.ErrForbidden) {
+		return eCtx.JSON(http.StatusForbidden,
+			NewErrResponse(Forbidden, ErrRespQueryNotPermitted.Error()))
+
+	} else if errors.Is(err, services.ErrDomainRulesPolicy) {
+		return eCtx.JSON(http.StatusConflict,
+			NewErrResponse(NoCandidate, ErrRespQueryReviewersCount.Error()))
+	}
+
+	if blocked := new(services.MergeBlockedError); errors.As(err, &blocked) {
+		resp := NewErrResponse(MergeBlocked, ErrRespQueryMergeBlocked.Error())
+		resp.Data.UnmetRules = blocked.Violations
+
+		return eCtx.JSON(http.StatusConflict, resp)
+	}
+	h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+	return eCtx.JSON(http.StatusInternalServerError,
+		NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+}
+
+// handlerPullRequestReview defines the logic of handling the request for keeping the reviewer's
+// decision on the PR.
+func (h *HttpController) handlerPullRequestReview(eCtx echo.Context) error {
+	const op = "chttp.pull-request-review"
+
+	review := dto.PullRequestReviewDTO{}
+	if err := eCtx.Bind(&review); err != nil || len(review.ID) == 0 || len(review.ReviewerID) == 0 {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
+	}
+
+	if !review.Decision.IsValid() {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongDecision.Error()))
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+
+	res, err := h.useCase.ReviewPullRequest(ctx, review)
+	if err != nil {
+		return h.respondPullRequestReviewErr(eCtx, op, err)
 	}
 
 	return eCtx.JSON(http.StatusOK, res)
 }
 
+// respondPullRequestReviewErr defines the logic of responding with the error of keeping the review's decision.
+func (h *HttpController) respondPullRequestReviewErr(eCtx echo.Context, op string, err error) error {
+	if errors.Is(err, services.ErrEntityNotFound) {
+		return eCtx.JSON(http.StatusNotFound,
+			NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+
+	} else if errors.Is(err, services.ErrDomainRulesWithROState) {
+		return eCtx.JSON(http.StatusConflict,
+			NewErrResponse(PrMerged, ErrRespQueryOpIsRestrict.Error()))
+
+	} else if errors.Is(err, services.ErrWrongCandidate) {
+		return eCtx.JSON(http.StatusConflict,
+			NewErrResponse(NotAssigned, ErrRespQueryWrongCandidate.Error()))
+
+	} else if errors.Is(err, services.ErrForbidden) {
+		return eCtx.JSON(http.StatusForbidden,
+			NewErrResponse(Forbidden, ErrRespQueryNotPermitted.Error()))
+	}
+	h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+	return eCtx.JSON(http.StatusInternalServerError,
+		NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+}
+
 // handlerPullRequestReassign defines the logic of handling the request for reassignin the PR's
 // reviewers.
 func (h *HttpController) handlerPullRequestReassign(eCtx echo.Context) error {
@@ -276,7 +862,8 @@ func (h *HttpController) handlerPullRequestReassign(eCtx echo.Context) error {
 			NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
 	}
 
-	ctx, _ := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
 	res, newId, err := h.useCase.ReassignUser(ctx, data)
 	if err != nil {
 		retErr := fmt.Errorf("error of the %s: %s", op, err)
@@ -296,6 +883,10 @@ func (h *HttpController) handlerPullRequestReassign(eCtx echo.Context) error {
 		} else if errors.Is(err, services.ErrWrongCandidate) {
 			return eCtx.JSON(http.StatusConflict,
 				NewErrResponse(NotAssigned, ErrRespQueryWrongCandidate.Error()))
+
+		} else if errors.Is(err, services.ErrForbidden) {
+			return eCtx.JSON(http.StatusForbidden,
+				NewErrResponse(Forbidden, ErrRespQueryNotPermitted.Error()))
 		}
 		h.log.Warn(retErr.Error())
 
@@ -12,12 +12,36 @@ var (
 )
 
 var (
-	ErrRespQueryEmptyParam       = errors.New("the requested params couldn't be empty")
-	ErrRespQueryNotFound         = errors.New("the requested model doesn't exist")
-	ErrRespQueryWrongRequestData = errors.New("error of the request's data: current format is not available")
-	ErrRespQueryAlreadyExists    = errors.New("the model already exists")
-	ErrRespQueryServerError      = errors.New("internal error was generated during request processing")
-	ErrRespQueryOpIsRestrict     = errors.New("can't complete this operation due to the violation of changing the internal state")
-	ErrRespQueryNoCandidate      = errors.New("couldn't find the needed candidates for the current operation")
-	ErrRespQueryWrongCandidate   = errors.New("couldn't complete the operation with the current candidate due to it's wrong")
+	ErrRespQueryEmptyParam          = errors.New("the requested params couldn't be empty")
+	ErrRespQueryNotFound            = errors.New("the requested model doesn't exist")
+	ErrRespQueryWrongRequestData    = errors.New("error of the request's data: current format is not available")
+	ErrRespQueryAlreadyExists       = errors.New("the model already exists")
+	ErrRespQueryServerError         = errors.New("internal error was generated during request processing")
+	ErrRespQueryOpIsRestrict        = errors.New("can't complete this operation due to the violation of changing the internal state")
+	ErrRespQueryNoCandidate         = errors.New("couldn't find the needed candidates for the current operation")
+	ErrRespQueryWrongCandidate      = errors.New("couldn't complete the operation with the current candidate due to it's wrong")
+	ErrRespQueryWrongStrategy       = errors.New("the reviewer strategy must be one of: RANDOM, ROUND_ROBIN, LEAST_LOADED, WEIGHTED_RANDOM")
+	ErrRespQueryWrongPolicy         = errors.New("the reviewer policy must satisfy: 0 <= min_reviewers <= max_reviewers, max_reviewers >= 1")
+	ErrRespQueryWrongTimeWindow     = errors.New("the time window must be set with RFC3339 'from' and 'to' where 'from' is before 'to'")
+	ErrRespQueryWrongStatus         = errors.New("the PR can be created only with the status: OPEN, DRAFT")
+	ErrRespQueryInvalidTransition   = errors.New("the PR's current status doesn't allow the requested change")
+	ErrRespQueryReviewersCount      = errors.New("the requested reviewers count is out of the team's reviewer policy")
+	ErrRespQueryWrongDecision       = errors.New("the review decision must be one of: APPROVED, CHANGES_REQUESTED, COMMENTED")
+	ErrRespQueryWrongMergePolicy    = errors.New("the merge policy must satisfy: required_approvals >= 0")
+	ErrRespQueryMergeBlocked        = errors.New("the PR doesn't satisfy the team's merge policy")
+	ErrRespQueryInvalidSignature    = errors.New("the webhook's signature doesn't match the payload")
+	ErrRespQueryInvalidWebhookToken = errors.New("the webhook's token is wrong")
+	ErrRespQueryWrongWebhook        = errors.New("the webhook must have the absolute http(s) url and the non-empty secret")
+	ErrRespQueryWrongDeliveryFilter = errors.New("the deliveries' status must be one of: PENDING, DELIVERED, DEAD and the limit must be positive")
+	ErrRespQueryWrongListFilter     = errors.New("the PRs' status must be one of: DRAFT, OPEN, CLOSED, MERGED, the RFC3339 'created_after' must be before 'created_before', " +
+		"'sort_by' must be one of: created_at, pull_request_id, 'order' must be one of: asc, desc, the limit must be positive and the cursor must be given for the same sorting")
+	ErrRespQueryWrongAPIToken = errors.New("the token must have the name and the scopes of: teams:read, teams:write, prs:read, prs:write, metrics:read, admin")
+	ErrRespQueryUnauthorized  = errors.New("the request must have the valid bearer token in the Authorization header")
+	ErrRespQueryForbidden     = errors.New("the token doesn't grant the scope required for the request")
+	ErrRespQueryNotPermitted  = errors.New("the actor's role in the team doesn't permit the operation")
+	ErrRespQueryWrongRole     = errors.New("the member's role must be one of: member, team_lead")
+
+	ErrRespQueryWrongIdempotencyKey   = errors.New("the idempotency key must be not longer than 255 characters")
+	ErrRespQueryIdempotencyKeyReused  = errors.New("the idempotency key was already used for the other request")
+	ErrRespQueryIdempotencyInProgress = errors.New("the request with the current idempotency key is still being processed")
 )
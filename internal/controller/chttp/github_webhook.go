@@ -0,0 +1,187 @@
+package chttp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// GithubEventHeader defines the GitHub webhook's header keeping the event's name.
+	GithubEventHeader = "X-GitHub-Event"
+
+	// GithubSignatureHeader defines the GitHub webhook's header keeping the payload's HMAC-SHA256 signature.
+	GithubSignatureHeader = "X-Hub-Signature-256"
+
+	githubSignaturePrefix = "sha256="
+)
+
+const (
+	githubPullRequestEvent       = "pull_request"
+	githubPullRequestReviewEvent = "pull_request_review"
+)
+
+type (
+	// githubUser defines the GitHub webhook's view of the user.
+	githubUser struct {
+		Login string `json:"login"`
+	}
+
+	// githubRepository defines the GitHub webhook's view of the repository.
+	githubRepository struct {
+		FullName string `json:"full_name"`
+	}
+
+	// githubPullRequest defines the GitHub webhook's view of the pull-request.
+	githubPullRequest struct {
+		Number int        `json:"number"`
+		Title  string     `json:"title"`
+		Draft  bool       `json:"draft"`
+		Merged bool       `json:"merged"`
+		User   githubUser `json:"user"`
+	}
+
+	// githubReview defines the GitHub webhook's view of the pull-request's review.
+	githubReview struct {
+		State string     `json:"state"`
+		User  githubUser `json:"user"`
+	}
+
+	// githubEvent defines the payload of the GitHub's pull_request and pull_request_review events.
+	githubEvent struct {
+		Action      string            `json:"action"`
+		PullRequest githubPullRequest `json:"pull_request"`
+		Review      githubReview      `json:"review"`
+		Repository  githubRepository  `json:"repository"`
+		Sender      githubUser        `json:"sender"`
+	}
+)
+
+// pullRequestID returns the service's id of the event's PR: the repository's full name with the PR's number.
+func (g githubEvent) pullRequestID() entities.PullRequestID {
+	return entities.PullRequestID(fmt.Sprintf("%s#%d", g.Repository.FullName, g.PullRequest.Number))
+}
+
+// githubReviewDecisions defines the mapping of the GitHub review's states to the review decisions.
+var githubReviewDecisions = map[string]entities.ReviewDecision{
+	"approved":          entities.Approved,
+	"changes_requested": entities.ChangesRequested,
+	"commented":         entities.Commented,
+}
+
+// handlerWebhookGithub defines the logic of handling the GitHub's webhooks.
+// The pull_request and pull_request_review events are applied to the PRs, the other ones are ignored.
+func (h *HttpController) handlerWebhookGithub(eCtx echo.Context) error {
+	const op = "chttp.webhook-github"
+
+	body, err := io.ReadAll(eCtx.Request().Body)
+	if err != nil {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
+	}
+
+	if !checkGithubSignature(h.githubSecret, eCtx.Request().Header.Get(GithubSignatureHeader), body) {
+		return eCtx.JSON(http.StatusUnauthorized,
+			NewErrResponse(InvalidSignature, ErrRespQueryInvalidSignature.Error()))
+	}
+
+	name := eCtx.Request().Header.Get(GithubEventHeader)
+	if name != githubPullRequestEvent && name != githubPullRequestReviewEvent {
+		return eCtx.NoContent(http.StatusNoContent)
+	}
+
+	event := githubEvent{}
+	if err := json.Unmarshal(body, &event); err != nil || len(event.Repository.FullName) == 0 {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+
+	if len(event.Sender.Login) != 0 {
+		ctx = services.WithActor(ctx, entities.UserID(event.Sender.Login))
+	}
+
+	if name == githubPullRequestReviewEvent {
+		return h.handleGithubReview(ctx, eCtx, op, event)
+	}
+	return h.handleGithubPullRequest(ctx, eCtx, op, event)
+}
+
+// handleGithubPullRequest defines the logic of applying the GitHub's pull_request event to the PR.
+func (h *HttpController) handleGithubPullRequest(ctx context.Context, eCtx echo.Context, op string, event githubEvent) error {
+	id := event.pullRequestID()
+
+	switch event.Action {
+	case "opened":
+		pullReq := dto.NewPullRequestDTO()
+		pullReq.ID = id
+		pullReq.Name = event.PullRequest.Title
+		pullReq.AuthorID = entities.UserID(event.PullRequest.User.Login)
+		pullReq.Status = entities.Open
+
+		if event.PullRequest.Draft {
+			pullReq.Status = entities.Draft
+		}
+		return h.createWebhookPullRequest(ctx, eCtx, op, pullReq)
+
+	case "reopened":
+		return h.setWebhookPullRequestStatus(ctx, eCtx, op, id, entities.Closed, entities.Open)
+
+	case "ready_for_review":
+		return h.setWebhookPullRequestStatus(ctx, eCtx, op, id, entities.Draft, entities.Open)
+
+	case "closed":
+		if event.PullRequest.Merged {
+			return h.mergeWebhookPullRequest(ctx, eCtx, op, id)
+		}
+		return h.setWebhookPullRequestStatus(ctx, eCtx, op, id, "", entities.Closed)
+	}
+
+	return eCtx.NoContent(http.StatusNoContent)
+}
+
+// handleGithubReview defines the logic of applying the GitHub's submitted review to the PR.
+func (h *HttpController) handleGithubReview(ctx context.Context, eCtx echo.Context, op string, event githubEvent) error {
+	decision, ok := githubReviewDecisions[strings.ToLower(event.Review.State)]
+	if event.Action != "submitted" || !ok {
+		return eCtx.NoContent(http.StatusNoContent)
+	}
+
+	return h.reviewWebhookPullRequest(ctx, eCtx, op, dto.PullRequestReviewDTO{
+		ID:         event.pullRequestID(),
+		ReviewerID: entities.UserID(event.Review.User.Login),
+		Decision:   decision,
+	})
+}
+
+// checkGithubSignature checks the payload's signature is the HMAC-SHA256 of the payload with the secret.
+func checkGithubSignature(secret []byte, signature string, payload []byte) bool {
+	sign, ok := strings.CutPrefix(signature, githubSignaturePrefix)
+	if !ok {
+		return false
+	}
+
+	got, err := hex.DecodeString(sign)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+
+	return hmac.Equal(got, mac.Sum(nil))
+}
@@ -0,0 +1,174 @@
+package chttp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+)
+
+const testGithubSecret = "It's a Secret to Everybody"
+
+// makeTestGithubController returns the controller receiving the GitHub webhooks with the team
+// of the fixtures' users 'alice', 'bob' and 'carol' requiring one approval for the merge.
+func makeTestGithubController(t *testing.T) HttpController {
+	t.Helper()
+
+	contr := makeTestController(t, WithGithubWebhook(testGithubSecret))
+	addTestTeams(t, contr, `{"team_name":"backend","merge_policy":{"required_approvals":1},"members":[
+		{"user_id":"alice","username":"Alice","is_active":true},
+		{"user_id":"bob","username":"Bob","is_active":true},
+		{"user_id":"carol","username":"Carol","is_active":true}]}`)
+
+	return contr
+}
+
+func signTestGithubPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return githubSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func deliverTestGithubEvent(t *testing.T, contr HttpController, event, fixture, signature string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	payload, err := os.ReadFile(filepath.Join("testdata", "github", fixture))
+	if err != nil {
+		t.Fatalf("couldn't read the fixture: %v", err)
+	}
+
+	if len(signature) == 0 {
+		signature = signTestGithubPayload(testGithubSecret, payload)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/github", strings.NewReader(string(payload)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(GithubEventHeader, event)
+	req.Header.Set(GithubSignatureHeader, signature)
+
+	rec := httptest.NewRecorder()
+	contr.server.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestWebhookGithubLifecycle(t *testing.T) {
+	contr := makeTestGithubController(t)
+
+	deliveries := []struct {
+		event   string
+		fixture string
+		code    int
+		status  entities.PullRequestStatus
+		errCode ErrCode
+	}{
+		{"ping", "ping.json", http.StatusNoContent, "", ""},
+		{"pull_request", "pull_request_opened_draft.json", http.StatusCreated, entities.Draft, ""},
+		{"pull_request", "pull_request_labeled.json", http.StatusNoContent, "", ""},
+		{"pull_request", "pull_request_ready_for_review.json", http.StatusOK, entities.Open, ""},
+		{"pull_request_review", "pull_request_review_changes_requested.json", http.StatusOK, entities.Open, ""},
+		{"pull_request", "pull_request_closed.json", http.StatusOK, entities.Closed, ""},
+		{"pull_request", "pull_request_reopened.json", http.StatusOK, entities.Open, ""},
+		{"pull_request", "pull_request_reopened.json", http.StatusConflict, "", InvalidTransition},
+		{"pull_request_review", "pull_request_review_approved.json", http.StatusOK, entities.Open, ""},
+		{"pull_request", "pull_request_closed_merged.json", http.StatusOK, entities.Merged, ""},
+	}
+
+	for _, delivery := range deliveries {
+		rec := deliverTestGithubEvent(t, contr, delivery.event, delivery.fixture, "")
+
+		if rec.Code != delivery.code {
+			t.Fatalf("%s: expected the code %d, got %d: %s", delivery.fixture, delivery.code, rec.Code, rec.Body.String())
+		}
+
+		if len(delivery.errCode) != 0 {
+			resp := ErrResponse{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp.Data.Code != delivery.errCode {
+				t.Fatalf("%s: expected the error %s, got %s", delivery.fixture, delivery.errCode, rec.Body.String())
+			}
+		}
+
+		if len(delivery.status) != 0 {
+			pullReq := dto.PullRequestDTO{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &pullReq); err != nil {
+				t.Fatalf("%s: couldn't decode the PR: %v", delivery.fixture, err)
+			}
+
+			if pullReq.ID != "acme/backend#42" || pullReq.Status != delivery.status {
+				t.Fatalf("%s: expected the PR 'acme/backend#42' with the status %s, got %s",
+					delivery.fixture, delivery.status, rec.Body.String())
+			}
+		}
+	}
+}
+
+func TestWebhookGithubMergeBypassesPolicy(t *testing.T) {
+	contr := makeTestGithubController(t)
+
+	for _, fixture := range []string{"pull_request_opened_draft.json", "pull_request_ready_for_review.json"} {
+		if rec := deliverTestGithubEvent(t, contr, "pull_request", fixture, ""); rec.Code >= http.StatusBadRequest {
+			t.Fatalf("%s: couldn't apply the event: %d %s", fixture, rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := deliverTestGithubEvent(t, contr, "pull_request_review", "pull_request_review_changes_requested.json", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("couldn't keep the review: %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = serveTestRequest(t, contr, http.MethodPost, "/pullRequest/merge", `{"pull_request_id":"acme/backend#42"}`)
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), string(MergeBlocked)) {
+		t.Fatalf("expected the service's merge blocked by the policy, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	// The merge made in GitHub is recorded anyway and its repeated delivery keeps the PR merged.
+	for i := 0; i != 2; i++ {
+		rec := deliverTestGithubEvent(t, contr, "pull_request", "pull_request_closed_merged.json", "")
+
+		pullReq := dto.PullRequestDTO{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &pullReq); rec.Code != http.StatusOK || err != nil ||
+			pullReq.Status != entities.Merged || pullReq.MergedAt == nil {
+			t.Fatalf("expected the PR merged in GitHub recorded, got %d %s", rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestWebhookGithubSignature(t *testing.T) {
+	contr := makeTestController(t, WithGithubWebhook(testGithubSecret))
+
+	signatures := map[string]string{
+		"no-prefix":  "7d38cdd689735b008b3c702edd92eea23791c5f6",
+		"not-hex":    githubSignaturePrefix + "zz",
+		"wrong-key":  signTestGithubPayload("other secret", []byte("{}")),
+		"wrong-algo": "sha1=7d38cdd689735b008b3c702edd92eea23791c5f6",
+	}
+
+	for name, signature := range signatures {
+		t.Run(name, func(t *testing.T) {
+			rec := deliverTestGithubEvent(t, contr, "pull_request", "pull_request_opened_draft.json", signature)
+
+			if rec.Code != http.StatusUnauthorized {
+				t.Fatalf("expected the code %d, got %d: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestWebhookGithubDisabled(t *testing.T) {
+	contr := makeTestController(t)
+
+	rec := deliverTestGithubEvent(t, contr, "ping", "ping.json", "")
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected the code %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
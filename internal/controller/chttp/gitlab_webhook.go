@@ -0,0 +1,158 @@
+package chttp
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// GitlabEventHeader defines the GitLab webhook's header keeping the event's name.
+	GitlabEventHeader = "X-Gitlab-Event"
+
+	// GitlabTokenHeader defines the GitLab webhook's header keeping the webhook's secret token.
+	GitlabTokenHeader = "X-Gitlab-Token"
+)
+
+const gitlabMergeRequestEvent = "Merge Request Hook"
+
+type (
+	// gitlabUser defines the GitLab webhook's view of the user.
+	gitlabUser struct {
+		Username string `json:"username"`
+	}
+
+	// gitlabProject defines the GitLab webhook's view of the project.
+	gitlabProject struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	}
+
+	// gitlabMergeRequest defines the GitLab webhook's view of the merge request.
+	gitlabMergeRequest struct {
+		IID            int    `json:"iid"`
+		Title          string `json:"title"`
+		Action         string `json:"action"`
+		Draft          bool   `json:"draft"`
+		WorkInProgress bool   `json:"work_in_progress"`
+	}
+
+	// gitlabDraftChange defines the GitLab webhook's view of the merge request's draft flag change.
+	gitlabDraftChange struct {
+		Previous bool `json:"previous"`
+		Current  bool `json:"current"`
+	}
+
+	// gitlabChanges defines the GitLab webhook's view of the merge request's changed attributes.
+	gitlabChanges struct {
+		Draft *gitlabDraftChange `json:"draft"`
+	}
+
+	// gitlabEvent defines the payload of the GitLab's Merge Request Hook event.
+	gitlabEvent struct {
+		ObjectKind   string             `json:"object_kind"`
+		User         gitlabUser         `json:"user"`
+		Project      gitlabProject      `json:"project"`
+		MergeRequest gitlabMergeRequest `json:"object_attributes"`
+		Changes      gitlabChanges      `json:"changes"`
+	}
+)
+
+// pullRequestID returns the service's id of the event's PR: the project's path with the merge request's iid.
+func (g gitlabEvent) pullRequestID() entities.PullRequestID {
+	return entities.PullRequestID(fmt.Sprintf("%s!%d", g.Project.PathWithNamespace, g.MergeRequest.IID))
+}
+
+// gitlabUserID returns the user's id mapped to the GitLab's username.
+// The username itself is used as the id if it isn't mapped.
+func (h *HttpController) gitlabUserID(username string) entities.UserID {
+	if id, ok := h.gitlabIdentities[username]; ok {
+		return id
+	}
+	return entities.UserID(username)
+}
+
+// handlerWebhookGitlab defines the logic of handling the GitLab's webhooks.
+// The Merge Request Hook events are applied to the PRs, the other ones are ignored.
+func (h *HttpController) handlerWebhookGitlab(eCtx echo.Context) error {
+	const op = "chttp.webhook-gitlab"
+
+	token := eCtx.Request().Header.Get(GitlabTokenHeader)
+	if subtle.ConstantTimeCompare([]byte(token), h.gitlabToken) != 1 {
+		return eCtx.JSON(http.StatusUnauthorized,
+			NewErrResponse(InvalidToken, ErrRespQueryInvalidWebhookToken.Error()))
+	}
+
+	if eCtx.Request().Header.Get(GitlabEventHeader) != gitlabMergeRequestEvent {
+		return eCtx.NoContent(http.StatusNoContent)
+	}
+
+	event := gitlabEvent{}
+	if err := eCtx.Bind(&event); err != nil || len(event.Project.PathWithNamespace) == 0 {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+
+	if len(event.User.Username) != 0 {
+		ctx = services.WithActor(ctx, h.gitlabUserID(event.User.Username))
+	}
+
+	return h.handleGitlabMergeRequest(ctx, eCtx, op, event)
+}
+
+// handleGitlabMergeRequest defines the logic of applying the GitLab's merge request's action to the PR.
+func (h *HttpController) handleGitlabMergeRequest(ctx context.Context, eCtx echo.Context, op string, event gitlabEvent) error {
+	id := event.pullRequestID()
+
+	switch event.MergeRequest.Action {
+	case "open":
+		pullReq := dto.NewPullRequestDTO()
+		pullReq.ID = id
+		pullReq.Name = event.MergeRequest.Title
+		pullReq.AuthorID = h.gitlabUserID(event.User.Username)
+		pullReq.Status = entities.Open
+
+		if event.MergeRequest.Draft || event.MergeRequest.WorkInProgress {
+			pullReq.Status = entities.Draft
+		}
+		return h.createWebhookPullRequest(ctx, eCtx, op, pullReq)
+
+	case "update":
+		if draft := event.Changes.Draft; draft != nil && draft.Previous && !draft.Current {
+			return h.setWebhookPullRequestStatus(ctx, eCtx, op, id, entities.Draft, entities.Open)
+		}
+
+	case "reopen":
+		return h.setWebhookPullRequestStatus(ctx, eCtx, op, id, entities.Closed, entities.Open)
+
+	case "close":
+		return h.setWebhookPullRequestStatus(ctx, eCtx, op, id, "", entities.Closed)
+
+	case "merge":
+		return h.mergeWebhookPullRequest(ctx, eCtx, op, id)
+
+	case "approval", "unapproval":
+		review := dto.PullRequestReviewDTO{
+			ID:         id,
+			ReviewerID: h.gitlabUserID(event.User.Username),
+			Decision:   entities.Approved,
+		}
+
+		// The revoked approval is kept as the neutral decision replacing the reviewer's approval.
+		if event.MergeRequest.Action == "unapproval" {
+			review.Decision = entities.Commented
+		}
+		return h.reviewWebhookPullRequest(ctx, eCtx, op, review)
+	}
+
+	return eCtx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,137 @@
+package chttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+)
+
+const testGitlabToken = "gitlab-webhook-token"
+
+// makeTestGitlabController returns the controller receiving the GitLab webhooks with the team
+// of the fixtures' users mapped to the 'alice' and 'bob'.
+func makeTestGitlabController(t *testing.T) HttpController {
+	t.Helper()
+
+	contr := makeTestController(t, WithGitlabWebhook(testGitlabToken, map[string]entities.UserID{
+		"alice.smith": "alice",
+		"bob.jones":   "bob",
+	}))
+	addTestTeams(t, contr, `{"team_name":"platform","members":[
+		{"user_id":"alice","username":"Alice","is_active":true},
+		{"user_id":"bob","username":"Bob","is_active":true}]}`)
+
+	return contr
+}
+
+func deliverTestGitlabEvent(t *testing.T, contr HttpController, event, fixture, token string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	payload, err := os.ReadFile(filepath.Join("testdata", "gitlab", fixture))
+	if err != nil {
+		t.Fatalf("couldn't read the fixture: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/gitlab", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(GitlabEventHeader, event)
+	req.Header.Set(GitlabTokenHeader, token)
+
+	rec := httptest.NewRecorder()
+	contr.server.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestWebhookGitlabLifecycle(t *testing.T) {
+	contr := makeTestGitlabController(t)
+
+	deliveries := []struct {
+		event    string
+		fixture  string
+		code     int
+		status   entities.PullRequestStatus
+		decision entities.ReviewDecision
+		errCode  ErrCode
+	}{
+		{"Push Hook", "push_hook.json", http.StatusNoContent, "", "", ""},
+		{"Merge Request Hook", "merge_request_open_draft.json", http.StatusCreated, entities.Draft, "", ""},
+		{"Merge Request Hook", "merge_request_update_labels.json", http.StatusNoContent, "", "", ""},
+		{"Merge Request Hook", "merge_request_update_ready.json", http.StatusOK, entities.Open, "", ""},
+		{"Merge Request Hook", "merge_request_approval.json", http.StatusOK, entities.Open, entities.Approved, ""},
+		{"Merge Request Hook", "merge_request_unapproval.json", http.StatusOK, entities.Open, entities.Commented, ""},
+		{"Merge Request Hook", "merge_request_close.json", http.StatusOK, entities.Closed, "", ""},
+		{"Merge Request Hook", "merge_request_reopen.json", http.StatusOK, entities.Open, "", ""},
+		{"Merge Request Hook", "merge_request_approval.json", http.StatusOK, entities.Open, entities.Approved, ""},
+		{"Merge Request Hook", "merge_request_merge.json", http.StatusOK, entities.Merged, "", ""},
+	}
+
+	for _, delivery := range deliveries {
+		rec := deliverTestGitlabEvent(t, contr, delivery.event, delivery.fixture, testGitlabToken)
+
+		if rec.Code != delivery.code {
+			t.Fatalf("%s: expected the code %d, got %d: %s", delivery.fixture, delivery.code, rec.Code, rec.Body.String())
+		}
+
+		if len(delivery.errCode) != 0 {
+			resp := ErrResponse{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp.Data.Code != delivery.errCode {
+				t.Fatalf("%s: expected the error %s, got %s", delivery.fixture, delivery.errCode, rec.Body.String())
+			}
+		}
+
+		if len(delivery.status) != 0 {
+			pullReq := dto.PullRequestDTO{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &pullReq); err != nil {
+				t.Fatalf("%s: couldn't decode the PR: %v", delivery.fixture, err)
+			}
+
+			if pullReq.ID != "platform/billing!7" || pullReq.AuthorID != "alice" || pullReq.Status != delivery.status {
+				t.Fatalf("%s: expected the PR 'platform/billing!7' of 'alice' with the status %s, got %s",
+					delivery.fixture, delivery.status, rec.Body.String())
+			}
+
+			if len(delivery.decision) != 0 && pullReq.Decisions["bob"] != delivery.decision {
+				t.Fatalf("%s: expected the bob's decision %s, got %s",
+					delivery.fixture, delivery.decision, rec.Body.String())
+			}
+		}
+	}
+}
+
+func TestWebhookGitlabMergeOfDraft(t *testing.T) {
+	contr := makeTestGitlabController(t)
+
+	if rec := deliverTestGitlabEvent(t, contr, "Merge Request Hook", "merge_request_open_draft.json", testGitlabToken); rec.Code != http.StatusCreated {
+		t.Fatalf("couldn't create the MR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	// The draft without the reviewers can't be merged by the service, but its merge in GitLab is recorded.
+	rec := deliverTestGitlabEvent(t, contr, "Merge Request Hook", "merge_request_merge.json", testGitlabToken)
+
+	pullReq := dto.PullRequestDTO{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &pullReq); rec.Code != http.StatusOK || err != nil || pullReq.Status != entities.Merged {
+		t.Fatalf("expected the MR merged in GitLab recorded, got %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestWebhookGitlabToken(t *testing.T) {
+	contr := makeTestGitlabController(t)
+
+	for name, token := range map[string]string{"empty": "", "wrong": "other-token"} {
+		t.Run(name, func(t *testing.T) {
+			rec := deliverTestGitlabEvent(t, contr, "Merge Request Hook", "merge_request_open_draft.json", token)
+
+			if rec.Code != http.StatusUnauthorized {
+				t.Fatalf("expected the code %d, got %d: %s", http.StatusUnauthorized, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
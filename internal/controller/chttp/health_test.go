@@ -0,0 +1,60 @@
+package chttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/services/usecase"
+)
+
+// testPinger defines the dependency failing the checks with the err.
+type testPinger struct {
+	err error
+}
+
+func (p testPinger) Ping(ctx context.Context) error {
+	return p.err
+}
+
+func TestHealthReadiness(t *testing.T) {
+	broker := &testPinger{}
+	contr := makeTestDepsController(t, func(deps *usecase.Deps) {
+		deps.Pingers["broker"] = broker
+	})
+
+	getHealth := func(code int) dto.HealthDTO {
+		t.Helper()
+
+		rec := serveTestRequest(t, contr, http.MethodGet, "/health/ready", "")
+		if rec.Code != code {
+			t.Fatalf("expected the %d readiness, got %d %s", code, rec.Code, rec.Body.String())
+		}
+
+		res := dto.HealthDTO{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+			t.Fatalf("couldn't decode the readiness: %v", err)
+		}
+		return res
+	}
+
+	expected := []dto.DependencyHealthDTO{{Name: "broker", Status: dto.HealthUp}, {Name: "memory", Status: dto.HealthUp}}
+	if res := getHealth(http.StatusOK); res.Status != dto.HealthUp || !slices.Equal(res.Dependencies, expected) {
+		t.Fatalf("expected every dependency up, got %+v", res)
+	}
+
+	broker.err = errors.New("connection refused")
+
+	expected[0].Status = dto.HealthDown
+	if res := getHealth(http.StatusServiceUnavailable); res.Status != dto.HealthDown || !slices.Equal(res.Dependencies, expected) {
+		t.Fatalf("expected the failing dependency down, got %+v", res)
+	}
+
+	if rec := serveTestRequest(t, contr, http.MethodGet, "/health/live", ""); rec.Code != http.StatusOK {
+		t.Fatalf("expected the service alive with the failing dependency, got %d", rec.Code)
+	}
+}
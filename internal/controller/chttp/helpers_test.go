@@ -0,0 +1,102 @@
+package chttp
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo/memory"
+	"github.com/MaKcm14/pr-service/internal/sender/shttp"
+	"github.com/MaKcm14/pr-service/internal/services"
+	"github.com/MaKcm14/pr-service/internal/services/usecase"
+)
+
+const testBootstrapToken = "bootstrap-token-of-the-tests-0123456789"
+
+// makeTestDeps returns the use-cases' dependencies over the in-memory repository.
+func makeTestDeps(log *slog.Logger, repo *memory.MemoryRepo) usecase.Deps {
+	return usecase.Deps{
+		Tx:          repo,
+		TeamRepo:    repo,
+		PrRepo:      repo,
+		UserRepo:    repo,
+		StatsRepo:   repo,
+		IdemRepo:    repo,
+		WebhookRepo: repo,
+		OutboxRepo:  repo,
+		TokenRepo:   repo,
+		Sender:      shttp.New(log),
+		Pingers:     map[string]services.Pinger{"memory": repo},
+	}
+}
+
+// makeTestController returns the controller over the empty in-memory repository.
+func makeTestController(t *testing.T, opts ...ControllerOpt) HttpController {
+	t.Helper()
+	return makeTestDepsController(t, func(*usecase.Deps) {}, opts...)
+}
+
+// makeTestDepsController returns the controller over the empty in-memory repository
+// with the use-cases' dependencies changed by the setDeps.
+func makeTestDepsController(t *testing.T, setDeps func(deps *usecase.Deps), opts ...ControllerOpt) HttpController {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	deps := makeTestDeps(log, memory.New(log))
+	setDeps(&deps)
+
+	return New(log, ":0", usecase.NewUseCase(log, deps), opts...)
+}
+
+// addTestTeams creates the teams on behalf of the bootstrap token's admin.
+func addTestTeams(t *testing.T, contr HttpController, teams ...string) {
+	t.Helper()
+
+	for _, team := range teams {
+		if rec := serveTestAuthRequest(t, contr, testBootstrapToken, http.MethodPost, "/team/add", team); rec.Code != http.StatusCreated {
+			t.Fatalf("couldn't create the test team: %d %s", rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func serveTestRequest(t *testing.T, contr HttpController, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	return serveTestAuthRequest(t, contr, "", method, target, body)
+}
+
+func serveTestAuthRequest(t *testing.T, contr HttpController, token, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if len(token) != 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	contr.server.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func createTestToken(t *testing.T, contr HttpController, body string) dto.APITokenCreatedDTO {
+	t.Helper()
+
+	rec := serveTestAuthRequest(t, contr, testBootstrapToken, http.MethodPost, "/admin/createToken", body)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("couldn't create the token: %d %s", rec.Code, rec.Body.String())
+	}
+
+	res := dto.APITokenCreatedDTO{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatalf("couldn't decode the token: %v", err)
+	}
+
+	return res
+}
@@ -0,0 +1,128 @@
+package chttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func serveTestIdempotentRequest(t *testing.T, contr HttpController, token, key, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyKeyHeader, key)
+	if len(token) != 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	contr.server.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// makeTestIdempotencyController returns the controller with the team of the users 'alice' and 'bob'.
+func makeTestIdempotencyController(t *testing.T) HttpController {
+	t.Helper()
+
+	contr := makeTestController(t)
+	addTestTeams(t, contr, `{"team_name":"backend","members":[
+		{"user_id":"alice","username":"Alice","is_active":true},
+		{"user_id":"bob","username":"Bob","is_active":true}]}`)
+
+	return contr
+}
+
+func TestIdempotentRequestReplay(t *testing.T) {
+	contr := makeTestIdempotencyController(t)
+
+	pullReq := `{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"alice"}`
+
+	created := serveTestIdempotentRequest(t, contr, "", "create-pr-1", "/pullRequest/create", pullReq)
+	if created.Code != http.StatusCreated || len(created.Header().Get(IdempotentReplayedHeader)) != 0 {
+		t.Fatalf("couldn't create the PR: %d %v %s", created.Code, created.Header(), created.Body.String())
+	}
+
+	replayed := serveTestIdempotentRequest(t, contr, "", "create-pr-1", "/pullRequest/create", pullReq)
+	if replayed.Code != http.StatusCreated || replayed.Header().Get(IdempotentReplayedHeader) != "true" ||
+		replayed.Body.String() != created.Body.String() {
+		t.Fatalf("expected the stored response replayed, got %d %v %s", replayed.Code, replayed.Header(), replayed.Body.String())
+	}
+
+	other := `{"pull_request_id":"pr-2","pull_request_name":"Add filters","author_id":"alice"}`
+	if rec := serveTestIdempotentRequest(t, contr, "", "create-pr-1", "/pullRequest/create", other); rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected the key reused for the other request rejected, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := serveTestIdempotentRequest(t, contr, "", strings.Repeat("k", maxIdempotencyKeyLen+1), "/pullRequest/create", other); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected the too long key rejected, got %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestIdempotentRequestInProgress(t *testing.T) {
+	contr := makeTestIdempotencyController(t)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	contr.server.POST("/test/slow", func(eCtx echo.Context) error {
+		close(started)
+		<-release
+		return eCtx.JSON(http.StatusOK, map[string]string{"status": "done"})
+	}, contr.idempotencyMiddleware)
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- serveTestIdempotentRequest(t, contr, "", "slow-1", "/test/slow", `{}`)
+	}()
+	<-started
+
+	if rec := serveTestIdempotentRequest(t, contr, "", "slow-1", "/test/slow", `{}`); rec.Code != http.StatusConflict {
+		t.Fatalf("expected the request in progress rejected, got %d %s", rec.Code, rec.Body.String())
+	}
+	close(release)
+
+	if rec := <-done; rec.Code != http.StatusOK {
+		t.Fatalf("expected the first request processed, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := serveTestIdempotentRequest(t, contr, "", "slow-1", "/test/slow", `{}`); rec.Code != http.StatusOK ||
+		rec.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Fatalf("expected the finished request replayed, got %d %v", rec.Code, rec.Header())
+	}
+}
+
+func TestIdempotencyKeyScopedByToken(t *testing.T) {
+	contr := makeTestAuthController(t)
+
+	first := createTestToken(t, contr, `{"name":"ci-1","scopes":["prs:write"]}`)
+	second := createTestToken(t, contr, `{"name":"ci-2","scopes":["prs:write"]}`)
+
+	pullReq := `{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"alice"}`
+
+	if rec := serveTestIdempotentRequest(t, contr, first.Token, "create", "/pullRequest/create", pullReq); rec.Code != http.StatusCreated {
+		t.Fatalf("couldn't create the PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec := serveTestIdempotentRequest(t, contr, second.Token, "create", "/pullRequest/create", pullReq)
+	if rec.Code != http.StatusConflict || len(rec.Header().Get(IdempotentReplayedHeader)) != 0 {
+		t.Fatalf("expected the other token's request processed, got %d %v %s", rec.Code, rec.Header(), rec.Body.String())
+	}
+
+	other := `{"pull_request_id":"pr-2","pull_request_name":"Add filters","author_id":"alice"}`
+	if rec := serveTestIdempotentRequest(t, contr, second.Token, "create-2", "/pullRequest/create", other); rec.Code != http.StatusCreated {
+		t.Fatalf("couldn't create the other PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := serveTestIdempotentRequest(t, contr, first.Token, "create-2", "/pullRequest/create", pullReq); rec.Code != http.StatusConflict ||
+		len(rec.Header().Get(IdempotentReplayedHeader)) != 0 {
+		t.Fatalf("expected the key of the other token not reused, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := serveTestIdempotentRequest(t, contr, first.Token, "create", "/pullRequest/create", pullReq); rec.Code != http.StatusCreated ||
+		rec.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Fatalf("expected the token's own response replayed, got %d %v", rec.Code, rec.Header())
+	}
+}
@@ -0,0 +1,78 @@
+package chttp
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/MaKcm14/pr-service/internal/metrics"
+	"github.com/MaKcm14/pr-service/internal/repo/memory"
+	"github.com/MaKcm14/pr-service/internal/services/usecase"
+)
+
+func TestMetrics(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := memory.New(log)
+
+	registry := metrics.New(log)
+	deps := makeTestDeps(log, repo)
+	deps.Metrics = registry
+
+	useCase := usecase.NewUseCase(log, deps)
+	registry.Register(metrics.NewWorkloadCollector(log, useCase))
+
+	contr := New(log, ":0", useCase, WithTokenAuth(testBootstrapToken), WithMetrics(registry))
+
+	addTestTeams(t, contr,
+		`{"team_name":"backend","members":[
+			{"user_id":"alice","username":"Alice","is_active":true},
+			{"user_id":"bob","username":"Bob","is_active":true}]}`,
+		`{"team_name":"solo","members":[{"user_id":"carol","username":"Carol","is_active":true}]}`)
+
+	rec := serveTestAuthRequest(t, contr, testBootstrapToken, http.MethodPost, "/pullRequest/create",
+		`{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"alice"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("couldn't create the PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = serveTestAuthRequest(t, contr, testBootstrapToken, http.MethodPost, "/pullRequest/create",
+		`{"pull_request_id":"pr-2","pull_request_name":"Add cache","author_id":"carol"}`)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected the PR without the candidates rejected, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	serveTestAuthRequest(t, contr, testBootstrapToken, http.MethodGet, "/team/get?team_name=backend", "")
+	serveTestAuthRequest(t, contr, testBootstrapToken, http.MethodGet, "/unknown/route", "")
+
+	if rec := serveTestAuthRequest(t, contr, "", http.MethodGet, "/metrics", ""); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected the metrics without the token unauthorized, got %d", rec.Code)
+	}
+
+	scraper := createTestToken(t, contr, `{"name":"prometheus","scopes":["metrics:read"]}`)
+	if rec := serveTestAuthRequest(t, contr, scraper.Token, http.MethodGet, "/team/get?team_name=backend", ""); rec.Code != http.StatusForbidden {
+		t.Fatalf("expected the scraper's token forbidden for the API, got %d", rec.Code)
+	}
+
+	rec = serveTestAuthRequest(t, contr, scraper.Token, http.MethodGet, "/metrics", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("couldn't scrape the metrics: %d %s", rec.Code, rec.Body.String())
+	}
+
+	for _, expected := range []string{
+		`pr_service_http_requests_total{code="200",method="GET",route="/team/get"} 1`,
+		`pr_service_http_requests_total{code="201",method="POST",route="/pullRequest/create"} 1`,
+		`pr_service_http_requests_total{code="404",method="GET",route="unmatched"} 1`,
+		`pr_service_http_request_duration_seconds_count{method="POST",route="/team/add"} 2`,
+		`pr_service_no_candidate_failures_total{operation="create"} 1`,
+		`pr_service_team_open_pull_requests{team="backend"} 1`,
+		`pr_service_team_open_pull_requests{team="solo"} 0`,
+		`pr_service_reviewer_open_assignments{reviewer="bob",team="backend"} 1`,
+		`pr_service_reviewer_open_assignments{reviewer="alice",team="backend"} 0`,
+	} {
+		if !strings.Contains(rec.Body.String(), expected) {
+			t.Fatalf("expected %q in the metrics, got:\n%s", expected, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package chttp
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/services"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ActorHeader defines the request's header keeping the id of the user making the request.
+const ActorHeader = "X-Actor-ID"
+
+const (
+	// IdempotencyKeyHeader defines the request's header keeping the client's idempotency key.
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader defines the response's header marking the response stored for the key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLen = 255
+)
+
+// actorMiddleware defines the logic of passing the request's actor to the use-cases through the ctx.
+func actorMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(eCtx echo.Context) error {
+		if actor := eCtx.Request().Header.Get(ActorHeader); len(actor) != 0 {
+			req := eCtx.Request()
+			eCtx.SetRequest(req.WithContext(services.WithActor(req.Context(), entities.UserID(actor))))
+		}
+		return next(eCtx)
+	}
+}
+
+// unmatchedRoute defines the route's label of the requests not matching any endpoint.
+const unmatchedRoute = "unmatched"
+
+// metricsMiddleware defines the logic of recording the request's count and latency by the endpoint's route.
+func (h *HttpController) metricsMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(eCtx echo.Context) error {
+		start := time.Now()
+
+		err := next(eCtx)
+
+		code := responseCode(eCtx, err)
+		h.metrics.ObserveRequest(eCtx.Request().Method, requestRoute(eCtx, code), code, time.Since(start))
+
+		return err
+	}
+}
+
+// tracingMiddleware defines the logic of starting the request's span continuing the trace
+// of the W3C trace context's headers. The use-cases' and the queries' spans are its children.
+func tracingMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	tracer := otel.Tracer("github.com/MaKcm14/pr-service/internal/controller/chttp")
+
+	return func(eCtx echo.Context) error {
+		req := eCtx.Request()
+		ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+
+		ctx, span := tracer.Start(ctx, req.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(req.Method),
+				semconv.URLPath(req.URL.Path),
+			),
+		)
+		defer span.End()
+
+		eCtx.SetRequest(req.WithContext(ctx))
+
+		err := next(eCtx)
+
+		code := responseCode(eCtx, err)
+		route := requestRoute(eCtx, code)
+
+		span.SetName(req.Method + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPResponseStatusCode(code))
+		if code >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(code))
+		}
+
+		return err
+	}
+}
+
+// responseCode returns the status code of the handled request's response.
+func responseCode(eCtx echo.Context, err error) int {
+	if httpErr := new(echo.HTTPError); err != nil && !eCtx.Response().Committed && errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	return eCtx.Response().Status
+}
+
+// requestRoute returns the route of the endpoint handling the request or the unmatched one.
+func requestRoute(eCtx echo.Context, code int) string {
+	route := eCtx.Path()
+	if len(route) == 0 || code == http.StatusNotFound && route == "/*" {
+		return unmatchedRoute
+	}
+	return route
+}
+
+// responseRecorder defines the response's writer keeping the copy of the written body.
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// idempotencyMiddleware defines the logic of processing the request with the Idempotency-Key header only once.
+// The client's retries with the same key and the same request get the stored response.
+// The key is scoped by the client, so the other clients' keys never match it.
+// The key isn't kept if the request failed with the server's error, so the retry is processed again.
+func (h *HttpController) idempotencyMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(eCtx echo.Context) error {
+		req := eCtx.Request()
+
+		key := req.Header.Get(IdempotencyKeyHeader)
+		if len(key) == 0 {
+			return next(eCtx)
+		} else if len(key) > maxIdempotencyKeyLen {
+			return eCtx.JSON(http.StatusBadRequest,
+				NewErrResponse(RequestDataErr, ErrRespQueryWrongIdempotencyKey.Error()))
+		}
+
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return eCtx.JSON(http.StatusBadRequest,
+				NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		key = scopeIdempotencyKey(req.Context(), key)
+
+		ctx, cancel := context.WithTimeout(req.Context(), time.Second*3)
+		defer cancel()
+
+		record, err := h.useCase.StartIdempotentRequest(ctx, key, makeRequestFingerprint(req, body))
+		if err != nil {
+			if errors.Is(err, services.ErrIdempotencyKeyReused) {
+				return eCtx.JSON(http.StatusUnprocessableEntity,
+					NewErrResponse(IdempotencyKeyReused, ErrRespQueryIdempotencyKeyReused.Error()))
+
+			} else if errors.Is(err, services.ErrIdempotencyInProgress) {
+				return eCtx.JSON(http.StatusConflict,
+					NewErrResponse(IdempotencyInProgress, ErrRespQueryIdempotencyInProgress.Error()))
+			}
+			return eCtx.JSON(http.StatusInternalServerError,
+				NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+		}
+
+		if !record.IsPending() {
+			eCtx.Response().Header().Set(IdempotentReplayedHeader, "true")
+			return eCtx.JSONBlob(record.StatusCode, record.Body)
+		}
+
+		writer := eCtx.Response().Writer
+		recorder := &responseRecorder{ResponseWriter: writer}
+
+		eCtx.Response().Writer = recorder
+		err = next(eCtx)
+		eCtx.Response().Writer = writer
+
+		// The response is already sent, so the key is processed even if the request was cancelled.
+		ctx, cancel = context.WithTimeout(context.WithoutCancel(req.Context()), time.Second*3)
+		defer cancel()
+
+		if status := eCtx.Response().Status; err != nil || status >= http.StatusInternalServerError {
+			h.useCase.ReleaseIdempotencyKey(ctx, key)
+			return err
+		}
+
+		record.StatusCode = eCtx.Response().Status
+		record.Body = recorder.body.Bytes()
+		h.useCase.SaveIdempotentResponse(ctx, record)
+
+		return nil
+	}
+}
+
+// scopeIdempotencyKey defines the logic of namespacing the client's key by the request's token,
+// so the response isn't replayed to the other client. The key of the request without the token,
+// e.g. with the disabled authentication, is namespaced by the request's actor.
+func scopeIdempotencyKey(ctx context.Context, key string) string {
+	if principal, ok := services.GetPrincipal(ctx); ok {
+		return fmt.Sprintf("token:%d:%s", principal.TokenID, key)
+	}
+	return fmt.Sprintf("actor:%q:%s", services.GetActor(ctx), key)
+}
+
+// makeRequestFingerprint defines the logic of identifying the request: the same key can't be used
+// for the different requests. The request's actor is the one resolved by the previous middlewares.
+func makeRequestFingerprint(req *http.Request, body []byte) string {
+	hash := sha256.New()
+
+	for _, part := range [][]byte{[]byte(req.Method), []byte(req.URL.Path), []byte(services.GetActor(req.Context())), body} {
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
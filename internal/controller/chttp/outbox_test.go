@@ -0,0 +1,99 @@
+package chttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/services"
+	"github.com/MaKcm14/pr-service/internal/services/usecase"
+)
+
+// testEventSink defines the sink keeping the published events.
+type testEventSink struct {
+	err    error
+	events []entities.DomainEvent
+}
+
+func (s *testEventSink) PublishEvent(ctx context.Context, event entities.DomainEvent) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, event)
+
+	return nil
+}
+
+func TestOutboxEvents(t *testing.T) {
+	sink := &testEventSink{}
+	contr := makeTestDepsController(t, func(deps *usecase.Deps) {
+		deps.Sinks = []services.EventSink{sink}
+	})
+	useCase := contr.useCase.(usecase.UseCase)
+
+	team := `{"team_name":"platform","merge_policy":{"required_approvals":1},"members":[
+		{"user_id":"dave","username":"Dave","is_active":true},
+		{"user_id":"erin","username":"Erin","is_active":true},
+		{"user_id":"frank","username":"Frank","is_active":true},
+		{"user_id":"grace","username":"Grace","is_active":true}]}`
+	addTestTeams(t, contr, team)
+
+	pullReq := `{"pull_request_id":"pr-1","pull_request_name":"Add outbox","author_id":"dave"}`
+	if rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/create", pullReq); rec.Code != http.StatusCreated {
+		t.Fatalf("couldn't create the PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/merge", `{"pull_request_id":"pr-1"}`); rec.Code != http.StatusConflict {
+		t.Fatalf("expected the merge to be blocked, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	if count, err := useCase.DispatchOutboxEvents(context.Background()); err != nil || count != 2 {
+		t.Fatalf("expected 2 dispatched events, got %d: %v", count, err)
+	}
+
+	types := make([]entities.DomainEventType, 0, len(sink.events))
+	for _, event := range sink.events {
+		types = append(types, event.Type)
+	}
+
+	if !slices.Equal(types, []entities.DomainEventType{entities.PullRequestCreated, entities.ReviewerAssigned}) {
+		t.Fatalf("expected the creation's events only, got %v", types)
+	}
+
+	payload := dto.PullRequestEventDTO{}
+	if err := json.Unmarshal(sink.events[1].Payload, &payload); err != nil {
+		t.Fatalf("couldn't decode the event's payload: %v", err)
+	}
+
+	if sink.events[1].TeamName != "platform" || sink.events[1].PullRequestID != "pr-1" || len(payload.Reviewers) == 0 {
+		t.Fatalf("expected the assignment of the pr-1's reviewers, got %+v %+v", sink.events[1], payload)
+	}
+
+	if count, err := useCase.DispatchOutboxEvents(context.Background()); err != nil || count != 0 {
+		t.Fatalf("expected the published events not to be dispatched again, got %d: %v", count, err)
+	}
+
+	sink.err = errors.New("sink is unavailable")
+
+	reassign := `{"pull_request_id":"pr-1","old_reviewer_id":"` + string(payload.Reviewers[0]) + `"}`
+	if rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/reassign", reassign); rec.Code != http.StatusOK {
+		t.Fatalf("couldn't reassign the reviewer: %d %s", rec.Code, rec.Body.String())
+	}
+
+	if count, err := useCase.DispatchOutboxEvents(context.Background()); err != nil || count != 1 {
+		t.Fatalf("expected 1 dispatched event, got %d: %v", count, err)
+	}
+
+	if count, err := useCase.DispatchOutboxEvents(context.Background()); err != nil || count != 0 {
+		t.Fatalf("expected the failed event to wait for its claim's expiration, got %d: %v", count, err)
+	}
+
+	if len(sink.events) != 2 {
+		t.Fatalf("expected the failed event not to be published, got %d events", len(sink.events))
+	}
+}
@@ -0,0 +1,85 @@
+package chttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+)
+
+func TestPullRequestGet(t *testing.T) {
+	contr := makeTestController(t)
+
+	team := `{"team_name":"frontend","members":[
+		{"user_id":"dave","username":"Dave","is_active":true},
+		{"user_id":"erin","username":"Erin","is_active":true},
+		{"user_id":"frank","username":"Frank","is_active":true},
+		{"user_id":"grace","username":"Grace","is_active":true}]}`
+	addTestTeams(t, contr, team)
+
+	pullReq := `{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"dave"}`
+	rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/create", pullReq)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("couldn't create the PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	created := dto.PullRequestDTO{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil || len(created.Reviewers) != 2 {
+		t.Fatalf("expected the 2 reviewers, got %s", rec.Body.String())
+	}
+	replaced, reviewer := created.Reviewers[0], created.Reviewers[1]
+
+	reassign := `{"pull_request_id":"pr-1","old_reviewer_id":"` + string(replaced) + `"}`
+	if rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/reassign", reassign); rec.Code != http.StatusOK {
+		t.Fatalf("couldn't reassign the reviewer: %d %s", rec.Code, rec.Body.String())
+	}
+
+	review := `{"pull_request_id":"pr-1","reviewer_id":"` + string(reviewer) + `","decision":"APPROVED"}`
+	if rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/review", review); rec.Code != http.StatusOK {
+		t.Fatalf("couldn't review the PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	inactive := `{"user_id":"` + string(reviewer) + `","is_active":false}`
+	if rec := serveTestRequest(t, contr, http.MethodPost, "/users/setIsActive", inactive); rec.Code != http.StatusOK {
+		t.Fatalf("couldn't deactivate the reviewer: %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = serveTestRequest(t, contr, http.MethodGet, "/pullRequest/get?pull_request_id=pr-1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("couldn't get the PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	res := struct {
+		PullRequest dto.PullRequestDetailsDTO `json:"pr"`
+	}{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatalf("couldn't decode the PR: %v", err)
+	}
+	details := res.PullRequest
+
+	if details.ID != "pr-1" || details.AuthorTeamName != "frontend" || details.ReassignmentsCount != 1 ||
+		len(details.ReviewersDetails) != 2 || len(details.Reviewers) != 2 {
+		t.Fatalf("unexpected PR's details: %s", rec.Body.String())
+	}
+
+	for _, info := range details.ReviewersDetails {
+		switch {
+		case info.ID == replaced:
+			t.Fatalf("the replaced reviewer is still listed: %s", rec.Body.String())
+		case info.ID == reviewer && (info.Name == "" || info.IsActive || info.Decision != entities.Approved):
+			t.Fatalf("expected the inactive reviewer's approval, got %+v", info)
+		case info.ID != reviewer && (info.Name == "" || !info.IsActive || info.Decision != ""):
+			t.Fatalf("expected the active reviewer without the decision, got %+v", info)
+		}
+	}
+
+	if rec := serveTestRequest(t, contr, http.MethodGet, "/pullRequest/get?pull_request_id=pr-2", ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected the unknown PR not found, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := serveTestRequest(t, contr, http.MethodGet, "/pullRequest/get", ""); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected the empty id rejected, got %d %s", rec.Code, rec.Body.String())
+	}
+}
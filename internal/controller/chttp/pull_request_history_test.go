@@ -0,0 +1,120 @@
+package chttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+)
+
+func serveTestActorRequest(t *testing.T, contr HttpController, actor, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(ActorHeader, actor)
+
+	rec := httptest.NewRecorder()
+	contr.server.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestPullRequestHistory(t *testing.T) {
+	contr := makeTestController(t)
+	addTestTeams(t, contr, `{"team_name":"frontend","members":[
+		{"user_id":"dave","username":"Dave","is_active":true},
+		{"user_id":"erin","username":"Erin","is_active":true},
+		{"user_id":"frank","username":"Frank","is_active":true},
+		{"user_id":"grace","username":"Grace","is_active":true}]}`)
+
+	rec := serveTestActorRequest(t, contr, "dave", http.MethodPost, "/pullRequest/create",
+		`{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"dave"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("couldn't create the PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	created := dto.PullRequestDTO{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil || len(created.Reviewers) != 2 {
+		t.Fatalf("expected the 2 reviewers, got %s", rec.Body.String())
+	}
+	replaced, deactivated := created.Reviewers[0], created.Reviewers[1]
+
+	rec = serveTestActorRequest(t, contr, string(deactivated), http.MethodPost, "/pullRequest/reassign",
+		`{"pull_request_id":"pr-1","old_reviewer_id":"`+string(replaced)+`"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("couldn't reassign the reviewer: %d %s", rec.Code, rec.Body.String())
+	}
+
+	reassigned := struct {
+		ReplacedBy entities.UserID `json:"replaced_by"`
+	}{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &reassigned); err != nil {
+		t.Fatalf("couldn't decode the reassignment: %v", err)
+	}
+
+	// The replaced reviewer is the only candidate left for the deactivated one.
+	rec = serveTestActorRequest(t, contr, "lead", http.MethodPost, "/team/deactivateUsers",
+		`{"team_name":"frontend","user_ids":["`+string(deactivated)+`"]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("couldn't deactivate the reviewer: %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec = serveTestRequest(t, contr, http.MethodGet, "/pullRequest/history?pull_request_id=pr-1", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("couldn't get the history: %d %s", rec.Code, rec.Body.String())
+	}
+
+	res := struct {
+		ID      entities.PullRequestID      `json:"pull_request_id"`
+		History []dto.ReviewerAssignmentDTO `json:"history"`
+	}{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil || res.ID != "pr-1" || len(res.History) != 4 {
+		t.Fatalf("expected the 4 records of the pr-1, got %s", rec.Body.String())
+	}
+
+	// The records' moments are checked for the order only.
+	var prevChangedAt time.Time
+	for i, record := range res.History {
+		if record.ChangedAt.IsZero() || record.ChangedAt.Before(prevChangedAt) {
+			t.Fatalf("expected the chronological records, got %+v", res.History)
+		}
+		prevChangedAt, res.History[i].ChangedAt = record.ChangedAt, time.Time{}
+	}
+
+	expected := []dto.ReviewerAssignmentDTO{
+		{ID: "pr-1", NewReviewerID: replaced, Reason: entities.AssignmentCreated, ActorID: "dave"},
+		{ID: "pr-1", NewReviewerID: deactivated, Reason: entities.AssignmentCreated, ActorID: "dave"},
+		{ID: "pr-1", OldReviewerID: replaced, NewReviewerID: reassigned.ReplacedBy, Reason: entities.AssignmentReassigned, ActorID: deactivated},
+		{ID: "pr-1", OldReviewerID: deactivated, NewReviewerID: replaced, Reason: entities.AssignmentDeactivated, ActorID: "lead"},
+	}
+
+	if !slices.Equal(res.History, expected) {
+		t.Fatalf("expected the history %+v, got %+v", expected, res.History)
+	}
+}
+
+func TestPullRequestHistoryErrors(t *testing.T) {
+	contr := makeTestController(t)
+
+	tests := []struct {
+		target string
+		code   int
+	}{
+		{"/pullRequest/history", http.StatusBadRequest},
+		{"/pullRequest/history?pull_request_id=", http.StatusBadRequest},
+		{"/pullRequest/history?pull_request_id=pr-1", http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		if rec := serveTestRequest(t, contr, http.MethodGet, test.target, ""); rec.Code != test.code {
+			t.Fatalf("%s: expected %d, got %d %s", test.target, test.code, rec.Code, rec.Body.String())
+		}
+	}
+}
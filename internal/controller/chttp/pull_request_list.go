@@ -0,0 +1,91 @@
+package chttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+// handlerPullRequestList defines the logic of handling the request for the page of the filtered PRs.
+func (h *HttpController) handlerPullRequestList(eCtx echo.Context) error {
+	const op = "chttp.pull-request-list"
+
+	filter, err := validatePullRequestsFilter(eCtx)
+	if err != nil {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongListFilter.Error()))
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+
+	res, err := h.useCase.ListPullRequests(ctx, filter)
+	if err != nil {
+		if errors.Is(err, services.ErrEntityNotFound) {
+			return eCtx.JSON(http.StatusNotFound,
+				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+		}
+		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+		return eCtx.JSON(http.StatusInternalServerError,
+			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	}
+
+	return eCtx.JSON(http.StatusOK, res)
+}
+
+// validatePullRequestsFilter checks whether the params of the PRs' listing are correct.
+// The sorting is taken from the cursor if it isn't set, and it must match the cursor's one otherwise.
+func validatePullRequestsFilter(eCtx echo.Context) (dto.PullRequestsFilterDTO, error) {
+	filter := dto.PullRequestsFilterDTO{
+		AuthorID:   entities.UserID(eCtx.QueryParam("author_id")),
+		ReviewerID: entities.UserID(eCtx.QueryParam("reviewer_id")),
+		TeamName:   eCtx.QueryParam("team_name"),
+		Status:     entities.PullRequestStatus(eCtx.QueryParam("status")),
+		SortBy:     dto.PullRequestsSortKey(eCtx.QueryParam("sort_by")),
+		Order:      dto.SortOrder(eCtx.QueryParam("order")),
+	}
+
+	if len(filter.Status) != 0 && !filter.Status.IsValid() {
+		return dto.PullRequestsFilterDTO{}, fmt.Errorf("error of the %s: wrong status", ErrQueryParam)
+	} else if len(filter.SortBy) != 0 && !filter.SortBy.IsValid() {
+		return dto.PullRequestsFilterDTO{}, fmt.Errorf("error of the %s: wrong sort_by", ErrQueryParam)
+	} else if len(filter.Order) != 0 && !filter.Order.IsValid() {
+		return dto.PullRequestsFilterDTO{}, fmt.Errorf("error of the %s: wrong order", ErrQueryParam)
+	}
+
+	after, before, err := validateTimeBounds(eCtx, "created_after", "created_before")
+	if err != nil {
+		return dto.PullRequestsFilterDTO{}, err
+	}
+	filter.CreatedAfter, filter.CreatedBefore = after, before
+
+	if param := eCtx.QueryParam("limit"); len(param) != 0 {
+		limit, err := strconv.Atoi(param)
+		if err != nil || limit <= 0 {
+			return dto.PullRequestsFilterDTO{}, fmt.Errorf("error of the %s: wrong limit", ErrQueryParam)
+		}
+		filter.Limit = limit
+	}
+
+	if param := eCtx.QueryParam("cursor"); len(param) != 0 {
+		cursor, err := dto.DecodePullRequestsCursor(param)
+		if err != nil {
+			return dto.PullRequestsFilterDTO{}, fmt.Errorf("error of the %s: %w", ErrQueryParam, err)
+		}
+
+		if err := filter.SetAfter(cursor); err != nil {
+			return dto.PullRequestsFilterDTO{}, fmt.Errorf("error of the %s: %w", ErrQueryParam, err)
+		}
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,141 @@
+package chttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+)
+
+// testListTeam defines the team of the PRs' authors and reviewers.
+const testListTeam = `{"team_name":"backend","members":[
+	{"user_id":"alice","username":"Alice","is_active":true},
+	{"user_id":"bob","username":"Bob","is_active":true},
+	{"user_id":"carol","username":"Carol","is_active":true}]}`
+
+func getTestPullRequestsPage(t *testing.T, contr HttpController, query string) ([]string, string) {
+	t.Helper()
+
+	rec := serveTestRequest(t, contr, http.MethodGet, "/pullRequest/list?"+query, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("couldn't get the PRs' page: %d %s", rec.Code, rec.Body.String())
+	}
+
+	page := dto.PullRequestsPageDTO{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+		t.Fatalf("couldn't decode the PRs' page: %v", err)
+	}
+
+	ids := make([]string, 0, len(page.PullRequests))
+	for _, pullReq := range page.PullRequests {
+		ids = append(ids, string(pullReq.ID))
+	}
+
+	return ids, page.NextCursor
+}
+
+func TestPullRequestList(t *testing.T) {
+	contr := makeTestController(t)
+	addTestTeams(t, contr, testListTeam)
+
+	for i, author := range []string{"alice", "bob", "alice", "bob", "alice"} {
+		pullReq := fmt.Sprintf(`{"pull_request_id":"pr-%d","pull_request_name":"Change %d","author_id":"%s"}`, i+1, i+1, author)
+		if rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/create", pullReq); rec.Code != http.StatusCreated {
+			t.Fatalf("couldn't create the PR: %d %s", rec.Code, rec.Body.String())
+		}
+	}
+
+	if rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/close", `{"pull_request_id":"pr-2"}`); rec.Code != http.StatusOK {
+		t.Fatalf("couldn't close the PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	var (
+		pages  [][]string
+		cursor string
+	)
+	for query := "sort_by=pull_request_id&order=asc&limit=2"; ; query = "limit=2&cursor=" + url.QueryEscape(cursor) {
+		var ids []string
+		ids, cursor = getTestPullRequestsPage(t, contr, query)
+
+		pages = append(pages, ids)
+		if len(cursor) == 0 {
+			break
+		}
+	}
+
+	if fmt.Sprint(pages) != "[[pr-1 pr-2] [pr-3 pr-4] [pr-5]]" {
+		t.Fatalf("unexpected pages: %v", pages)
+	}
+
+	cases := []struct {
+		query string
+		ids   string
+	}{
+		{query: "", ids: "[pr-5 pr-4 pr-3 pr-2 pr-1]"},
+		{query: "author_id=alice&order=asc", ids: "[pr-1 pr-3 pr-5]"},
+		{query: "status=CLOSED", ids: "[pr-2]"},
+		{query: "team_name=backend&status=OPEN&sort_by=pull_request_id", ids: "[pr-5 pr-4 pr-3 pr-1]"},
+		{query: "created_before=2000-01-01T00:00:00Z", ids: "[]"},
+		{query: "created_after=2000-01-01T00:00:00Z&author_id=bob", ids: "[pr-4 pr-2]"},
+	}
+
+	for _, test := range cases {
+		if ids, _ := getTestPullRequestsPage(t, contr, test.query); fmt.Sprint(ids) != test.ids {
+			t.Fatalf("expected %s for the %q query, got %v", test.ids, test.query, ids)
+		}
+	}
+
+	// The reviewer's PRs must be the same as the ones of the reviewer's queue.
+	rec := serveTestRequest(t, contr, http.MethodGet, "/users/getReview?user_id=carol", "")
+
+	review := struct {
+		PullRequests []dto.PullRequestDTOShort `json:"pull_requests"`
+	}{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &review); err != nil {
+		t.Fatalf("couldn't decode the reviewer's PRs: %v", err)
+	}
+
+	queue := make([]string, 0, len(review.PullRequests))
+	for _, pullReq := range review.PullRequests {
+		queue = append(queue, string(pullReq.ID))
+	}
+	slices.Sort(queue)
+
+	if ids, _ := getTestPullRequestsPage(t, contr, "reviewer_id=carol&sort_by=pull_request_id&order=asc"); !slices.Equal(ids, queue) {
+		t.Fatalf("expected the reviewer's PRs %v, got %v", queue, ids)
+	}
+}
+
+func TestPullRequestListValidation(t *testing.T) {
+	contr := makeTestController(t)
+	addTestTeams(t, contr, testListTeam)
+
+	cursor := dto.PullRequestsCursorDTO{SortBy: dto.SortByID, Order: dto.Asc, ID: "pr-1"}.Encode()
+
+	cases := []struct {
+		query string
+		code  int
+	}{
+		{query: "status=REVIEWED", code: http.StatusBadRequest},
+		{query: "sort_by=name", code: http.StatusBadRequest},
+		{query: "order=up", code: http.StatusBadRequest},
+		{query: "limit=0", code: http.StatusBadRequest},
+		{query: "created_after=2024-02-01T00:00:00Z&created_before=2024-01-01T00:00:00Z", code: http.StatusBadRequest},
+		{query: "cursor=not-a-cursor", code: http.StatusBadRequest},
+		{query: "order=desc&cursor=" + cursor, code: http.StatusBadRequest},
+		{query: "cursor=" + cursor, code: http.StatusOK},
+		{query: "author_id=dave", code: http.StatusNotFound},
+		{query: "reviewer_id=dave", code: http.StatusNotFound},
+		{query: "team_name=frontend", code: http.StatusNotFound},
+	}
+
+	for _, test := range cases {
+		if rec := serveTestRequest(t, contr, http.MethodGet, "/pullRequest/list?"+test.query, ""); rec.Code != test.code {
+			t.Fatalf("expected %d for the %q query, got %d %s", test.code, test.query, rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package chttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+)
+
+func TestPullRequestReadyKeepsReviewersCount(t *testing.T) {
+	contr := makeTestController(t)
+
+	team := `{"team_name":"frontend","members":[
+		{"user_id":"dave","username":"Dave","is_active":true},
+		{"user_id":"erin","username":"Erin","is_active":true},
+		{"user_id":"frank","username":"Frank","is_active":true},
+		{"user_id":"grace","username":"Grace","is_active":true}]}`
+	addTestTeams(t, contr, team)
+
+	drafts := []string{
+		`{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"dave","status":"DRAFT","reviewers_count":1}`,
+		`{"pull_request_id":"pr-2","pull_request_name":"Add filters","author_id":"dave","status":"DRAFT"}`,
+		`{"pull_request_id":"pr-3","pull_request_name":"Add sorting","author_id":"dave","status":"DRAFT","reviewers_count":2}`,
+	}
+	for _, draft := range drafts {
+		rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/create", draft)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("couldn't create the draft: %d %s", rec.Code, rec.Body.String())
+		}
+
+		created := dto.PullRequestDTO{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil || len(created.Reviewers) != 0 {
+			t.Fatalf("expected the draft without the reviewers, got %s", rec.Body.String())
+		}
+	}
+
+	for _, test := range []struct {
+		id        string
+		reviewers int
+	}{
+		{id: "pr-1", reviewers: 1},
+		{id: "pr-2", reviewers: 2},
+	} {
+		rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/ready", `{"pull_request_id":"`+test.id+`"}`)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("couldn't mark the %s ready: %d %s", test.id, rec.Code, rec.Body.String())
+		}
+
+		ready := dto.PullRequestDTO{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &ready); err != nil || ready.Status != entities.Open ||
+			len(ready.Reviewers) != test.reviewers {
+			t.Fatalf("expected the open %s with the %d reviewers, got %s", test.id, test.reviewers, rec.Body.String())
+		}
+	}
+
+	policy := `{"team_name":"frontend","min_reviewers":1,"max_reviewers":1}`
+	if rec := serveTestRequest(t, contr, http.MethodPost, "/team/setReviewerPolicy", policy); rec.Code != http.StatusOK {
+		t.Fatalf("couldn't set the reviewer policy: %d %s", rec.Code, rec.Body.String())
+	}
+
+	rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/ready", `{"pull_request_id":"pr-3"}`)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected the requested count out of the changed policy rejected, got %d %s", rec.Code, rec.Body.String())
+	}
+}
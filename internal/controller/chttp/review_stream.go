@@ -0,0 +1,83 @@
+package chttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+// reviewStreamHeartbeat defines how often the comment is sent to the idle stream
+// so the proxies don't close the connection.
+const reviewStreamHeartbeat = 15 * time.Second
+
+// handlerUsersReviewStream defines the logic of handling the request for streaming the reviewer's queue changes
+// as the Server-Sent Events. Every event's data is the PR in the format of the reviewer's PRs list.
+func (h *HttpController) handlerUsersReviewStream(eCtx echo.Context) error {
+	const op = "chttp.users-review-stream"
+
+	id, err := validateUserID(eCtx)
+	if err != nil {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryEmptyParam.Error()))
+	}
+
+	ctx := eCtx.Request().Context()
+
+	events, unsubscribe, err := h.useCase.SubscribeReviewQueue(ctx, entities.UserID(id.(string)))
+	if err != nil {
+		if errors.Is(err, services.ErrEntityNotFound) {
+			return eCtx.JSON(http.StatusNotFound,
+				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+		}
+		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+		return eCtx.JSON(http.StatusInternalServerError,
+			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	}
+	defer unsubscribe()
+
+	resp := eCtx.Response()
+	resp.Header().Set(echo.HeaderContentType, "text/event-stream")
+	resp.Header().Set(echo.HeaderCacheControl, "no-cache")
+	resp.Header().Set(echo.HeaderConnection, "keep-alive")
+	resp.WriteHeader(http.StatusOK)
+	resp.Flush()
+
+	ticker := time.NewTicker(reviewStreamHeartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			data, err := json.Marshal(event.PullRequest)
+			if err != nil {
+				h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+				return nil
+			}
+
+			if _, err := fmt.Fprintf(resp, "id: %d\nevent: %s\ndata: %s\n\n", event.EventID, event.Event, data); err != nil {
+				return nil
+			}
+			resp.Flush()
+
+		case <-ticker.C:
+			if _, err := fmt.Fprint(resp, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			resp.Flush()
+		}
+	}
+}
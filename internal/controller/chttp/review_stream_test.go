@@ -0,0 +1,146 @@
+package chttp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/services/usecase"
+)
+
+// testStreamTeam defines the team of the PR's author 'alice' and its reviewer 'bob'.
+const testStreamTeam = `{"team_name":"backend","members":[
+	{"user_id":"alice","username":"Alice","is_active":true},
+	{"user_id":"bob","username":"Bob","is_active":true}]}`
+
+// testStreamEvent defines the Server-Sent Event read from the stream.
+type testStreamEvent struct {
+	event string
+	data  string
+}
+
+// readTestStream reads the stream's events until the stream is closed.
+func readTestStream(resp *http.Response) <-chan testStreamEvent {
+	events := make(chan testStreamEvent, 10)
+
+	go func() {
+		defer close(events)
+
+		scanner := bufio.NewScanner(resp.Body)
+		event := testStreamEvent{}
+
+		for scanner.Scan() {
+			line := scanner.Text()
+
+			switch {
+			case len(line) == 0 && len(event.event) != 0:
+				events <- event
+				event = testStreamEvent{}
+			case strings.HasPrefix(line, "event: "):
+				event.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				event.data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+
+	return events
+}
+
+func waitTestStreamEvent(t *testing.T, events <-chan testStreamEvent) (string, dto.PullRequestDTOShort) {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatalf("the stream is closed")
+		}
+
+		pullReq := dto.PullRequestDTOShort{}
+		if err := json.Unmarshal([]byte(event.data), &pullReq); err != nil {
+			t.Fatalf("couldn't decode the event's data %q: %v", event.data, err)
+		}
+		return event.event, pullReq
+
+	case <-time.After(3 * time.Second):
+		t.Fatalf("the stream's event isn't received")
+	}
+
+	return "", dto.PullRequestDTOShort{}
+}
+
+func TestUsersReviewStream(t *testing.T) {
+	contr := makeTestController(t)
+	addTestTeams(t, contr, testStreamTeam)
+	useCase := contr.useCase.(usecase.UseCase)
+
+	server := httptest.NewServer(contr.server)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/users/reviewStream?user_id=bob")
+	if err != nil {
+		t.Fatalf("couldn't open the stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("expected the event stream, got %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	events := readTestStream(resp)
+
+	pullReq := `{"pull_request_id":"pr-1","pull_request_name":"Add streams","author_id":"alice"}`
+	if rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/create", pullReq); rec.Code != http.StatusCreated {
+		t.Fatalf("couldn't create the PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	review := `{"pull_request_id":"pr-1","reviewer_id":"bob","decision":"APPROVED"}`
+	if rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/review", review); rec.Code != http.StatusOK {
+		t.Fatalf("couldn't review the PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/merge", `{"pull_request_id":"pr-1"}`); rec.Code != http.StatusOK {
+		t.Fatalf("couldn't merge the PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	if _, err := useCase.DispatchOutboxEvents(context.Background()); err != nil {
+		t.Fatalf("couldn't dispatch the outbox's events: %v", err)
+	}
+
+	event, short := waitTestStreamEvent(t, events)
+	if event != string(entities.ReviewAssigned) || short.ID != "pr-1" || short.Status != entities.Open {
+		t.Fatalf("expected the assignment of the open pr-1, got %s %+v", event, short)
+	}
+
+	event, short = waitTestStreamEvent(t, events)
+	if event != string(entities.ReviewMerged) || short.Status != entities.Merged || short.Decision != entities.Approved {
+		t.Fatalf("expected the merge of the pr-1 approved by bob, got %s %+v", event, short)
+	}
+
+	useCase.CloseReviewStreams()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatalf("expected the stream to be closed")
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatalf("the stream isn't closed")
+	}
+}
+
+func TestUsersReviewStreamNotFound(t *testing.T) {
+	contr := makeTestController(t)
+	addTestTeams(t, contr, testStreamTeam)
+
+	rec := serveTestRequest(t, contr, http.MethodGet, "/users/reviewStream?user_id=mallory", "")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected %d for the unknown user, got %d", http.StatusNotFound, rec.Code)
+	}
+}
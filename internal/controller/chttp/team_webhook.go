@@ -0,0 +1,154 @@
+package chttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+// handlerTeamAddWebhook defines the logic of handling the request for registering the team's webhook.
+func (h *HttpController) handlerTeamAddWebhook(eCtx echo.Context) error {
+	const op = "chttp.team-add-webhook"
+
+	webhook := entities.Webhook{}
+	if err := eCtx.Bind(&webhook); err != nil || len(webhook.TeamName) == 0 {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
+	}
+
+	if !webhook.IsValid() {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongWebhook.Error()))
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+
+	res, err := h.useCase.AddTeamWebhook(ctx, webhook)
+	if err != nil {
+		if errors.Is(err, services.ErrEntityNotFound) {
+			return eCtx.JSON(http.StatusNotFound,
+				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+		}
+		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+		return eCtx.JSON(http.StatusInternalServerError,
+			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	}
+
+	return eCtx.JSON(http.StatusCreated, res)
+}
+
+// handlerTeamRemoveWebhook defines the logic of handling the request for removing the team's webhook.
+func (h *HttpController) handlerTeamRemoveWebhook(eCtx echo.Context) error {
+	const op = "chttp.team-remove-webhook"
+
+	data := dto.TeamWebhookRemoveDTO{}
+	if err := eCtx.Bind(&data); err != nil || len(data.Name) == 0 || data.WebhookID <= 0 {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongRequestData.Error()))
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+
+	if err := h.useCase.RemoveTeamWebhook(ctx, data.Name, data.WebhookID); err != nil {
+		if errors.Is(err, services.ErrEntityNotFound) {
+			return eCtx.JSON(http.StatusNotFound,
+				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+		}
+		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+		return eCtx.JSON(http.StatusInternalServerError,
+			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	}
+
+	return eCtx.NoContent(http.StatusNoContent)
+}
+
+// handlerTeamGetWebhooks defines the logic of handling the request for getting the team's webhooks.
+func (h *HttpController) handlerTeamGetWebhooks(eCtx echo.Context) error {
+	const op = "chttp.team-get-webhooks"
+
+	name, err := validateTeamName(eCtx)
+	if err != nil {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryEmptyParam.Error()))
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+
+	res, err := h.useCase.GetTeamWebhooks(ctx, name.(string))
+	if err != nil {
+		if errors.Is(err, services.ErrEntityNotFound) {
+			return eCtx.JSON(http.StatusNotFound,
+				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+		}
+		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+		return eCtx.JSON(http.StatusInternalServerError,
+			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	}
+
+	return eCtx.JSON(http.StatusOK, struct {
+		Name     string             `json:"team_name"`
+		Webhooks []entities.Webhook `json:"webhooks"`
+	}{
+		Name:     name.(string),
+		Webhooks: res,
+	})
+}
+
+// handlerWebhookDeliveries defines the logic of handling the request for inspecting the webhooks' deliveries.
+func (h *HttpController) handlerWebhookDeliveries(eCtx echo.Context) error {
+	const op = "chttp.webhook-deliveries"
+
+	filter := dto.WebhookDeliveriesFilterDTO{
+		TeamName: eCtx.QueryParam("team_name"),
+		Status:   entities.DeliveryStatus(eCtx.QueryParam("status")),
+	}
+
+	if len(filter.Status) != 0 && !filter.Status.IsValid() {
+		return eCtx.JSON(http.StatusBadRequest,
+			NewErrResponse(RequestDataErr, ErrRespQueryWrongDeliveryFilter.Error()))
+	}
+
+	if param := eCtx.QueryParam("limit"); len(param) != 0 {
+		limit, err := strconv.Atoi(param)
+		if err != nil || limit <= 0 {
+			return eCtx.JSON(http.StatusBadRequest,
+				NewErrResponse(RequestDataErr, ErrRespQueryWrongDeliveryFilter.Error()))
+		}
+		filter.Limit = limit
+	}
+
+	ctx, cancel := context.WithTimeout(eCtx.Request().Context(), time.Second*3)
+	defer cancel()
+
+	res, err := h.useCase.GetWebhookDeliveries(ctx, filter)
+	if err != nil {
+		if errors.Is(err, services.ErrEntityNotFound) {
+			return eCtx.JSON(http.StatusNotFound,
+				NewErrResponse(NotFound, ErrRespQueryNotFound.Error()))
+		}
+		h.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+
+		return eCtx.JSON(http.StatusInternalServerError,
+			NewErrResponse(ServerErr, ErrRespQueryServerError.Error()))
+	}
+
+	return eCtx.JSON(http.StatusOK, struct {
+		Deliveries []entities.WebhookDelivery `json:"deliveries"`
+	}{
+		Deliveries: res,
+	})
+}
@@ -0,0 +1,243 @@
+package chttp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/sender/shttp"
+	"github.com/MaKcm14/pr-service/internal/services/usecase"
+)
+
+const testOutboundSecret = "outbound-webhook-secret"
+
+// testWebhookTeam defines the team of the users 'alice', 'bob' and 'carol' requiring one approval for the merge.
+const testWebhookTeam = `{"team_name":"backend","merge_policy":{"required_approvals":1},"members":[
+	{"user_id":"alice","username":"Alice","is_active":true},
+	{"user_id":"bob","username":"Bob","is_active":true},
+	{"user_id":"carol","username":"Carol","is_active":true}]}`
+
+// testWebhookReceiver defines the team's webhook keeping the received events.
+type testWebhookReceiver struct {
+	mut    sync.Mutex
+	code   int
+	events []dto.WebhookEventDTO
+}
+
+func (r *testWebhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	payload, _ := io.ReadAll(req.Body)
+
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if req.Header.Get(shttp.SignatureHeader) != shttp.Sign(testOutboundSecret, req.Header.Get(shttp.TimestampHeader), payload) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	event := dto.WebhookEventDTO{}
+	if err := json.Unmarshal(payload, &event); err != nil || string(event.Event) != req.Header.Get(shttp.EventHeader) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if r.code == http.StatusOK {
+		r.events = append(r.events, event)
+	}
+	w.WriteHeader(r.code)
+}
+
+func getTestDeliveries(t *testing.T, contr HttpController, query string) []entities.WebhookDelivery {
+	t.Helper()
+
+	rec := serveTestRequest(t, contr, http.MethodGet, "/webhooks/deliveries?"+query, "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("couldn't get the deliveries: %d %s", rec.Code, rec.Body.String())
+	}
+
+	res := struct {
+		Deliveries []entities.WebhookDelivery `json:"deliveries"`
+	}{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatalf("couldn't decode the deliveries: %v", err)
+	}
+
+	return res.Deliveries
+}
+
+// sendTestDeliveries publishes the outbox's events and sends the queued deliveries.
+// The count of the sent deliveries is returned.
+func sendTestDeliveries(t *testing.T, useCase usecase.UseCase) int {
+	t.Helper()
+
+	if _, err := useCase.DispatchOutboxEvents(context.Background()); err != nil {
+		t.Fatalf("couldn't dispatch the outbox's events: %v", err)
+	}
+
+	count, err := useCase.SendWebhookDeliveries(context.Background())
+	if err != nil {
+		t.Fatalf("couldn't send the deliveries: %v", err)
+	}
+
+	return count
+}
+
+func TestTeamWebhookDeliveries(t *testing.T) {
+	contr := makeTestController(t)
+	addTestTeams(t, contr, testWebhookTeam)
+	useCase := contr.useCase.(usecase.UseCase)
+
+	receiver := &testWebhookReceiver{code: http.StatusOK}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	rec := serveTestRequest(t, contr, http.MethodPost, "/team/addWebhook",
+		`{"team_name":"backend","url":"`+server.URL+`","secret":"`+testOutboundSecret+`"}`)
+	if rec.Code != http.StatusCreated || strings.Contains(rec.Body.String(), testOutboundSecret) {
+		t.Fatalf("expected the webhook to be added without the secret, got %d %s", rec.Code, rec.Body.String())
+	}
+
+	pullReq := `{"pull_request_id":"pr-1","pull_request_name":"Add webhooks","author_id":"alice"}`
+	if rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/create", pullReq); rec.Code != http.StatusCreated {
+		t.Fatalf("couldn't create the PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	if count := sendTestDeliveries(t, useCase); count != 1 {
+		t.Fatalf("expected 1 sent delivery, got %d", count)
+	}
+
+	if len(receiver.events) != 1 || receiver.events[0].Event != entities.ReviewerAssignedEvent ||
+		receiver.events[0].PullRequest.ID != "pr-1" || len(receiver.events[0].Reviewers) == 0 {
+		t.Fatalf("expected the %s event of the pr-1, got %+v", entities.ReviewerAssignedEvent, receiver.events)
+	}
+
+	if deliveries := getTestDeliveries(t, contr, "team_name=backend&status=DELIVERED"); len(deliveries) != 1 ||
+		deliveries[0].Attempts != 1 || deliveries[0].DeliveredAt == nil {
+		t.Fatalf("expected the single delivered delivery, got %+v", deliveries)
+	}
+
+	receiver.code = http.StatusInternalServerError
+
+	review := `{"pull_request_id":"pr-1","reviewer_id":"` + string(receiver.events[0].Reviewers[0]) + `","decision":"APPROVED"}`
+	if rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/review", review); rec.Code != http.StatusOK {
+		t.Fatalf("couldn't review the PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/merge", `{"pull_request_id":"pr-1"}`); rec.Code != http.StatusOK {
+		t.Fatalf("couldn't merge the PR: %d %s", rec.Code, rec.Body.String())
+	}
+
+	if count := sendTestDeliveries(t, useCase); count != 1 {
+		t.Fatalf("expected 1 sent delivery, got %d", count)
+	}
+
+	if count := sendTestDeliveries(t, useCase); count != 0 {
+		t.Fatalf("expected the failed delivery to wait for the backoff, got %d", count)
+	}
+
+	deliveries := getTestDeliveries(t, contr, "status=PENDING")
+	if len(deliveries) != 1 || deliveries[0].Event != entities.PullRequestMergedEvent ||
+		deliveries[0].Attempts != 1 || deliveries[0].LastStatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected the failed %s delivery, got %+v", entities.PullRequestMergedEvent, deliveries)
+	}
+
+	if rec := serveTestRequest(t, contr, http.MethodGet, "/webhooks/deliveries?status=LOST", ""); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected %d for the wrong status, got %d", http.StatusBadRequest, rec.Code)
+	}
+
+	rec = serveTestRequest(t, contr, http.MethodPost, "/team/removeWebhook", `{"team_name":"backend","webhook_id":1}`)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("couldn't remove the webhook: %d %s", rec.Code, rec.Body.String())
+	}
+
+	if deliveries := getTestDeliveries(t, contr, ""); len(deliveries) != 0 {
+		t.Fatalf("expected the deliveries to be removed with the webhook, got %+v", deliveries)
+	}
+}
+
+func TestTeamWebhookValidation(t *testing.T) {
+	contr := makeTestController(t)
+	addTestTeams(t, contr, testWebhookTeam)
+
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"team_name":"backend","url":"ftp://example.com","secret":"s"}`, http.StatusBadRequest},
+		{`{"team_name":"backend","url":"http://example.com"}`, http.StatusBadRequest},
+		{`{"team_name":"frontend","url":"http://example.com","secret":"s"}`, http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		if rec := serveTestRequest(t, contr, http.MethodPost, "/team/addWebhook", test.body); rec.Code != test.code {
+			t.Fatalf("%s: expected %d, got %d", test.body, test.code, rec.Code)
+		}
+	}
+}
+
+func TestTeamChangesSurviveConcurrentRollbacks(t *testing.T) {
+	contr := makeTestController(t)
+	addTestTeams(t, contr, testWebhookTeam,
+		`{"team_name":"solo","members":[{"user_id":"dave","username":"Dave","is_active":true}]}`)
+
+	const workers = 20
+
+	var wg sync.WaitGroup
+	for i := 0; i != workers; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			rec := serveTestRequest(t, contr, http.MethodPost, "/team/addWebhook",
+				fmt.Sprintf(`{"team_name":"backend","url":"http://receiver-%d.local/hook","secret":"%s"}`, i, testOutboundSecret))
+			if rec.Code != http.StatusCreated {
+				t.Errorf("couldn't add the webhook: %d %s", rec.Code, rec.Body.String())
+			}
+		}()
+
+		// The PRs without the candidates roll their transactions back.
+		go func() {
+			defer wg.Done()
+
+			rec := serveTestRequest(t, contr, http.MethodPost, "/pullRequest/create",
+				fmt.Sprintf(`{"pull_request_id":"pr-%d","pull_request_name":"Solo","author_id":"dave"}`, i))
+			if rec.Code != http.StatusConflict {
+				t.Errorf("expected the PR without the candidates rejected, got %d %s", rec.Code, rec.Body.String())
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		if rec := serveTestRequest(t, contr, http.MethodPost, "/team/setMergePolicy",
+			`{"team_name":"backend","required_approvals":2}`); rec.Code != http.StatusOK {
+			t.Errorf("couldn't set the merge policy: %d %s", rec.Code, rec.Body.String())
+		}
+	}()
+	wg.Wait()
+
+	rec := serveTestRequest(t, contr, http.MethodGet, "/team/getWebhooks?team_name=backend", "")
+	webhooks := struct {
+		Webhooks []entities.Webhook `json:"webhooks"`
+	}{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &webhooks); err != nil || len(webhooks.Webhooks) != workers {
+		t.Fatalf("expected %d webhooks kept, got %s", workers, rec.Body.String())
+	}
+
+	rec = serveTestRequest(t, contr, http.MethodGet, "/team/get?team_name=backend", "")
+	team := dto.TeamDTO{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &team); err != nil || team.MergePolicy.RequiredApprovals != 2 {
+		t.Fatalf("expected the merge policy kept, got %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,82 @@
+package chttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+
+	contr := makeTestController(t, WithTracing())
+	addTestTeams(t, contr, `{"team_name":"backend","members":[{"user_id":"alice","username":"Alice","is_active":true}]}`)
+
+	const (
+		traceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentSpanID = "00f067aa0ba902b7"
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-"+parentSpanID+"-01")
+	rec := httptest.NewRecorder()
+	contr.server.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("couldn't get the team: %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := serveTestRequest(t, contr, http.MethodGet, "/team/get?team_name=unknown", ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected the unknown team not found, got %d", rec.Code)
+	}
+
+	spans := make(map[string][]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = append(spans[span.Name()], span)
+	}
+
+	if len(spans["GET /team/get"]) != 2 || len(spans["iteam.get-team"]) != 2 {
+		t.Fatalf("expected the handler's and the use-case's spans for both requests, got %v", spans)
+	}
+
+	handler, useCase := spans["GET /team/get"][0], spans["iteam.get-team"][0]
+
+	if handler.SpanContext().TraceID().String() != traceID || handler.Parent().SpanID().String() != parentSpanID {
+		t.Fatalf("expected the handler's span continuing the incoming trace, got %s with the parent %s",
+			handler.SpanContext().TraceID(), handler.Parent().SpanID())
+	}
+
+	if !handler.Parent().IsRemote() {
+		t.Fatalf("expected the handler's span parent to be the remote one")
+	}
+
+	if useCase.Parent().SpanID() != handler.SpanContext().SpanID() {
+		t.Fatalf("expected the use-case's span to be the child of the handler's one")
+	}
+
+	if useCase.Status().Code != codes.Unset {
+		t.Fatalf("expected the successful use-case's span, got %v", useCase.Status())
+	}
+
+	if failed := spans["iteam.get-team"][1]; failed.Status().Code != codes.Error {
+		t.Fatalf("expected the failed use-case's span marked with the error, got %v", failed.Status())
+	}
+
+	if spans["GET /team/get"][1].SpanContext().TraceID().String() == traceID {
+		t.Fatalf("expected the request without the trace context to start the new trace")
+	}
+}
@@ -1,5 +1,7 @@
 package chttp
 
+import "github.com/MaKcm14/pr-service/internal/entities"
+
 const (
 	TeamExists     ErrCode = "TEAM_EXISTS"
 	PrExists       ErrCode = "PR_EXISTS"
@@ -9,6 +11,16 @@ const (
 	NotFound       ErrCode = "NOT_FOUND"
 	ServerErr      ErrCode = "SERVER_ERROR"
 	RequestDataErr ErrCode = "WRONG_DATA"
+
+	InvalidTransition ErrCode = "INVALID_STATUS_TRANSITION"
+	MergeBlocked      ErrCode = "MERGE_BLOCKED"
+	InvalidSignature  ErrCode = "INVALID_SIGNATURE"
+	InvalidToken      ErrCode = "INVALID_TOKEN"
+	Unauthorized      ErrCode = "UNAUTHORIZED"
+	Forbidden         ErrCode = "FORBIDDEN"
+
+	IdempotencyKeyReused  ErrCode = "IDEMPOTENCY_KEY_REUSED"
+	IdempotencyInProgress ErrCode = "IDEMPOTENCY_IN_PROGRESS"
 )
 
 // ErrCode defines the string error's view description.
@@ -16,8 +28,9 @@ type ErrCode string
 
 // ErrData defines the object describes the error's data.
 type ErrData struct {
-	Code    ErrCode `json:"code"`
-	Message string  `json:"message"`
+	Code       ErrCode                       `json:"code"`
+	Message    string                        `json:"message"`
+	UnmetRules []entities.MergeRuleViolation `json:"unmet_rules,omitempty"`
 }
 
 // ErrResponse defines the object that returns in case of errors.
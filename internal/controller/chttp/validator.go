@@ -2,6 +2,7 @@ package chttp
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
@@ -29,3 +30,43 @@ func validateUserID(eCtx echo.Context) (any, error) {
 
 	return userID, nil
 }
+
+func validatePullRequestID(eCtx echo.Context) (any, error) {
+	id := eCtx.QueryParam("pull_request_id")
+
+	if len(id) == 0 {
+		return nil, fmt.Errorf("error of the %s: %w", ErrQueryParam, ErrQueryEmptyParam)
+	}
+
+	return id, nil
+}
+
+// validateTimeWindow checks whether the optional 'from' and 'to' params are the correct RFC3339 window.
+func validateTimeWindow(eCtx echo.Context) (*time.Time, *time.Time, error) {
+	return validateTimeBounds(eCtx, "from", "to")
+}
+
+// validateTimeBounds checks whether the optional params are the correct RFC3339 window
+// where the lower bound is before the upper one.
+func validateTimeBounds(eCtx echo.Context, lower string, upper string) (*time.Time, *time.Time, error) {
+	bounds := [2]*time.Time{}
+
+	for i, name := range []string{lower, upper} {
+		param := eCtx.QueryParam(name)
+		if len(param) == 0 {
+			continue
+		}
+
+		moment, err := time.Parse(time.RFC3339, param)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error of the %s: %w", ErrQueryParam, err)
+		}
+		bounds[i] = &moment
+	}
+
+	if bounds[0] != nil && bounds[1] != nil && !bounds[0].Before(*bounds[1]) {
+		return nil, nil, fmt.Errorf("error of the %s: '%s' must be before '%s'", ErrQueryParam, lower, upper)
+	}
+
+	return bounds[0], bounds[1], nil
+}
@@ -0,0 +1,78 @@
+package chttp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/labstack/echo/v4"
+)
+
+// createWebhookPullRequest defines the common logic of creating the PR opened in the VCS.
+func (h *HttpController) createWebhookPullRequest(
+	ctx context.Context,
+	eCtx echo.Context,
+	op string,
+	pullReq dto.PullRequestDTO,
+) error {
+	res, err := h.useCase.CreatePullRequest(ctx, pullReq)
+	if err != nil {
+		return h.respondPullRequestCreateErr(eCtx, op, err)
+	}
+
+	return eCtx.JSON(http.StatusCreated, res)
+}
+
+// setWebhookPullRequestStatus defines the common logic of changing the PR's status changed in the VCS.
+// The current PR's status must be equal to the from one if it's set.
+func (h *HttpController) setWebhookPullRequestStatus(
+	ctx context.Context,
+	eCtx echo.Context,
+	op string,
+	id entities.PullRequestID,
+	from entities.PullRequestStatus,
+	to entities.PullRequestStatus,
+) error {
+	pullReq := dto.NewPullRequestDTO()
+	pullReq.ID = id
+	pullReq.Status = from
+
+	res, err := h.useCase.SetPullRequestStatus(ctx, to, pullReq)
+	if err != nil {
+		return h.respondPullRequestStatusErr(eCtx, op, err)
+	}
+
+	return eCtx.JSON(http.StatusOK, res)
+}
+
+// mergeWebhookPullRequest defines the common logic of recording the PR's merge made in the VCS.
+// The merge has already happened, so it isn't checked against the merge policy.
+func (h *HttpController) mergeWebhookPullRequest(
+	ctx context.Context,
+	eCtx echo.Context,
+	op string,
+	id entities.PullRequestID,
+) error {
+	res, err := h.useCase.MarkPullRequestMerged(ctx, id)
+	if err != nil {
+		return h.respondPullRequestStatusErr(eCtx, op, err)
+	}
+
+	return eCtx.JSON(http.StatusOK, res)
+}
+
+// reviewWebhookPullRequest defines the common logic of keeping the reviewer's decision left in the VCS.
+func (h *HttpController) reviewWebhookPullRequest(
+	ctx context.Context,
+	eCtx echo.Context,
+	op string,
+	review dto.PullRequestReviewDTO,
+) error {
+	res, err := h.useCase.ReviewPullRequest(ctx, review)
+	if err != nil {
+		return h.respondPullRequestReviewErr(eCtx, op, err)
+	}
+
+	return eCtx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,10 @@
+package entities
+
+const (
+	AssignmentCreated     AssignmentReason = "CREATED"
+	AssignmentReassigned  AssignmentReason = "REASSIGNED"
+	AssignmentDeactivated AssignmentReason = "DEACTIVATED"
+)
+
+// AssignmentReason defines the reason of the PR's reviewers change.
+type AssignmentReason string
@@ -0,0 +1,21 @@
+package dto
+
+import "github.com/MaKcm14/pr-service/internal/entities"
+
+// PullRequestEventDTO defines the dto object for the domain event's payload.
+// The reviewers' fields are set depending on the event's type.
+type PullRequestEventDTO struct {
+	PullRequest   PullRequestDTO    `json:"pull_request"`
+	Reviewers     []entities.UserID `json:"reviewers,omitempty"`
+	OldReviewerID entities.UserID   `json:"old_reviewer_id,omitempty"`
+	NewReviewerID entities.UserID   `json:"new_reviewer_id,omitempty"`
+	ActorID       entities.UserID   `json:"actor_id,omitempty"`
+}
+
+// ReviewQueueEventDTO defines the dto object for the change of the reviewer's queue.
+// The PR is described from the reviewer's point of view like in the reviewer's PRs list.
+type ReviewQueueEventDTO struct {
+	EventID     entities.EventID
+	Event       entities.ReviewQueueEventType
+	PullRequest PullRequestDTOShort
+}
@@ -0,0 +1,21 @@
+package dto
+
+const (
+	HealthUp   HealthStatus = "UP"
+	HealthDown HealthStatus = "DOWN"
+)
+
+// HealthStatus defines the status of the service or its dependency.
+type HealthStatus string
+
+// DependencyHealthDTO defines the dto object for the dependency's health view.
+type DependencyHealthDTO struct {
+	Name   string       `json:"name"`
+	Status HealthStatus `json:"status"`
+}
+
+// HealthDTO defines the dto object for the service's health view.
+type HealthDTO struct {
+	Status       HealthStatus          `json:"status"`
+	Dependencies []DependencyHealthDTO `json:"dependencies,omitempty"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+// IdempotencyRecordDTO defines the dto object for the response kept for the client's idempotency key.
+// The record is pending while the StatusCode is zero: the request is still being processed.
+type IdempotencyRecordDTO struct {
+	Key         string
+	Fingerprint string
+	StatusCode  int
+	Body        []byte
+	CreatedAt   time.Time
+}
+
+// IsPending checks whether the request with the current key is still being processed.
+func (i IdempotencyRecordDTO) IsPending() bool {
+	return i.StatusCode == 0
+}
@@ -1,19 +1,27 @@
 package dto
 
 import (
+	"maps"
 	"time"
 
 	"github.com/MaKcm14/pr-service/internal/entities"
 )
 
 type PullRequestDTO struct {
-	ID        entities.PullRequestID     `json:"pull_request_id"`
-	Name      string                     `json:"pull_request_name"`
-	Status    entities.PullRequestStatus `json:"status"`
-	CreatedAt *time.Time                 `json:"created_at"`
-	MergedAt  *time.Time                 `json:"merged_at"`
-	AuthorID  entities.UserID            `json:"author_id"`
-	Reviewers []entities.UserID          `json:"assigned_reviewers"`
+	ID             entities.PullRequestID     `json:"pull_request_id"`
+	Name           string                     `json:"pull_request_name"`
+	Status         entities.PullRequestStatus `json:"status"`
+	CreatedAt      *time.Time                 `json:"created_at"`
+	MergedAt       *time.Time                 `json:"merged_at"`
+	AuthorID       entities.UserID            `json:"author_id"`
+	Reviewers      []entities.UserID          `json:"assigned_reviewers"`
+	ReviewersCount *int                       `json:"reviewers_count,omitempty"`
+
+	// RequestedReviewers defines the reviewers count requested at the PR's creation and kept in the repo.
+	RequestedReviewers *int `json:"-"`
+
+	// Decisions defines the latest decision of the author and every current reviewer who has reviewed the PR.
+	Decisions map[entities.UserID]entities.ReviewDecision `json:"review_decisions,omitempty"`
 }
 
 func NewPullRequestDTO() PullRequestDTO {
@@ -44,6 +52,15 @@ func PullRequestToPullRequestDTO(pullReq entities.PullRequest) PullRequestDTO {
 	for _, user := range pullReq.Reviewers {
 		dto.Reviewers = append(dto.Reviewers, user.ID)
 	}
+
+	if len(pullReq.Decisions) != 0 {
+		dto.Decisions = maps.Clone(pullReq.Decisions)
+	}
+
+	if pullReq.RequestedReviewers != nil {
+		dto.RequestedReviewers = new(int)
+		(*dto.RequestedReviewers) = (*pullReq.RequestedReviewers)
+	}
 	return dto
 }
 
@@ -52,6 +69,9 @@ type PullRequestDTOShort struct {
 	Name     string                     `json:"pull_request_name"`
 	Status   entities.PullRequestStatus `json:"status"`
 	AuthorID entities.UserID            `json:"author_id"`
+
+	// Decision defines the reviewer's latest decision in the reviewer's PRs list.
+	Decision entities.ReviewDecision `json:"review_decision,omitempty"`
 }
 
 func PullRequestToPullRequestDTOShort(pullRequest entities.PullRequest) PullRequestDTOShort {
@@ -81,6 +101,7 @@ func PullRequestDTOToPullRequest(pullReq PullRequestDTO) entities.PullRequest {
 			ID: pullReq.AuthorID,
 		},
 		Reviewers: make(map[entities.UserID]entities.User, len(pullReq.Reviewers)),
+		Decisions: maps.Clone(pullReq.Decisions),
 	}
 	if pullReq.CreatedAt != nil {
 		obj.CreatedAt = new(time.Time)
@@ -92,6 +113,11 @@ func PullRequestDTOToPullRequest(pullReq PullRequestDTO) entities.PullRequest {
 		(*obj.MergedAt) = (*pullReq.MergedAt)
 	}
 
+	if pullReq.RequestedReviewers != nil {
+		obj.RequestedReviewers = new(int)
+		(*obj.RequestedReviewers) = (*pullReq.RequestedReviewers)
+	}
+
 	for _, userID := range pullReq.Reviewers {
 		obj.Reviewers[userID] = entities.User{
 			ID: userID,
@@ -105,3 +131,48 @@ type PullRequestChangeReviewerDTO struct {
 	ID            entities.PullRequestID `json:"pull_request_id"`
 	OldReviewerID entities.UserID        `json:"old_reviewer_id"`
 }
+
+// ReviewerChangeDTO defines the dto object for the PR's reviewer change.
+// The empty NewReviewerID means the reviewer is removed without the replacement.
+type ReviewerChangeDTO struct {
+	ID            entities.PullRequestID `json:"pull_request_id"`
+	OldReviewerID entities.UserID        `json:"old_reviewer_id"`
+	NewReviewerID entities.UserID        `json:"new_reviewer_id,omitempty"`
+}
+
+// ReviewerAssignmentDTO defines the dto object for the record of the PR's reviewers change history.
+// The empty OldReviewerID means the reviewer is assigned and the empty NewReviewerID means the reviewer is removed.
+type ReviewerAssignmentDTO struct {
+	ID            entities.PullRequestID    `json:"pull_request_id"`
+	OldReviewerID entities.UserID           `json:"old_reviewer_id,omitempty"`
+	NewReviewerID entities.UserID           `json:"new_reviewer_id,omitempty"`
+	Reason        entities.AssignmentReason `json:"reason"`
+	ActorID       entities.UserID           `json:"actor_id,omitempty"`
+	ChangedAt     time.Time                 `json:"changed_at"`
+}
+
+// PullRequestReviewDTO defines the dto object for the reviewer's decision on the PR.
+type PullRequestReviewDTO struct {
+	ID         entities.PullRequestID  `json:"pull_request_id"`
+	ReviewerID entities.UserID         `json:"reviewer_id"`
+	Decision   entities.ReviewDecision `json:"decision"`
+	CreatedAt  time.Time               `json:"created_at"`
+}
+
+// PullRequestDetailsDTO defines the dto object for the PR with the data of its participants.
+// ReassignmentsCount is the count of the reviewers replaced with the other ones.
+type PullRequestDetailsDTO struct {
+	PullRequestDTO
+
+	AuthorTeamName     string               `json:"author_team_name"`
+	ReviewersDetails   []ReviewerDetailsDTO `json:"reviewers"`
+	ReassignmentsCount int                  `json:"reassignments_count"`
+}
+
+// ReviewerDetailsDTO defines the dto object for the PR's reviewer with the reviewer's latest decision.
+type ReviewerDetailsDTO struct {
+	ID       entities.UserID         `json:"user_id"`
+	Name     string                  `json:"username"`
+	IsActive bool                    `json:"is_active"`
+	Decision entities.ReviewDecision `json:"review_decision,omitempty"`
+}
@@ -0,0 +1,123 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+)
+
+const (
+	SortByCreatedAt PullRequestsSortKey = "created_at"
+	SortByID        PullRequestsSortKey = "pull_request_id"
+)
+
+// PullRequestsSortKey defines the field the PRs' listing is sorted by.
+// The PRs with the same created_at are sorted by the id.
+type PullRequestsSortKey string
+
+func (s PullRequestsSortKey) IsValid() bool {
+	return s == SortByCreatedAt || s == SortByID
+}
+
+const (
+	Asc  SortOrder = "asc"
+	Desc SortOrder = "desc"
+)
+
+// SortOrder defines the direction of the listing's sorting.
+type SortOrder string
+
+func (s SortOrder) IsValid() bool {
+	return s == Asc || s == Desc
+}
+
+// PullRequestsFilterDTO defines the dto object for filtering and paging the PRs' listing.
+// The empty fields aren't taken into account. The PRs created at the CreatedAfter are included
+// and the ones created at the CreatedBefore aren't.
+type PullRequestsFilterDTO struct {
+	AuthorID      entities.UserID
+	ReviewerID    entities.UserID
+	TeamName      string
+	Status        entities.PullRequestStatus
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+
+	SortBy PullRequestsSortKey
+	Order  SortOrder
+
+	// After defines the position the page starts after.
+	After *PullRequestsCursorDTO
+	Limit int
+}
+
+// SetAfter defines the logic of starting the page after the cursor's position.
+// The sorting is taken from the cursor if it isn't set, and it must match the cursor's one otherwise.
+func (f *PullRequestsFilterDTO) SetAfter(cursor PullRequestsCursorDTO) error {
+	if len(f.SortBy) == 0 {
+		f.SortBy = cursor.SortBy
+	}
+
+	if len(f.Order) == 0 {
+		f.Order = cursor.Order
+	}
+
+	if f.SortBy != cursor.SortBy || f.Order != cursor.Order {
+		return fmt.Errorf("the cursor of the other sorting")
+	}
+	f.After = &cursor
+
+	return nil
+}
+
+// PullRequestsPageDTO defines the dto object for the page of the PRs' listing.
+// The empty NextCursor means the page is the last one.
+type PullRequestsPageDTO struct {
+	PullRequests []PullRequestDTO `json:"pull_requests"`
+	NextCursor   string           `json:"next_cursor,omitempty"`
+}
+
+// PullRequestsCursorDTO defines the position of the PRs' listing right after the PR.
+// The cursor is valid only for the listing with the same sorting.
+type PullRequestsCursorDTO struct {
+	SortBy    PullRequestsSortKey    `json:"sort_by"`
+	Order     SortOrder              `json:"order"`
+	ID        entities.PullRequestID `json:"pull_request_id"`
+	CreatedAt *time.Time             `json:"created_at,omitempty"`
+}
+
+func MakePullRequestsCursor(sortBy PullRequestsSortKey, order SortOrder, pullReq PullRequestDTO) PullRequestsCursorDTO {
+	return PullRequestsCursorDTO{
+		SortBy:    sortBy,
+		Order:     order,
+		ID:        pullReq.ID,
+		CreatedAt: pullReq.CreatedAt,
+	}
+}
+
+// Encode returns the opaque view of the cursor passed to the client.
+func (c PullRequestsCursorDTO) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePullRequestsCursor returns the cursor from its opaque view.
+func DecodePullRequestsCursor(cursor string) (PullRequestsCursorDTO, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return PullRequestsCursorDTO{}, fmt.Errorf("wrong cursor's encoding: %w", err)
+	}
+
+	var res PullRequestsCursorDTO
+	if err := json.Unmarshal(data, &res); err != nil {
+		return PullRequestsCursorDTO{}, fmt.Errorf("wrong cursor's data: %w", err)
+	}
+
+	if !res.SortBy.IsValid() || !res.Order.IsValid() || len(res.ID) == 0 {
+		return PullRequestsCursorDTO{}, fmt.Errorf("wrong cursor's position")
+	}
+
+	return res, nil
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+)
+
+// StatsFilterDTO defines the dto object for the statistics' request.
+// The empty fields aren't used for the filtering.
+// The PRs are counted if they were created within [From, To) and
+// the reassignments are counted if they happened within it.
+type StatsFilterDTO struct {
+	TeamName string
+	UserID   entities.UserID
+	From     *time.Time
+	To       *time.Time
+}
+
+// ReviewStatsDTO defines the dto object for the review's statistics.
+type ReviewStatsDTO struct {
+	Assigned      int      `json:"assigned_pull_requests"`
+	Open          int      `json:"open_pull_requests"`
+	Merged        int      `json:"merged_pull_requests"`
+	Reassignments int      `json:"reassignments"`
+	MeanMergeTime *float64 `json:"mean_merge_time_seconds"`
+}
+
+// UserStatsDTO defines the dto object for the reviewer's statistics:
+// the PRs are the ones where the user is assigned as the reviewer and
+// the reassignments are the ones where the user was replaced.
+type UserStatsDTO struct {
+	ID       entities.UserID `json:"user_id"`
+	TeamName string          `json:"team_name"`
+	ReviewStatsDTO
+}
+
+// TeamStatsDTO defines the dto object for the team's statistics:
+// the PRs and the reassignments are the ones of the PRs authored by the team's members.
+type TeamStatsDTO struct {
+	TeamName string `json:"team_name"`
+	ReviewStatsDTO
+}
+
+// StatsDTO defines the dto object for the statistics' view.
+type StatsDTO struct {
+	From  *time.Time     `json:"from,omitempty"`
+	To    *time.Time     `json:"to,omitempty"`
+	Users []UserStatsDTO `json:"users"`
+	Teams []TeamStatsDTO `json:"teams,omitempty"`
+}
@@ -4,15 +4,19 @@ import "github.com/MaKcm14/pr-service/internal/entities"
 
 // TeamMember defines the dto object for the User's team view.
 type TeamMember struct {
-	ID       entities.UserID `json:"user_id"`
-	Name     string          `json:"username"`
-	IsActive bool            `json:"is_active"`
+	ID       entities.UserID   `json:"user_id"`
+	Name     string            `json:"username"`
+	IsActive bool              `json:"is_active"`
+	Role     entities.UserRole `json:"role"`
 }
 
 // TeamDTO defines the dto object for the Team's view.
 type TeamDTO struct {
-	Name    string       `json:"team_name"`
-	Members []TeamMember `json:"members"`
+	Name             string                    `json:"team_name"`
+	Members          []TeamMember              `json:"members"`
+	ReviewerStrategy entities.ReviewerStrategy `json:"reviewer_strategy,omitempty"`
+	ReviewerPolicy   *entities.ReviewerPolicy  `json:"reviewer_policy,omitempty"`
+	MergePolicy      entities.MergePolicy      `json:"merge_policy"`
 }
 
 func NewTeamDTO() TeamDTO {
@@ -26,6 +30,7 @@ func UserToTeamMember(user entities.User) TeamMember {
 		ID:       user.ID,
 		Name:     user.Name,
 		IsActive: user.IsActive,
+		Role:     user.GetRole(),
 	}
 }
 
@@ -33,8 +38,47 @@ func TeamToTeamDTO(team entities.Team) TeamDTO {
 	dto := NewTeamDTO()
 
 	dto.Name = team.Name
+	dto.ReviewerStrategy = team.ReviewerStrategy
+	dto.MergePolicy = team.GetMergePolicy()
+
+	if !team.ReviewerPolicy.IsZero() {
+		dto.ReviewerPolicy = new(entities.ReviewerPolicy)
+		(*dto.ReviewerPolicy) = team.ReviewerPolicy
+	}
 	for _, member := range team.Members {
 		dto.Members = append(dto.Members, UserToTeamMember(member))
 	}
 	return dto
 }
+
+// TeamReviewerStrategyDTO defines the dto object for changing the team's reviewer strategy.
+type TeamReviewerStrategyDTO struct {
+	Name             string                    `json:"team_name"`
+	ReviewerStrategy entities.ReviewerStrategy `json:"reviewer_strategy"`
+}
+
+// TeamReviewerPolicyDTO defines the dto object for changing the team's reviewer policy.
+type TeamReviewerPolicyDTO struct {
+	Name string `json:"team_name"`
+	entities.ReviewerPolicy
+}
+
+// TeamMergePolicyDTO defines the dto object for changing the team's merge policy.
+type TeamMergePolicyDTO struct {
+	Name string `json:"team_name"`
+	entities.MergePolicy
+}
+
+// TeamDeactivateUsersDTO defines the dto object for the team's members deactivation request.
+type TeamDeactivateUsersDTO struct {
+	Name    string            `json:"team_name"`
+	UserIDs []entities.UserID `json:"user_ids"`
+}
+
+// TeamDeactivationReportDTO defines the dto object for the report of the team's members deactivation.
+type TeamDeactivationReportDTO struct {
+	Name        string              `json:"team_name"`
+	Deactivated []entities.UserID   `json:"deactivated_users"`
+	Reassigned  []ReviewerChangeDTO `json:"reassigned_reviewers"`
+	Unassigned  []ReviewerChangeDTO `json:"unassigned_reviewers"`
+}
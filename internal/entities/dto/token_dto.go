@@ -0,0 +1,16 @@
+package dto
+
+import "github.com/MaKcm14/pr-service/internal/entities"
+
+// APITokenCreatedDTO defines the dto object for the created token with its secret.
+// The secret is returned only once: it isn't stored.
+type APITokenCreatedDTO struct {
+	entities.APIToken
+
+	Token string `json:"token"`
+}
+
+// APITokenRevokeDTO defines the dto object for revoking the token.
+type APITokenRevokeDTO struct {
+	ID entities.TokenID `json:"token_id"`
+}
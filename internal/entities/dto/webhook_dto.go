@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+)
+
+// WebhookEventDTO defines the dto object for the event sent to the team's webhooks.
+type WebhookEventDTO struct {
+	Event         entities.WebhookEventType `json:"event"`
+	TeamName      string                    `json:"team_name"`
+	PullRequest   PullRequestDTO            `json:"pull_request"`
+	Reviewers     []entities.UserID         `json:"reviewers,omitempty"`
+	OldReviewerID entities.UserID           `json:"old_reviewer_id,omitempty"`
+	NewReviewerID entities.UserID           `json:"new_reviewer_id,omitempty"`
+	ActorID       entities.UserID           `json:"actor_id,omitempty"`
+	OccurredAt    time.Time                 `json:"occurred_at"`
+}
+
+// TeamWebhookRemoveDTO defines the dto object for removing the team's webhook.
+type TeamWebhookRemoveDTO struct {
+	Name      string             `json:"team_name"`
+	WebhookID entities.WebhookID `json:"webhook_id"`
+}
+
+// WebhookDeliveriesFilterDTO defines the dto object for filtering the webhooks' deliveries.
+// The empty fields aren't taken into account.
+type WebhookDeliveriesFilterDTO struct {
+	TeamName string
+	Status   entities.DeliveryStatus
+	Limit    int
+}
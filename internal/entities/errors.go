@@ -6,4 +6,8 @@ var (
 	ErrReviewerAssign    = errors.New("entities: error of assigning the reviewers")
 	ErrStatusForReassign = errors.New("entities: error of reassigning with the current PR's status")
 	ErrReviewerIsWrong   = errors.New("entities: the user is not in reviewer's list")
+	ErrNoCandidate       = errors.New("entities: there are fewer eligible candidates than the minimum of the reviewers")
+	ErrStatusTransition  = errors.New("entities: the PR's status can't be changed to the requested one")
+	ErrReviewersCount    = errors.New("entities: the reviewers count is out of the team's reviewer policy")
+	ErrStatusForReview   = errors.New("entities: error of reviewing with the current PR's status")
 )
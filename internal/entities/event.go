@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DomainEventType defines the type of the PR's change kept in the outbox.
+type DomainEventType string
+
+const (
+	PullRequestCreated DomainEventType = "PullRequestCreated"
+	ReviewerAssigned   DomainEventType = "ReviewerAssigned"
+	ReviewerReassigned DomainEventType = "ReviewerReassigned"
+	PullRequestMerged  DomainEventType = "PullRequestMerged"
+)
+
+// EventID defines the unique domain event's identifier.
+type EventID int64
+
+// DomainEvent defines the PR's change written to the outbox with the change itself.
+// The event is published to the sinks at least once, so the sinks can use the ID for the deduplication.
+type DomainEvent struct {
+	ID            EventID         `json:"event_id"`
+	Type          DomainEventType `json:"type"`
+	PullRequestID PullRequestID   `json:"pull_request_id"`
+	TeamName      string          `json:"team_name"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+}
+
+// ReviewQueueEventType defines the change of the reviewer's queue pushed to the reviewer's stream.
+type ReviewQueueEventType string
+
+const (
+	ReviewAssigned   ReviewQueueEventType = "assigned"
+	ReviewReassigned ReviewQueueEventType = "reassigned"
+	ReviewMerged     ReviewQueueEventType = "merged"
+)
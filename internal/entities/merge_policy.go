@@ -0,0 +1,74 @@
+package entities
+
+import "fmt"
+
+// DefaultRequiredApprovals defines the count of the approvals required by the default merge policy.
+const DefaultRequiredApprovals = 1
+
+const (
+	MinApprovalsRule    MergeRule = "MIN_APPROVALS"
+	ChangesResolvedRule MergeRule = "CHANGES_RESOLVED"
+)
+
+// MergeRule defines the rule of the team's merge policy.
+type MergeRule string
+
+// MergeRuleViolation defines the rule of the merge policy the PR doesn't satisfy.
+type MergeRuleViolation struct {
+	Rule    MergeRule `json:"rule"`
+	Message string    `json:"message"`
+}
+
+// MergePolicy defines the team's conditions the PR must satisfy before the merge.
+// The zero policy isn't set: the default one requiring the single approval is used instead,
+// so the PR without the reviewers can't be merged.
+type MergePolicy struct {
+	// RequiredApprovals defines the minimum count of the approvals.
+	RequiredApprovals int `json:"required_approvals"`
+
+	// RequireResolvedChanges defines whether every reviewer's latest decision mustn't request the changes.
+	RequireResolvedChanges bool `json:"require_resolved_changes"`
+
+	// AllowAuthorApproval defines whether the PR's author can approve the own PR.
+	// The author is excluded from approving by default.
+	AllowAuthorApproval bool `json:"allow_author_approval"`
+}
+
+func DefaultMergePolicy() MergePolicy {
+	return MergePolicy{
+		RequiredApprovals: DefaultRequiredApprovals,
+	}
+}
+
+// IsZero checks whether the policy wasn't set.
+func (m MergePolicy) IsZero() bool {
+	return m == MergePolicy{}
+}
+
+func (m MergePolicy) IsValid() bool {
+	return m.RequiredApprovals >= 0
+}
+
+// checkApprovals returns the violation of the approvals' minimum or nil.
+func (m MergePolicy) checkApprovals(approvals int) *MergeRuleViolation {
+	if approvals >= m.RequiredApprovals {
+		return nil
+	}
+
+	return &MergeRuleViolation{
+		Rule:    MinApprovalsRule,
+		Message: fmt.Sprintf("the PR has %d of %d required approvals", approvals, m.RequiredApprovals),
+	}
+}
+
+// checkChangesResolved returns the violation of the resolved changes' rule or nil.
+func (m MergePolicy) checkChangesResolved(requesters []UserID) *MergeRuleViolation {
+	if !m.RequireResolvedChanges || len(requesters) == 0 {
+		return nil
+	}
+
+	return &MergeRuleViolation{
+		Rule:    ChangesResolvedRule,
+		Message: fmt.Sprintf("the changes requested by %v aren't resolved", requesters),
+	}
+}
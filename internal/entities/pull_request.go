@@ -1,20 +1,46 @@
 package entities
 
 import (
-	"math/rand"
+	"slices"
 	"time"
 )
 
 const (
+	Draft  PullRequestStatus = "DRAFT"
 	Open   PullRequestStatus = "OPEN"
+	Closed PullRequestStatus = "CLOSED"
 	Merged PullRequestStatus = "MERGED"
 )
 
+// statusTransitions defines the PR's statuses that can be set after the current one.
+// The repeated merge is allowed, so the merge stays idempotent.
+var statusTransitions = map[PullRequestStatus][]PullRequestStatus{
+	Draft:  {Open, Closed},
+	Open:   {Closed, Merged},
+	Closed: {Open},
+	Merged: {Merged},
+}
+
+const (
+	DefaultMinReviewers = 1
+	DefaultMaxReviewers = 2
+)
+
 type PullRequestID string
 
 // PullRequestStatus defines the common pull-request status.
 type PullRequestStatus string
 
+func (s PullRequestStatus) IsValid() bool {
+	_, ok := statusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo checks whether the next status can be set after the current one.
+func (s PullRequestStatus) CanTransitionTo(next PullRequestStatus) bool {
+	return slices.Contains(statusTransitions[s], next)
+}
+
 // PullRequest defines the entities object describes the pull-requests.
 type PullRequest struct {
 	ID        PullRequestID
@@ -24,11 +50,17 @@ type PullRequest struct {
 	MergedAt  *time.Time
 	Author    User
 	Reviewers map[UserID]User
+	Decisions map[UserID]ReviewDecision
+
+	// RequestedReviewers defines the reviewers count requested at the PR's creation,
+	// nil means the team policy's maximum. It's kept for the reviewers assigned later, e.g. leaving the DRAFT status.
+	RequestedReviewers *int
 }
 
 func NewPullRequest() PullRequest {
 	return PullRequest{
 		Reviewers: make(map[UserID]User, 5),
+		Decisions: make(map[UserID]ReviewDecision, 5),
 	}
 }
 
@@ -42,25 +74,63 @@ func (p *PullRequest) SetMergedAtNow() {
 	(*p.MergedAt) = time.Now()
 }
 
-func (p *PullRequest) SetReviewers(team Team) error {
-	gen := makeReviewerRandGen(team.Members, nil)
-	for i := 0; i != min(len(team.Members), rand.Intn(2)+1); {
-		pos, err := gen()
-		if err != nil {
-			break
-		}
-		p.Reviewers[team.Members[pos].ID] = team.Members[pos]
-		i++
+// SetStatus defines the logic of changing the PR's status according to the statuses' transitions.
+// The merge's moment is set when the PR is merged, the repeated merge keeps the original one.
+func (p *PullRequest) SetStatus(next PullRequestStatus) error {
+	if !p.Status.CanTransitionTo(next) {
+		return ErrStatusTransition
 	}
 
-	if len(p.Reviewers) == 0 {
-		return ErrReviewerAssign
+	if p.Status == next {
+		return nil
 	}
+	p.Status = next
+
+	if next == Merged {
+		p.SetMergedAtNow()
+	}
+
 	return nil
 }
 
-func (p *PullRequest) ReassignReviewer(id UserID, team Team) (UserID, error) {
+// MarkMerged defines the logic of recording the merge already made outside of the service, e.g. in the VCS.
+// The statuses' transitions aren't checked, the repeated merge keeps the original moment.
+func (p *PullRequest) MarkMerged() {
 	if p.Status == Merged {
+		return
+	}
+	p.Status = Merged
+	p.SetMergedAtNow()
+}
+
+// SetReviewers defines the logic of assigning the reviewers chosen by the selector among the team's members.
+// The requested count must fit the team's reviewer policy, the policy's maximum is used if it's nil.
+// The count is reduced to the eligible candidates' count if it's still not less than the policy's minimum.
+// The author and the current reviewers are never chosen.
+func (p *PullRequest) SetReviewers(team Team, selector ReviewerSelector, requested *int) error {
+	policy := team.GetReviewerPolicy()
+
+	count, err := policy.GetReviewersCount(requested)
+	if err != nil {
+		return err
+	}
+
+	candidates := getReviewerCandidates(team.Members, p.getExcludedReviewers())
+	if len(candidates) < policy.MinReviewers {
+		return ErrNoCandidate
+	}
+
+	for _, user := range selector.Select(team.Name, candidates, min(count, len(candidates))) {
+		p.Reviewers[user.ID] = user
+	}
+
+	return nil
+}
+
+// ReassignReviewer defines the logic of replacing the reviewer of the open PR with the one chosen by the selector
+// among the team's members. The author and the current reviewers are never chosen.
+func (p *PullRequest) ReassignReviewer(id UserID, team Team, selector ReviewerSelector) (UserID, error) {
+	if p.Status != Open {
 		return "", ErrStatusForReassign
 	}
 
@@ -68,20 +138,100 @@ func (p *PullRequest) ReassignReviewer(id UserID, team Team) (UserID, error) {
 		return "", ErrReviewerIsWrong
 	}
 
+	candidates := getReviewerCandidates(team.Members, p.getExcludedReviewers())
+
+	res := selector.Select(team.Name, candidates, 1)
+	if len(res) == 0 {
+		return "", ErrNoCandidate
+	}
+
 	delete(p.Reviewers, id)
+	delete(p.Decisions, id)
+	p.Reviewers[res[0].ID] = res[0]
 
-	gen := makeReviewerRandGen(team.Members, []UserID{id})
+	return res[0].ID, nil
+}
 
-	pos, err := gen()
-	if err != nil {
-		return "", err
+// UnassignReviewer defines the logic of removing the reviewer of the open PR without the replacement.
+func (p *PullRequest) UnassignReviewer(id UserID) error {
+	if p.Status != Open {
+		return ErrStatusForReassign
+	}
+
+	if !p.CheckUserIsReviewer(id) {
+		return ErrReviewerIsWrong
 	}
+	delete(p.Reviewers, id)
+	delete(p.Decisions, id)
 
-	p.Reviewers[team.Members[pos].ID] = team.Members[pos]
-	return team.Members[pos].ID, nil
+	return nil
+}
+
+// SetReviewDecision defines the logic of keeping the reviewer's latest decision on the open PR.
+// The author's decision is kept only if the team's merge policy allows the author to approve the own PR.
+func (p *PullRequest) SetReviewDecision(id UserID, decision ReviewDecision, policy MergePolicy) error {
+	if p.Status != Open {
+		return ErrStatusForReview
+	}
+
+	if !p.CheckUserIsReviewer(id) && (id != p.Author.ID || !policy.AllowAuthorApproval) {
+		return ErrReviewerIsWrong
+	}
+
+	if p.Decisions == nil {
+		p.Decisions = make(map[UserID]ReviewDecision, len(p.Reviewers))
+	}
+	p.Decisions[id] = decision
+
+	return nil
+}
+
+// CheckMergePolicy returns the rules of the team's merge policy the PR doesn't satisfy.
+// Only the current reviewers' decisions are taken into account, the author's approval is counted
+// if the policy allows it.
+func (p *PullRequest) CheckMergePolicy(policy MergePolicy) []MergeRuleViolation {
+	approvals := 0
+	requesters := make([]UserID, 0, len(p.Decisions))
+
+	for id, decision := range p.Decisions {
+		if p.CheckUserIsReviewer(id) {
+			if decision == Approved {
+				approvals++
+			} else if decision == ChangesRequested {
+				requesters = append(requesters, id)
+			}
+		} else if id == p.Author.ID && policy.AllowAuthorApproval && decision == Approved {
+			approvals++
+		}
+	}
+	slices.Sort(requesters)
+
+	res := make([]MergeRuleViolation, 0, 2)
+	for _, violation := range []*MergeRuleViolation{
+		policy.checkApprovals(approvals),
+		policy.checkChangesResolved(requesters),
+	} {
+		if violation != nil {
+			res = append(res, *violation)
+		}
+	}
+
+	return res
 }
 
 func (p *PullRequest) CheckUserIsReviewer(id UserID) bool {
 	_, val := p.Reviewers[id]
 	return val
 }
+
+// getExcludedReviewers returns the users that can't be chosen as the new reviewers.
+func (p *PullRequest) getExcludedReviewers() []UserID {
+	res := make([]UserID, 0, len(p.Reviewers)+1)
+	res = append(res, p.Author.ID)
+
+	for id := range p.Reviewers {
+		res = append(res, id)
+	}
+
+	return res
+}
@@ -0,0 +1,400 @@
+package entities
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func makeTestTeam(ids ...UserID) Team {
+	team := Team{
+		Name:    "backend",
+		Members: make([]User, 0, len(ids)),
+	}
+
+	for _, id := range ids {
+		team.Members = append(team.Members, User{ID: id, Name: string(id), IsActive: true})
+	}
+
+	return team
+}
+
+func makeTestPullRequest(author UserID, reviewers ...UserID) PullRequest {
+	pullReq := NewPullRequest()
+	pullReq.ID = "pr-1"
+	pullReq.Status = Open
+	pullReq.Author = User{ID: author}
+
+	for _, id := range reviewers {
+		pullReq.Reviewers[id] = User{ID: id}
+	}
+
+	return pullReq
+}
+
+func getTestSelectors() map[string]ReviewerSelector {
+	return map[string]ReviewerSelector{
+		"random":          RandomSelector{},
+		"round-robin":     NewRoundRobinSelector(),
+		"least-loaded":    LeastLoadedSelector{Load: ReviewerLoad{"u1": 0, "u2": 5}},
+		"weighted-random": WeightedRandomSelector{Load: ReviewerLoad{"u1": 0, "u2": 5}},
+	}
+}
+
+func TestSetReviewersExcludesAuthor(t *testing.T) {
+	team := makeTestTeam("u1", "u2", "u3")
+
+	for name, selector := range getTestSelectors() {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i != 50; i++ {
+				pullReq := makeTestPullRequest("u1")
+
+				if err := pullReq.SetReviewers(team, selector, nil); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if pullReq.CheckUserIsReviewer("u1") {
+					t.Fatalf("the author was assigned as the reviewer: %v", pullReq.Reviewers)
+				}
+
+				if count := len(pullReq.Reviewers); count != DefaultMaxReviewers {
+					t.Fatalf("expected %d reviewers, got %d", DefaultMaxReviewers, count)
+				}
+			}
+		})
+	}
+}
+
+func TestSetReviewersNoCandidate(t *testing.T) {
+	team := makeTestTeam("u1", "u2")
+	team.Members[1].IsActive = false
+
+	for name, selector := range getTestSelectors() {
+		t.Run(name, func(t *testing.T) {
+			pullReq := makeTestPullRequest("u1")
+
+			if err := pullReq.SetReviewers(team, selector, nil); !errors.Is(err, ErrNoCandidate) {
+				t.Fatalf("expected %v, got %v", ErrNoCandidate, err)
+			}
+
+			if len(pullReq.Reviewers) != 0 {
+				t.Fatalf("expected no reviewers, got %v", pullReq.Reviewers)
+			}
+		})
+	}
+}
+
+func TestSetReviewersPolicy(t *testing.T) {
+	team := makeTestTeam("u1", "u2", "u3", "u4", "u5")
+	team.ReviewerPolicy = ReviewerPolicy{MinReviewers: 2, MaxReviewers: 3}
+
+	count := func(val int) *int {
+		return &val
+	}
+
+	cases := []struct {
+		name      string
+		members   int
+		requested *int
+		expected  int
+		err       error
+	}{
+		{name: "policy's maximum by default", members: 5, expected: 3},
+		{name: "requested count", members: 5, requested: count(2), expected: 2},
+		{name: "requested count below the minimum", members: 5, requested: count(1), err: ErrReviewersCount},
+		{name: "requested count above the maximum", members: 5, requested: count(4), err: ErrReviewersCount},
+		{name: "reduced to the candidates count", members: 3, expected: 2},
+		{name: "candidates below the minimum", members: 2, err: ErrNoCandidate},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			curTeam := team
+			curTeam.Members = team.Members[:c.members]
+			pullReq := makeTestPullRequest("u1")
+
+			err := pullReq.SetReviewers(curTeam, RandomSelector{}, c.requested)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("expected %v, got %v", c.err, err)
+			}
+
+			if len(pullReq.Reviewers) != c.expected {
+				t.Fatalf("expected %d reviewers, got %d", c.expected, len(pullReq.Reviewers))
+			}
+		})
+	}
+}
+
+func TestReassignReviewerExcludesAuthorAndReviewers(t *testing.T) {
+	team := makeTestTeam("u1", "u2", "u3", "u4")
+
+	for name, selector := range getTestSelectors() {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i != 50; i++ {
+				pullReq := makeTestPullRequest("u1", "u2", "u3")
+
+				id, err := pullReq.ReassignReviewer("u2", team, selector)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if id != "u4" {
+					t.Fatalf("expected the only eligible candidate u4, got %s", id)
+				}
+
+				if pullReq.CheckUserIsReviewer("u2") || !pullReq.CheckUserIsReviewer("u3") || len(pullReq.Reviewers) != 2 {
+					t.Fatalf("wrong reviewers after reassign: %v", pullReq.Reviewers)
+				}
+			}
+		})
+	}
+}
+
+func TestReassignReviewerNoCandidate(t *testing.T) {
+	team := makeTestTeam("u1", "u2", "u3")
+
+	for name, selector := range getTestSelectors() {
+		t.Run(name, func(t *testing.T) {
+			pullReq := makeTestPullRequest("u1", "u2", "u3")
+
+			if _, err := pullReq.ReassignReviewer("u2", team, selector); !errors.Is(err, ErrNoCandidate) {
+				t.Fatalf("expected %v, got %v", ErrNoCandidate, err)
+			}
+
+			if !pullReq.CheckUserIsReviewer("u2") || !pullReq.CheckUserIsReviewer("u3") {
+				t.Fatalf("the reviewers must stay unchanged: %v", pullReq.Reviewers)
+			}
+		})
+	}
+}
+
+func TestReassignReviewerWrongReviewer(t *testing.T) {
+	team := makeTestTeam("u1", "u2", "u3")
+	pullReq := makeTestPullRequest("u1", "u2")
+
+	if _, err := pullReq.ReassignReviewer("u3", team, RandomSelector{}); !errors.Is(err, ErrReviewerIsWrong) {
+		t.Fatalf("expected %v, got %v", ErrReviewerIsWrong, err)
+	}
+
+	if _, err := pullReq.ReassignReviewer("u1", team, RandomSelector{}); !errors.Is(err, ErrReviewerIsWrong) {
+		t.Fatalf("expected %v, got %v", ErrReviewerIsWrong, err)
+	}
+}
+
+func TestUnassignReviewer(t *testing.T) {
+	pullReq := makeTestPullRequest("u1", "u2", "u3")
+
+	if err := pullReq.UnassignReviewer("u2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if pullReq.CheckUserIsReviewer("u2") || !pullReq.CheckUserIsReviewer("u3") {
+		t.Fatalf("wrong reviewers after unassign: %v", pullReq.Reviewers)
+	}
+
+	if err := pullReq.UnassignReviewer("u2"); !errors.Is(err, ErrReviewerIsWrong) {
+		t.Fatalf("expected %v, got %v", ErrReviewerIsWrong, err)
+	}
+
+	pullReq.Status = Merged
+	if err := pullReq.UnassignReviewer("u3"); !errors.Is(err, ErrStatusForReassign) {
+		t.Fatalf("expected %v, got %v", ErrStatusForReassign, err)
+	}
+}
+
+func TestSetStatusTransitions(t *testing.T) {
+	cases := []struct {
+		from  PullRequestStatus
+		to    PullRequestStatus
+		valid bool
+	}{
+		{from: Draft, to: Open, valid: true},
+		{from: Draft, to: Closed, valid: true},
+		{from: Draft, to: Merged},
+		{from: Open, to: Closed, valid: true},
+		{from: Open, to: Merged, valid: true},
+		{from: Open, to: Draft},
+		{from: Closed, to: Open, valid: true},
+		{from: Closed, to: Merged},
+		{from: Merged, to: Merged, valid: true},
+		{from: Merged, to: Open},
+		{from: Merged, to: Closed},
+	}
+
+	for _, c := range cases {
+		t.Run(string(c.from)+"->"+string(c.to), func(t *testing.T) {
+			pullReq := makeTestPullRequest("u1")
+			pullReq.Status = c.from
+			if c.from == Merged {
+				pullReq.SetMergedAtNow()
+			}
+
+			err := pullReq.SetStatus(c.to)
+			if c.valid != (err == nil) {
+				t.Fatalf("expected the transition's validity %v, got %v", c.valid, err)
+			}
+
+			if err != nil && (!errors.Is(err, ErrStatusTransition) || pullReq.Status != c.from) {
+				t.Fatalf("expected %v with the unchanged status, got %v and %s", ErrStatusTransition, err, pullReq.Status)
+			}
+
+			if err == nil && (pullReq.Status != c.to || (c.to == Merged) != (pullReq.MergedAt != nil)) {
+				t.Fatalf("wrong PR's state after the transition: %s, merged at %v", pullReq.Status, pullReq.MergedAt)
+			}
+		})
+	}
+}
+
+func TestReassignReviewerNotOpen(t *testing.T) {
+	team := makeTestTeam("u1", "u2", "u3")
+
+	for _, status := range []PullRequestStatus{Draft, Closed, Merged} {
+		pullReq := makeTestPullRequest("u1", "u2")
+		pullReq.Status = status
+
+		if _, err := pullReq.ReassignReviewer("u2", team, RandomSelector{}); !errors.Is(err, ErrStatusForReassign) {
+			t.Fatalf("%s: expected %v, got %v", status, ErrStatusForReassign, err)
+		}
+	}
+}
+
+func TestSetStatusRepeatedMerge(t *testing.T) {
+	pullReq := makeTestPullRequest("u1")
+	pullReq.Status = Open
+
+	if err := pullReq.SetStatus(Merged); err != nil {
+		t.Fatalf("expected the PR to be merged, got %v", err)
+	}
+	mergedAt := *pullReq.MergedAt
+
+	if err := pullReq.SetStatus(Merged); err != nil {
+		t.Fatalf("expected the repeated merge to be allowed, got %v", err)
+	}
+
+	if pullReq.Status != Merged || !pullReq.MergedAt.Equal(mergedAt) {
+		t.Fatalf("expected the original merge's moment %v, got %v", mergedAt, pullReq.MergedAt)
+	}
+}
+
+func TestSetReviewDecision(t *testing.T) {
+	pullReq := makeTestPullRequest("u1", "u2", "u3")
+
+	if err := pullReq.SetReviewDecision("u1", Approved, MergePolicy{}); !errors.Is(err, ErrReviewerIsWrong) {
+		t.Fatalf("expected %v for the author's review, got %v", ErrReviewerIsWrong, err)
+	}
+
+	if err := pullReq.SetReviewDecision("u1", Approved, MergePolicy{AllowAuthorApproval: true}); err != nil {
+		t.Fatalf("expected the author's review to be allowed by the policy, got %v", err)
+	}
+
+	if err := pullReq.SetReviewDecision("u2", ChangesRequested, MergePolicy{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := pullReq.SetReviewDecision("u2", Approved, MergePolicy{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if decision := pullReq.Decisions["u2"]; decision != Approved {
+		t.Fatalf("expected the latest decision %s, got %s", Approved, decision)
+	}
+
+	pullReq.Status = Merged
+	if err := pullReq.SetReviewDecision("u3", Approved, MergePolicy{}); !errors.Is(err, ErrStatusForReview) {
+		t.Fatalf("expected %v for the merged PR, got %v", ErrStatusForReview, err)
+	}
+}
+
+func getViolatedRules(violations []MergeRuleViolation) []MergeRule {
+	res := make([]MergeRule, 0, len(violations))
+	for _, violation := range violations {
+		res = append(res, violation.Rule)
+	}
+	return res
+}
+
+func TestDefaultMergePolicy(t *testing.T) {
+	team := makeTestTeam("u1", "u2")
+
+	pullReq := makeTestPullRequest("u1")
+	violations := pullReq.CheckMergePolicy(team.GetMergePolicy())
+	if rules := getViolatedRules(violations); !slices.Equal(rules, []MergeRule{MinApprovalsRule}) {
+		t.Fatalf("expected the PR without the reviewers blocked by the default policy, got %v", rules)
+	}
+
+	if violations[0].Message != "the PR has 0 of 1 required approvals" {
+		t.Fatalf("expected the unmet approvals reported, got %s", violations[0].Message)
+	}
+
+	pullReq = makeTestPullRequest("u1", "u2")
+	pullReq.Decisions = map[UserID]ReviewDecision{"u2": Approved}
+	if rules := getViolatedRules(pullReq.CheckMergePolicy(team.GetMergePolicy())); len(rules) != 0 {
+		t.Fatalf("expected the approved PR allowed by the default policy, got %v", rules)
+	}
+
+	team.MergePolicy = MergePolicy{RequireResolvedChanges: true}
+	if policy := team.GetMergePolicy(); policy != team.MergePolicy {
+		t.Fatalf("expected the team's own policy, got %+v", policy)
+	}
+}
+
+func TestCheckMergePolicy(t *testing.T) {
+	cases := []struct {
+		name      string
+		policy    MergePolicy
+		decisions map[UserID]ReviewDecision
+		expected  []MergeRule
+	}{
+		{
+			name:     "zero policy",
+			expected: []MergeRule{},
+		},
+		{
+			name:      "approvals",
+			policy:    MergePolicy{RequiredApprovals: 2},
+			decisions: map[UserID]ReviewDecision{"u2": Approved, "u3": Commented},
+			expected:  []MergeRule{MinApprovalsRule},
+		},
+		{
+			name:      "replaced reviewer's approval",
+			policy:    MergePolicy{RequiredApprovals: 1},
+			decisions: map[UserID]ReviewDecision{"u4": Approved},
+			expected:  []MergeRule{MinApprovalsRule},
+		},
+		{
+			name:      "unresolved changes",
+			policy:    MergePolicy{RequiredApprovals: 1, RequireResolvedChanges: true},
+			decisions: map[UserID]ReviewDecision{"u2": Approved, "u3": ChangesRequested},
+			expected:  []MergeRule{ChangesResolvedRule},
+		},
+		{
+			name:      "unresolved changes allowed",
+			policy:    MergePolicy{RequiredApprovals: 1},
+			decisions: map[UserID]ReviewDecision{"u2": Approved, "u3": ChangesRequested},
+			expected:  []MergeRule{},
+		},
+		{
+			name:      "author excluded",
+			policy:    MergePolicy{RequiredApprovals: 2, RequireResolvedChanges: true},
+			decisions: map[UserID]ReviewDecision{"u1": Approved, "u2": Approved, "u3": ChangesRequested},
+			expected:  []MergeRule{MinApprovalsRule, ChangesResolvedRule},
+		},
+		{
+			name:      "author allowed",
+			policy:    MergePolicy{RequiredApprovals: 2, AllowAuthorApproval: true},
+			decisions: map[UserID]ReviewDecision{"u1": Approved, "u2": Approved},
+			expected:  []MergeRule{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			pullReq := makeTestPullRequest("u1", "u2", "u3")
+			pullReq.Decisions = c.decisions
+
+			if rules := getViolatedRules(pullReq.CheckMergePolicy(c.policy)); !slices.Equal(rules, c.expected) {
+				t.Fatalf("expected the violated rules %v, got %v", c.expected, rules)
+			}
+		})
+	}
+}
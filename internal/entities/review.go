@@ -0,0 +1,14 @@
+package entities
+
+const (
+	Approved         ReviewDecision = "APPROVED"
+	ChangesRequested ReviewDecision = "CHANGES_REQUESTED"
+	Commented        ReviewDecision = "COMMENTED"
+)
+
+// ReviewDecision defines the outcome of the reviewer's review.
+type ReviewDecision string
+
+func (r ReviewDecision) IsValid() bool {
+	return r == Approved || r == ChangesRequested || r == Commented
+}
@@ -0,0 +1,198 @@
+package entities
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"sync"
+)
+
+const (
+	RandomStrategy         ReviewerStrategy = "RANDOM"
+	RoundRobinStrategy     ReviewerStrategy = "ROUND_ROBIN"
+	LeastLoadedStrategy    ReviewerStrategy = "LEAST_LOADED"
+	WeightedRandomStrategy ReviewerStrategy = "WEIGHTED_RANDOM"
+)
+
+// ReviewerStrategy defines the team's strategy of choosing the reviewers.
+type ReviewerStrategy string
+
+func (r ReviewerStrategy) IsValid() bool {
+	switch r {
+	case RandomStrategy, RoundRobinStrategy, LeastLoadedStrategy, WeightedRandomStrategy:
+		return true
+	}
+	return false
+}
+
+// ReviewerLoad defines the count of the open PRs assigned to every reviewer.
+type ReviewerLoad map[UserID]int
+
+// ReviewerSelector defines the strategy of choosing the reviewers among the team's candidates.
+// The candidates are already checked to be eligible for the review.
+// Select returns at most count candidates.
+type ReviewerSelector interface {
+	Select(teamName string, candidates []User, count int) []User
+}
+
+// RandomSelector defines the strategy of choosing the reviewers uniformly at random.
+type RandomSelector struct{}
+
+func (RandomSelector) Select(_ string, candidates []User, count int) []User {
+	res := make([]User, 0, max(count, 0))
+	gen := makeReviewerRandGen(candidates, nil)
+
+	for len(res) < count {
+		pos, err := gen()
+		if err != nil {
+			break
+		}
+		res = append(res, candidates[pos])
+	}
+
+	return res
+}
+
+// RoundRobinSelector defines the strategy of choosing the reviewers in turn for every team.
+// The team's turn is kept as the last assigned reviewer, so the order survives the membership's changes.
+// The turns are kept in the memory of the service's instance, so the rotation is the best-effort one:
+// the instances and the concurrent choices of the same team don't share it.
+type RoundRobinSelector struct {
+	mut     sync.Mutex
+	cursors map[string]UserID
+}
+
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{
+		cursors: make(map[string]UserID, 50),
+	}
+}
+
+// Select chooses the reviewers in turn and advances the team's turn at once.
+func (r *RoundRobinSelector) Select(teamName string, candidates []User, count int) []User {
+	turn := r.Turn()
+	res := turn.Select(teamName, candidates, count)
+	turn.Commit()
+
+	return res
+}
+
+// Turn returns the selector choosing the reviewers in turn from the current teams' turns.
+// Its choices advance the teams' turns only after its Commit, so the choices made inside
+// the rolled back transaction don't move them.
+func (r *RoundRobinSelector) Turn() *RoundRobinTurn {
+	return &RoundRobinTurn{
+		selector: r,
+		cursors:  make(map[string]UserID, 1),
+	}
+}
+
+func (r *RoundRobinSelector) getCursor(teamName string) UserID {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	return r.cursors[teamName]
+}
+
+// RoundRobinTurn defines the round-robin's choices kept aside the selector's teams' turns until they're committed.
+type RoundRobinTurn struct {
+	selector *RoundRobinSelector
+	cursors  map[string]UserID
+}
+
+func (t *RoundRobinTurn) Select(teamName string, candidates []User, count int) []User {
+	if len(candidates) == 0 || count <= 0 {
+		return nil
+	}
+
+	ordered := slices.Clone(candidates)
+	slices.SortFunc(ordered, func(a, b User) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	cursor, ok := t.cursors[teamName]
+	if !ok {
+		cursor = t.selector.getCursor(teamName)
+	}
+
+	start, _ := slices.BinarySearchFunc(ordered, cursor, func(user User, id UserID) int {
+		if user.ID <= id {
+			return -1
+		}
+		return 1
+	})
+
+	res := make([]User, 0, min(count, len(ordered)))
+	for i := 0; i != cap(res); i++ {
+		res = append(res, ordered[(start+i)%len(ordered)])
+	}
+	t.cursors[teamName] = res[len(res)-1].ID
+
+	return res
+}
+
+// Commit advances the selector's teams' turns to the turn's choices.
+func (t *RoundRobinTurn) Commit() {
+	t.selector.mut.Lock()
+	defer t.selector.mut.Unlock()
+
+	for teamName, cursor := range t.cursors {
+		t.selector.cursors[teamName] = cursor
+	}
+}
+
+// LeastLoadedSelector defines the strategy of choosing the reviewers with the fewest open assigned PRs.
+// The ties are broken at random.
+type LeastLoadedSelector struct {
+	Load ReviewerLoad
+}
+
+func (l LeastLoadedSelector) Select(_ string, candidates []User, count int) []User {
+	ordered := slices.Clone(candidates)
+	rand.Shuffle(len(ordered), func(i, j int) {
+		ordered[i], ordered[j] = ordered[j], ordered[i]
+	})
+
+	slices.SortStableFunc(ordered, func(a, b User) int {
+		return cmp.Compare(l.Load[a.ID], l.Load[b.ID])
+	})
+
+	return ordered[:min(max(count, 0), len(ordered))]
+}
+
+// WeightedRandomSelector defines the strategy of choosing the reviewers at random
+// with the weight inversely proportional to their open assigned PRs' count.
+type WeightedRandomSelector struct {
+	Load ReviewerLoad
+}
+
+func (w WeightedRandomSelector) Select(_ string, candidates []User, count int) []User {
+	left := slices.Clone(candidates)
+	res := make([]User, 0, min(max(count, 0), len(left)))
+
+	for len(res) != cap(res) {
+		weights := make([]float64, len(left))
+		total := 0.0
+
+		for i, user := range left {
+			weights[i] = 1 / float64(1+w.Load[user.ID])
+			total += weights[i]
+		}
+
+		point := rand.Float64() * total
+		pos := len(left) - 1
+
+		for i, weight := range weights {
+			if point < weight {
+				pos = i
+				break
+			}
+			point -= weight
+		}
+
+		res = append(res, left[pos])
+		left = slices.Delete(left, pos, pos+1)
+	}
+
+	return res
+}
@@ -0,0 +1,95 @@
+package entities
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func getTestSelectedIDs(users []User) []UserID {
+	res := make([]UserID, 0, len(users))
+	for _, user := range users {
+		res = append(res, user.ID)
+	}
+	slices.Sort(res)
+
+	return res
+}
+
+func TestRoundRobinSelectorRotates(t *testing.T) {
+	selector := NewRoundRobinSelector()
+	candidates := makeTestTeam("u3", "u1", "u4", "u2").Members
+
+	for i, expected := range [][]UserID{{"u1"}, {"u2", "u3"}, {"u1", "u4"}, {"u2"}} {
+		if res := getTestSelectedIDs(selector.Select("backend", candidates, len(expected))); !slices.Equal(res, expected) {
+			t.Fatalf("choice %d: expected %v, got %v", i, expected, res)
+		}
+	}
+
+	if res := getTestSelectedIDs(selector.Select("frontend", candidates, 1)); !slices.Equal(res, []UserID{"u1"}) {
+		t.Fatalf("expected the other team's own turn, got %v", res)
+	}
+}
+
+func TestRoundRobinTurnCommit(t *testing.T) {
+	selector := NewRoundRobinSelector()
+	candidates := makeTestTeam("u1", "u2", "u3").Members
+
+	rolledBack := selector.Turn()
+	if res := getTestSelectedIDs(rolledBack.Select("backend", candidates, 1)); !slices.Equal(res, []UserID{"u1"}) {
+		t.Fatalf("expected the first candidate, got %v", res)
+	}
+
+	if res := getTestSelectedIDs(rolledBack.Select("backend", candidates, 1)); !slices.Equal(res, []UserID{"u2"}) {
+		t.Fatalf("expected the turn's choices to rotate, got %v", res)
+	}
+
+	committed := selector.Turn()
+	if res := getTestSelectedIDs(committed.Select("backend", candidates, 1)); !slices.Equal(res, []UserID{"u1"}) {
+		t.Fatalf("expected the uncommitted turn not to advance the rotation, got %v", res)
+	}
+	committed.Commit()
+
+	if res := getTestSelectedIDs(selector.Select("backend", candidates, 1)); !slices.Equal(res, []UserID{"u2"}) {
+		t.Fatalf("expected the committed turn to advance the rotation, got %v", res)
+	}
+}
+
+func TestLeastLoadedSelector(t *testing.T) {
+	selector := LeastLoadedSelector{Load: ReviewerLoad{"u1": 3, "u2": 0, "u3": 1}}
+	candidates := makeTestTeam("u1", "u2", "u3", "u4").Members
+
+	for i := 0; i != 50; i++ {
+		if res := getTestSelectedIDs(selector.Select("backend", candidates, 1)); !slices.Equal(res, []UserID{"u2"}) &&
+			!slices.Equal(res, []UserID{"u4"}) {
+			t.Fatalf("expected the candidate without the load, got %v", res)
+		}
+
+		if res := getTestSelectedIDs(selector.Select("backend", candidates, 3)); !slices.Equal(res, []UserID{"u2", "u3", "u4"}) {
+			t.Fatalf("expected the least loaded candidates, got %v", res)
+		}
+	}
+}
+
+func TestWeightedRandomSelector(t *testing.T) {
+	selector := WeightedRandomSelector{Load: ReviewerLoad{"u1": 0, "u2": 3}}
+	candidates := makeTestTeam("u1", "u2").Members
+
+	const choices = 20000
+
+	chosen := 0
+	for i := 0; i != choices; i++ {
+		if selector.Select("backend", candidates, 1)[0].ID == "u1" {
+			chosen++
+		}
+	}
+
+	// The weights are 1/(1+0) and 1/(1+3), so the candidate without the load is chosen with the 0.8 probability.
+	if share := float64(chosen) / choices; math.Abs(share-0.8) > 0.03 {
+		t.Fatalf("expected the candidate without the load chosen in 80%% of the choices, got %.3f", share)
+	}
+
+	if res := getTestSelectedIDs(selector.Select("backend", candidates, 2)); !slices.Equal(res, []UserID{"u1", "u2"}) {
+		t.Fatalf("expected every candidate chosen once, got %v", res)
+	}
+}
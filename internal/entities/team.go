@@ -5,9 +5,12 @@ type TeamID int64
 
 // Team defines the groups of the 'User's.
 type Team struct {
-	ID      TeamID `json:"-"`
-	Name    string `json:"team_name"`
-	Members []User `json:"members"`
+	ID               TeamID           `json:"-"`
+	Name             string           `json:"team_name"`
+	Members          []User           `json:"members"`
+	ReviewerStrategy ReviewerStrategy `json:"reviewer_strategy,omitempty"`
+	ReviewerPolicy   ReviewerPolicy   `json:"reviewer_policy"`
+	MergePolicy      MergePolicy      `json:"merge_policy"`
 }
 
 func NewTeam() Team {
@@ -15,3 +18,63 @@ func NewTeam() Team {
 		Members: make([]User, 250),
 	}
 }
+
+// GetReviewerPolicy returns the team's reviewer policy or the default one if it isn't set.
+func (t Team) GetReviewerPolicy() ReviewerPolicy {
+	if t.ReviewerPolicy.IsZero() {
+		return DefaultReviewerPolicy()
+	}
+	return t.ReviewerPolicy
+}
+
+// GetMergePolicy returns the team's merge policy or the default one if it isn't set.
+func (t Team) GetMergePolicy() MergePolicy {
+	if t.MergePolicy.IsZero() {
+		return DefaultMergePolicy()
+	}
+	return t.MergePolicy
+}
+
+// IsLead checks whether the user is the team's lead.
+func (t Team) IsLead(id UserID) bool {
+	for _, member := range t.Members {
+		if member.ID == id {
+			return member.GetRole() == TeamLeadRole
+		}
+	}
+	return false
+}
+
+// ReviewerPolicy defines the team's bounds of the PR's reviewers count.
+type ReviewerPolicy struct {
+	MinReviewers int `json:"min_reviewers"`
+	MaxReviewers int `json:"max_reviewers"`
+}
+
+func DefaultReviewerPolicy() ReviewerPolicy {
+	return ReviewerPolicy{
+		MinReviewers: DefaultMinReviewers,
+		MaxReviewers: DefaultMaxReviewers,
+	}
+}
+
+// IsZero checks whether the policy wasn't set.
+func (r ReviewerPolicy) IsZero() bool {
+	return r == ReviewerPolicy{}
+}
+
+func (r ReviewerPolicy) IsValid() bool {
+	return r.MinReviewers >= 0 && r.MaxReviewers >= 1 && r.MinReviewers <= r.MaxReviewers
+}
+
+// GetReviewersCount returns the requested reviewers count or the policy's maximum if it wasn't requested.
+func (r ReviewerPolicy) GetReviewersCount(requested *int) (int, error) {
+	if requested == nil {
+		return r.MaxReviewers, nil
+	}
+
+	if *requested < r.MinReviewers || *requested > r.MaxReviewers {
+		return 0, ErrReviewersCount
+	}
+	return *requested, nil
+}
@@ -0,0 +1,107 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"slices"
+	"time"
+)
+
+const (
+	ScopeTeamsRead  Scope = "teams:read"
+	ScopeTeamsWrite Scope = "teams:write"
+	ScopePrsRead    Scope = "prs:read"
+	ScopePrsWrite   Scope = "prs:write"
+
+	// ScopeMetricsRead grants the scraping of the service's metrics.
+	ScopeMetricsRead Scope = "metrics:read"
+
+	// ScopeAdmin grants every other scope.
+	ScopeAdmin Scope = "admin"
+)
+
+// Scope defines the set of the API's operations the token grants.
+type Scope string
+
+func (s Scope) IsValid() bool {
+	switch s {
+	case ScopeTeamsRead, ScopeTeamsWrite, ScopePrsRead, ScopePrsWrite, ScopeMetricsRead, ScopeAdmin:
+		return true
+	}
+	return false
+}
+
+const (
+	// TokenPrefix defines the prefix of the tokens' secrets distinguishing them from the other credentials.
+	TokenPrefix = "prs_"
+
+	tokenSecretLen = 32
+)
+
+// TokenID defines the unique API token's identifier.
+type TokenID int64
+
+// APIToken defines the bearer token authenticating the API's clients.
+// Only the hash of the token's secret is stored, so the secret is shown only on the token's creation.
+// The token of the user makes the user the actor of the requests.
+type APIToken struct {
+	ID        TokenID    `json:"token_id"`
+	Name      string     `json:"name"`
+	Scopes    []Scope    `json:"scopes"`
+	UserID    UserID     `json:"user_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+// IsValid checks the token is named and has only the known scopes.
+func (t APIToken) IsValid() bool {
+	if len(t.Name) == 0 || len(t.Scopes) == 0 {
+		return false
+	}
+
+	for _, scope := range t.Scopes {
+		if !scope.IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
+// HasScope checks whether the token grants the scope.
+func (t APIToken) HasScope(scope Scope) bool {
+	return slices.Contains(t.Scopes, scope) || slices.Contains(t.Scopes, ScopeAdmin)
+}
+
+func (t APIToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// NewTokenSecret returns the random secret of the new token.
+func NewTokenSecret() (string, error) {
+	buff := make([]byte, tokenSecretLen)
+
+	if _, err := rand.Read(buff); err != nil {
+		return "", err
+	}
+	return TokenPrefix + base64.RawURLEncoding.EncodeToString(buff), nil
+}
+
+// HashTokenSecret returns the hash the token is stored and searched by.
+// The secrets are random enough, so the hash doesn't need the salt.
+func HashTokenSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// BootstrapTokenName defines the name of the admin token set in the service's configuration.
+// It lets the first tokens be issued.
+const BootstrapTokenName = "bootstrap"
+
+func NewBootstrapToken() APIToken {
+	return APIToken{
+		Name:   BootstrapTokenName,
+		Scopes: []Scope{ScopeAdmin},
+	}
+}
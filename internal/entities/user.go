@@ -3,10 +3,31 @@ package entities
 // UserID defines the unique user's identifier.
 type UserID string
 
+// UserRole defines the user's role in his team.
+type UserRole string
+
+const (
+	MemberRole   UserRole = "member"
+	TeamLeadRole UserRole = "team_lead"
+)
+
+func (r UserRole) IsValid() bool {
+	return r == MemberRole || r == TeamLeadRole
+}
+
 // User defines the member of the Team.
 type User struct {
-	ID       UserID `json:"user_id"`
-	Name     string `json:"username"`
-	IsActive bool   `json:"is_active"`
-	TeamName string `json:"team_name"`
+	ID       UserID   `json:"user_id"`
+	Name     string   `json:"username"`
+	IsActive bool     `json:"is_active"`
+	TeamName string   `json:"team_name"`
+	Role     UserRole `json:"role,omitempty"`
+}
+
+// GetRole returns the user's role or the member one if it isn't set.
+func (u User) GetRole() UserRole {
+	if len(u.Role) == 0 {
+		return MemberRole
+	}
+	return u.Role
 }
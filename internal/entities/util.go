@@ -1,6 +1,9 @@
 package entities
 
-import "math/rand"
+import (
+	"math/rand"
+	"slices"
+)
 
 func makeReviewerRandGen(col []User, except []UserID) func() (int, error) {
 	buff := make(map[int]struct{})
@@ -26,3 +29,16 @@ func makeReviewerRandGen(col []User, except []UserID) func() (int, error) {
 		return -1, ErrReviewerAssign
 	}
 }
+
+// getReviewerCandidates returns the active members that aren't in the except list.
+func getReviewerCandidates(col []User, except []UserID) []User {
+	res := make([]User, 0, len(col))
+
+	for _, user := range col {
+		if user.IsActive && !slices.Contains(except, user.ID) {
+			res = append(res, user)
+		}
+	}
+
+	return res
+}
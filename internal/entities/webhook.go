@@ -0,0 +1,114 @@
+package entities
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+const (
+	ReviewerAssignedEvent   WebhookEventType = "reviewer.assigned"
+	ReviewerReassignedEvent WebhookEventType = "reviewer.reassigned"
+	PullRequestMergedEvent  WebhookEventType = "pull_request.merged"
+)
+
+// WebhookEventType defines the type of the event sent to the teams' webhooks.
+type WebhookEventType string
+
+const (
+	DeliveryPending   DeliveryStatus = "PENDING"
+	DeliveryDelivered DeliveryStatus = "DELIVERED"
+	DeliveryDead      DeliveryStatus = "DEAD"
+)
+
+// DeliveryStatus defines the state of the event's delivery to the webhook.
+type DeliveryStatus string
+
+func (d DeliveryStatus) IsValid() bool {
+	return d == DeliveryPending || d == DeliveryDelivered || d == DeliveryDead
+}
+
+const (
+	MaxDeliveryAttempts = 8
+
+	deliveryBackoffBase = 10 * time.Second
+	deliveryBackoffMax  = time.Hour
+)
+
+// WebhookID defines the unique webhook's identifier.
+type WebhookID int64
+
+// Webhook defines the team's URL receiving the signed events.
+type Webhook struct {
+	ID       WebhookID `json:"webhook_id"`
+	TeamName string    `json:"team_name"`
+	URL      string    `json:"url"`
+	Secret   string    `json:"secret,omitempty"`
+}
+
+// IsValid checks the webhook's URL is the absolute HTTP(S) one and the secret is set.
+func (w Webhook) IsValid() bool {
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && len(u.Host) != 0 && len(w.Secret) != 0
+}
+
+// DeliveryID defines the unique delivery's identifier.
+type DeliveryID int64
+
+// WebhookDelivery defines the event's delivery to the team's webhook.
+// The failed delivery is retried with the exponential backoff until it becomes dead.
+type WebhookDelivery struct {
+	ID             DeliveryID       `json:"delivery_id"`
+	WebhookID      WebhookID        `json:"webhook_id"`
+	EventID        EventID          `json:"event_id"`
+	TeamName       string           `json:"team_name"`
+	URL            string           `json:"url"`
+	Secret         string           `json:"-"`
+	Event          WebhookEventType `json:"event"`
+	Payload        json.RawMessage  `json:"payload"`
+	Status         DeliveryStatus   `json:"status"`
+	Attempts       int              `json:"attempts"`
+	NextAttemptAt  time.Time        `json:"next_attempt_at"`
+	LastStatusCode int              `json:"last_status_code,omitempty"`
+	LastError      string           `json:"last_error,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	DeliveredAt    *time.Time       `json:"delivered_at"`
+}
+
+// SetDelivered marks the delivery as the successful one.
+func (w *WebhookDelivery) SetDelivered(now time.Time, statusCode int) {
+	w.Attempts++
+	w.Status = DeliveryDelivered
+	w.LastStatusCode = statusCode
+	w.LastError = ""
+	w.DeliveredAt = &now
+}
+
+// SetFailed marks the delivery's attempt as the failed one and schedules the next attempt.
+// The delivery becomes dead after the MaxDeliveryAttempts attempts.
+func (w *WebhookDelivery) SetFailed(now time.Time, statusCode int, reason string) {
+	w.Attempts++
+	w.LastStatusCode = statusCode
+	w.LastError = reason
+
+	if w.Attempts >= MaxDeliveryAttempts {
+		w.Status = DeliveryDead
+		return
+	}
+	w.NextAttemptAt = now.Add(GetDeliveryBackoff(w.Attempts))
+}
+
+// GetDeliveryBackoff returns the delay before the next delivery's attempt after the failed ones.
+// The delay is doubled after every failed attempt up to the maximum one.
+func GetDeliveryBackoff(attempts int) time.Duration {
+	backoff := deliveryBackoffBase
+
+	for i := 1; i < attempts && backoff < deliveryBackoffMax; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, deliveryBackoffMax)
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetDeliveryBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		backoff  time.Duration
+	}{
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{3, 40 * time.Second},
+		{9, 2560 * time.Second},
+		{10, time.Hour},
+		{100, time.Hour},
+	}
+
+	for _, test := range tests {
+		if backoff := GetDeliveryBackoff(test.attempts); backoff != test.backoff {
+			t.Fatalf("%d attempts: expected %v, got %v", test.attempts, test.backoff, backoff)
+		}
+	}
+}
+
+func TestDeliverySetFailed(t *testing.T) {
+	now := time.Now()
+	delivery := WebhookDelivery{Status: DeliveryPending, NextAttemptAt: now}
+
+	delivery.SetFailed(now, 500, "unexpected status code 500")
+
+	if delivery.Status != DeliveryPending || delivery.Attempts != 1 {
+		t.Fatalf("expected the pending delivery after 1 attempt, got %s after %d", delivery.Status, delivery.Attempts)
+	}
+
+	if !delivery.NextAttemptAt.Equal(now.Add(GetDeliveryBackoff(1))) {
+		t.Fatalf("expected the next attempt at %v, got %v", now.Add(GetDeliveryBackoff(1)), delivery.NextAttemptAt)
+	}
+
+	for delivery.Status == DeliveryPending {
+		delivery.SetFailed(now, 0, "connection refused")
+	}
+
+	if delivery.Status != DeliveryDead || delivery.Attempts != MaxDeliveryAttempts {
+		t.Fatalf("expected the dead delivery after %d attempts, got %s after %d",
+			MaxDeliveryAttempts, delivery.Status, delivery.Attempts)
+	}
+}
@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/services"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// workloadCollectTimeout defines the max duration of calculating the review's workload on the scrape.
+const workloadCollectTimeout = 5 * time.Second
+
+// WorkloadCollector defines the collector of the review's workload calculated by the statistics' use-case
+// on every scrape, so the values are the same for every service's instance.
+type WorkloadCollector struct {
+	log   *slog.Logger
+	stats services.StatsInteractor
+
+	teamOpen           *prometheus.Desc
+	teamReassignments  *prometheus.Desc
+	reviewerOpen       *prometheus.Desc
+	reviewerAssigned   *prometheus.Desc
+	reviewerReassigned *prometheus.Desc
+}
+
+func NewWorkloadCollector(log *slog.Logger, stats services.StatsInteractor) *WorkloadCollector {
+	return &WorkloadCollector{
+		log:   log,
+		stats: stats,
+
+		teamOpen: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "team", "open_pull_requests"),
+			"The count of the open PRs authored by the team's members.",
+			[]string{"team"}, nil),
+
+		teamReassignments: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "team", "reassignments_total"),
+			"The count of the reviewers' reassignments of the PRs authored by the team's members.",
+			[]string{"team"}, nil),
+
+		reviewerOpen: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "reviewer", "open_assignments"),
+			"The count of the open PRs the user is assigned to as the reviewer.",
+			[]string{"team", "reviewer"}, nil),
+
+		reviewerAssigned: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "reviewer", "assignments_total"),
+			"The count of the PRs the user is assigned to as the reviewer.",
+			[]string{"team", "reviewer"}, nil),
+
+		reviewerReassigned: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "reviewer", "reassignments_total"),
+			"The count of the reassignments replacing the user as the reviewer.",
+			[]string{"team", "reviewer"}, nil),
+	}
+}
+
+func (w *WorkloadCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- w.teamOpen
+	ch <- w.teamReassignments
+	ch <- w.reviewerOpen
+	ch <- w.reviewerAssigned
+	ch <- w.reviewerReassigned
+}
+
+// Collect defines the logic of calculating the workload's metrics.
+// The metrics are skipped if the statistics aren't available.
+func (w *WorkloadCollector) Collect(ch chan<- prometheus.Metric) {
+	const op = "metrics.collect-workload"
+
+	ctx, cancel := context.WithTimeout(context.Background(), workloadCollectTimeout)
+	defer cancel()
+
+	stats, err := w.stats.GetStats(ctx, dto.StatsFilterDTO{})
+	if err != nil {
+		w.log.Warn(fmt.Sprintf("error of the %s: %s", op, err))
+		return
+	}
+
+	for _, team := range stats.Teams {
+		ch <- prometheus.MustNewConstMetric(w.teamOpen, prometheus.GaugeValue, float64(team.Open), team.TeamName)
+		ch <- prometheus.MustNewConstMetric(w.teamReassignments, prometheus.CounterValue,
+			float64(team.Reassignments), team.TeamName)
+	}
+
+	for _, user := range stats.Users {
+		ch <- prometheus.MustNewConstMetric(w.reviewerOpen, prometheus.GaugeValue,
+			float64(user.Open), user.TeamName, string(user.ID))
+		ch <- prometheus.MustNewConstMetric(w.reviewerAssigned, prometheus.CounterValue,
+			float64(user.Assigned), user.TeamName, string(user.ID))
+		ch <- prometheus.MustNewConstMetric(w.reviewerReassigned, prometheus.CounterValue,
+			float64(user.Reassignments), user.TeamName, string(user.ID))
+	}
+}
+
+// PoolCollector defines the collector of the PostgreSQL connections' pool statistics.
+type PoolCollector struct {
+	stat func() *pgxpool.Stat
+
+	acquiredConns     *prometheus.Desc
+	idleConns         *prometheus.Desc
+	constructingConns *prometheus.Desc
+	totalConns        *prometheus.Desc
+	maxConns          *prometheus.Desc
+	acquires          *prometheus.Desc
+	acquireDuration   *prometheus.Desc
+	canceledAcquires  *prometheus.Desc
+	emptyAcquires     *prometheus.Desc
+}
+
+func NewPoolCollector(stat func() *pgxpool.Stat) *PoolCollector {
+	desc := func(name string, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+
+	return &PoolCollector{
+		stat: stat,
+
+		acquiredConns:     desc("acquired_conns", "The count of the currently acquired connections."),
+		idleConns:         desc("idle_conns", "The count of the currently idle connections."),
+		constructingConns: desc("constructing_conns", "The count of the connections being established."),
+		totalConns:        desc("total_conns", "The total count of the pool's connections."),
+		maxConns:          desc("max_conns", "The max size of the pool."),
+		acquires:          desc("acquires_total", "The count of the successful connections' acquires."),
+		acquireDuration:   desc("acquire_duration_seconds_total", "The total duration of the successful connections' acquires."),
+		canceledAcquires:  desc("canceled_acquires_total", "The count of the acquires canceled by the ctx."),
+		emptyAcquires:     desc("empty_acquires_total", "The count of the acquires waited for the connection since the pool was empty."),
+	}
+}
+
+func (p *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(p, ch)
+}
+
+func (p *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := p.stat()
+
+	for _, metric := range []struct {
+		desc  *prometheus.Desc
+		kind  prometheus.ValueType
+		value float64
+	}{
+		{desc: p.acquiredConns, kind: prometheus.GaugeValue, value: float64(stat.AcquiredConns())},
+		{desc: p.idleConns, kind: prometheus.GaugeValue, value: float64(stat.IdleConns())},
+		{desc: p.constructingConns, kind: prometheus.GaugeValue, value: float64(stat.ConstructingConns())},
+		{desc: p.totalConns, kind: prometheus.GaugeValue, value: float64(stat.TotalConns())},
+		{desc: p.maxConns, kind: prometheus.GaugeValue, value: float64(stat.MaxConns())},
+		{desc: p.acquires, kind: prometheus.CounterValue, value: float64(stat.AcquireCount())},
+		{desc: p.acquireDuration, kind: prometheus.CounterValue, value: stat.AcquireDuration().Seconds()},
+		{desc: p.canceledAcquires, kind: prometheus.CounterValue, value: float64(stat.CanceledAcquireCount())},
+		{desc: p.emptyAcquires, kind: prometheus.CounterValue, value: float64(stat.EmptyAcquireCount())},
+	} {
+		ch <- prometheus.MustNewConstMetric(metric.desc, metric.kind, metric.value)
+	}
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace defines the common prefix of the service's metrics.
+const namespace = "pr_service"
+
+// Metrics defines the registry of the service's metrics exposed in the Prometheus text format.
+// It's safe for the concurrent use.
+type Metrics struct {
+	log      *slog.Logger
+	registry *prometheus.Registry
+
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	noCandidate     *prometheus.CounterVec
+}
+
+func New(log *slog.Logger) *Metrics {
+	m := &Metrics{
+		log:      log,
+		registry: prometheus.NewRegistry(),
+
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "The count of the handled HTTP requests by the route and the response's status code.",
+		}, []string{"method", "route", "code"}),
+
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "The latency of the handled HTTP requests by the route.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+
+		noCandidate: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "no_candidate_failures_total",
+			Help:      "The count of the reviewers' assignments failed without the active candidate by the operation.",
+		}, []string{"operation"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.requestDuration,
+		m.noCandidate,
+	)
+
+	return m
+}
+
+// Register defines the logic of adding the collectors exposed together with the service's metrics.
+func (m *Metrics) Register(cs ...prometheus.Collector) {
+	m.registry.MustRegister(cs...)
+}
+
+// Handler returns the handler exposing the registered metrics.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		ErrorLog: slog.NewLogLogger(m.log.Handler(), slog.LevelWarn),
+	})
+}
+
+// ObserveRequest defines the logic of counting the handled request and its latency.
+// The route is the endpoint's path template, so the metrics' labels are bounded.
+func (m *Metrics) ObserveRequest(method string, route string, code int, duration time.Duration) {
+	m.requests.WithLabelValues(method, route, strconv.Itoa(code)).Inc()
+	m.requestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// RecordNoCandidate defines the logic of counting the reviewers' assignment failed without the candidate.
+func (m *Metrics) RecordNoCandidate(operation string) {
+	m.noCandidate.WithLabelValues(operation).Inc()
+}
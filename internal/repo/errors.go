@@ -11,4 +11,5 @@ var (
 	ErrDependModelsNotFound       = errors.New("repo: error of finding the dependendent model")
 	ErrModelAlreadyExists         = errors.New("repo: model already exists")
 	ErrStartTransaction           = errors.New("repo: error of starting the transaction")
+	ErrCommitTransaction          = errors.New("repo: error of committing the transaction")
 )
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+)
+
+// ReserveIdempotencyKey defines the logic of reserving the key with the pending record.
+// The key is reserved if it's new, if its pending record was created before the staleBefore
+// or if its record was created before the expiredBefore. The stored record is returned otherwise.
+func (m *MemoryRepo) ReserveIdempotencyKey(
+	ctx context.Context,
+	record dto.IdempotencyRecordDTO,
+	staleBefore time.Time,
+	expiredBefore time.Time,
+) (dto.IdempotencyRecordDTO, bool, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	stored, ok := m.responses[record.Key]
+	if ok && !(stored.IsPending() && stored.CreatedAt.Before(staleBefore)) && !stored.CreatedAt.Before(expiredBefore) {
+		stored.Body = slices.Clone(stored.Body)
+		return stored, false, nil
+	}
+
+	record.StatusCode = 0
+	record.Body = nil
+	m.responses[record.Key] = record
+
+	return record, true, nil
+}
+
+// SaveIdempotentResponse defines the logic of keeping the response for the reserved key.
+func (m *MemoryRepo) SaveIdempotentResponse(ctx context.Context, record dto.IdempotencyRecordDTO) error {
+	const op = "memory.save-idempotent-response"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if stored, ok := m.responses[record.Key]; !ok || stored.Fingerprint != record.Fingerprint {
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	record.Body = slices.Clone(record.Body)
+	m.responses[record.Key] = record
+
+	return nil
+}
+
+// DeleteIdempotencyKey defines the logic of releasing the key's pending record.
+func (m *MemoryRepo) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if stored, ok := m.responses[key]; ok && stored.IsPending() {
+		delete(m.responses, key)
+	}
+
+	return nil
+}
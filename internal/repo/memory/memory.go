@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+)
+
+// MemoryRepo defines the logic of interaction with the in-memory storage.
+// It's safe for the concurrent use.
+type MemoryRepo struct {
+	log   *slog.Logger
+	mut   sync.RWMutex
+	txMut sync.Mutex
+
+	teams map[string]teamModel
+	users map[entities.UserID]userModel
+	prs   map[entities.PullRequestID]dto.PullRequestDTO
+
+	history []dto.ReviewerAssignmentDTO
+	reviews []dto.PullRequestReviewDTO
+
+	webhooks   map[entities.WebhookID]entities.Webhook
+	deliveries map[entities.DeliveryID]entities.WebhookDelivery
+	outbox     map[entities.EventID]outboxModel
+
+	// responses and tokens aren't the part of the transactions' snapshots: they're kept outside of the requests' ops.
+	responses map[string]dto.IdempotencyRecordDTO
+	tokens    map[entities.TokenID]tokenModel
+
+	teamSeq     entities.TeamID
+	webhookSeq  entities.WebhookID
+	deliverySeq entities.DeliveryID
+	eventSeq    entities.EventID
+	tokenSeq    entities.TokenID
+}
+
+func New(log *slog.Logger) *MemoryRepo {
+	return &MemoryRepo{
+		log:   log,
+		teams: make(map[string]teamModel, 50),
+		users: make(map[entities.UserID]userModel, 250),
+		prs:   make(map[entities.PullRequestID]dto.PullRequestDTO, 250),
+
+		webhooks:   make(map[entities.WebhookID]entities.Webhook, 50),
+		deliveries: make(map[entities.DeliveryID]entities.WebhookDelivery, 250),
+		outbox:     make(map[entities.EventID]outboxModel, 250),
+
+		responses: make(map[string]dto.IdempotencyRecordDTO, 250),
+		tokens:    make(map[entities.TokenID]tokenModel, 10),
+	}
+}
+
+func (m *MemoryRepo) Close() {}
+
+// Ping defines the logic of checking the storage's availability.
+// The in-memory storage is always available.
+func (m *MemoryRepo) Ping(ctx context.Context) error {
+	return nil
+}
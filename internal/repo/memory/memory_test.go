@@ -0,0 +1,253 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+func makeTestTeam(name string, ids ...entities.UserID) entities.Team {
+	team := entities.Team{
+		Name:    name,
+		Members: make([]entities.User, 0, len(ids)),
+	}
+
+	for _, id := range ids {
+		team.Members = append(team.Members, entities.User{ID: id, Name: string(id), IsActive: true})
+	}
+
+	return team
+}
+
+func makeTestPullRequest(id entities.PullRequestID, author entities.UserID) dto.PullRequestDTO {
+	pullReq := dto.NewPullRequestDTO()
+	pullReq.ID = id
+	pullReq.Name = string(id)
+	pullReq.Status = entities.Open
+	pullReq.AuthorID = author
+
+	return pullReq
+}
+
+// makeTestRepo returns the repository with the team 'backend' of the users 'alice' and 'bob'
+// and the PR 'pr-1' of the 'alice'.
+func makeTestRepo(t *testing.T) *MemoryRepo {
+	t.Helper()
+
+	repository := New(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+
+	if err := repository.CreateTeam(ctx, makeTestTeam("backend", "alice", "bob")); err != nil {
+		t.Fatalf("couldn't create the test team: %v", err)
+	}
+
+	if err := repository.CreatePullRequest(ctx, makeTestPullRequest("pr-1", "alice")); err != nil {
+		t.Fatalf("couldn't create the test PR: %v", err)
+	}
+
+	return repository
+}
+
+func TestMemoryRepoErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func(ctx context.Context, repository *MemoryRepo) error
+		expected error
+	}{
+		{
+			name: "unknown team",
+			call: func(ctx context.Context, repository *MemoryRepo) error {
+				_, err := repository.GetTeam(ctx, "frontend")
+				return err
+			},
+			expected: repo.ErrModelNotFound,
+		},
+		{
+			name: "unknown team's policy",
+			call: func(ctx context.Context, repository *MemoryRepo) error {
+				_, err := repository.SetTeamMergePolicy(ctx, "frontend", entities.MergePolicy{RequiredApprovals: 1})
+				return err
+			},
+			expected: repo.ErrModelNotFound,
+		},
+		{
+			name: "unknown user",
+			call: func(ctx context.Context, repository *MemoryRepo) error {
+				_, err := repository.SetUserIsActive(ctx, false, "carol")
+				return err
+			},
+			expected: repo.ErrModelNotFound,
+		},
+		{
+			name: "unknown user's reviews",
+			call: func(ctx context.Context, repository *MemoryRepo) error {
+				_, err := repository.GetUserPullRequests(ctx, "carol")
+				return err
+			},
+			expected: repo.ErrModelNotFound,
+		},
+		{
+			name: "unknown PR",
+			call: func(ctx context.Context, repository *MemoryRepo) error {
+				_, err := repository.GetPullRequest(ctx, "pr-2")
+				return err
+			},
+			expected: repo.ErrModelNotFound,
+		},
+		{
+			name: "PR of the unknown author",
+			call: func(ctx context.Context, repository *MemoryRepo) error {
+				return repository.CreatePullRequest(ctx, makeTestPullRequest("pr-2", "carol"))
+			},
+			expected: repo.ErrModelNotFound,
+		},
+		{
+			name: "existing PR",
+			call: func(ctx context.Context, repository *MemoryRepo) error {
+				return repository.CreatePullRequest(ctx, makeTestPullRequest("pr-1", "bob"))
+			},
+			expected: repo.ErrModelAlreadyExists,
+		},
+		{
+			name: "existing team",
+			call: func(ctx context.Context, repository *MemoryRepo) error {
+				return repository.CreateTeam(ctx, makeTestTeam("backend", "alice", "bob"))
+			},
+			expected: repo.ErrModelAlreadyExists,
+		},
+		{
+			name: "duplicated member",
+			call: func(ctx context.Context, repository *MemoryRepo) error {
+				return repository.CreateTeam(ctx, makeTestTeam("frontend", "carol", "carol"))
+			},
+			expected: repo.ErrCreateMultipleUniqueModels,
+		},
+		{
+			name: "member of the other team",
+			call: func(ctx context.Context, repository *MemoryRepo) error {
+				return repository.CreateTeam(ctx, makeTestTeam("frontend", "carol", "bob"))
+			},
+			expected: repo.ErrCreateMultipleUniqueModels,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repository := makeTestRepo(t)
+
+			if err := test.call(context.Background(), repository); !errors.Is(err, test.expected) {
+				t.Fatalf("expected the %v, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestMemoryRepoFailedCreateTeamKeepsState(t *testing.T) {
+	repository := makeTestRepo(t)
+	ctx := context.Background()
+
+	if err := repository.CreateTeam(ctx, makeTestTeam("frontend", "carol", "bob")); err == nil {
+		t.Fatalf("expected the team with the other team's member rejected")
+	}
+
+	if _, err := repository.GetTeam(ctx, "frontend"); !errors.Is(err, repo.ErrModelNotFound) {
+		t.Fatalf("expected the rejected team not created, got %v", err)
+	}
+
+	if _, err := repository.GetUser(ctx, "carol"); !errors.Is(err, repo.ErrModelNotFound) {
+		t.Fatalf("expected the rejected team's members not created, got %v", err)
+	}
+}
+
+func TestMemoryRepoCreateTeamKeepsRoles(t *testing.T) {
+	repository := makeTestRepo(t)
+	ctx := context.Background()
+
+	team := makeTestTeam("backend", "alice", "bob")
+	team.Members[0].Role = entities.TeamLeadRole
+	if err := repository.CreateTeam(ctx, team); !errors.Is(err, repo.ErrModelAlreadyExists) {
+		t.Fatalf("expected the existing team updated, got %v", err)
+	}
+
+	if err := repository.CreateTeam(ctx, makeTestTeam("backend", "alice", "bob")); !errors.Is(err, repo.ErrModelAlreadyExists) {
+		t.Fatalf("expected the existing team updated, got %v", err)
+	}
+
+	res, err := repository.GetTeam(ctx, "backend")
+	if err != nil {
+		t.Fatalf("couldn't get the team: %v", err)
+	}
+
+	if !res.IsLead("alice") || res.IsLead("bob") {
+		t.Fatalf("expected the members' omitted roles kept, got %+v", res.Members)
+	}
+}
+
+func TestMemoryRepoConcurrentUse(t *testing.T) {
+	repository := makeTestRepo(t)
+	ctx := context.Background()
+
+	const workers = 16
+
+	var wg sync.WaitGroup
+	for i := 0; i != workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			user := entities.UserID(fmt.Sprintf("user-%d", i))
+			if err := repository.CreateTeam(ctx, makeTestTeam(fmt.Sprintf("team-%d", i), user)); err != nil {
+				t.Errorf("couldn't create the team: %v", err)
+				return
+			}
+
+			err := repository.WithinTx(ctx, services.Serializable, func(ctx context.Context) error {
+				if err := repository.CreatePullRequest(ctx, makeTestPullRequest(entities.PullRequestID(user), user)); err != nil {
+					return err
+				}
+
+				pullReq := makeTestPullRequest(entities.PullRequestID(user), user)
+				pullReq.Reviewers = append(pullReq.Reviewers, "bob")
+				return repository.AddPullRequestReviewers(ctx, pullReq)
+			})
+			if err != nil {
+				t.Errorf("couldn't create the PR: %v", err)
+				return
+			}
+
+			if _, err := repository.GetTeam(ctx, "backend"); err != nil {
+				t.Errorf("couldn't get the team: %v", err)
+			}
+
+			if _, err := repository.GetUserPullRequests(ctx, "bob"); err != nil {
+				t.Errorf("couldn't get the reviews: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	reviews, err := repository.GetUserPullRequests(ctx, "bob")
+	if err != nil {
+		t.Fatalf("couldn't get the reviews: %v", err)
+	}
+
+	if len(reviews) != workers {
+		t.Fatalf("expected %d reviews, got %d", workers, len(reviews))
+	}
+
+	for i := 0; i != workers; i++ {
+		if _, err := repository.GetTeam(ctx, fmt.Sprintf("team-%d", i)); err != nil {
+			t.Fatalf("couldn't get the concurrently created team: %v", err)
+		}
+	}
+}
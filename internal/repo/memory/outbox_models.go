@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/repo"
+)
+
+// outboxModel defines the domain event kept in the outbox with its publishing state.
+type outboxModel struct {
+	event         entities.DomainEvent
+	attempts      int
+	nextAttemptAt time.Time
+	publishedAt   *time.Time
+	lastError     string
+}
+
+// AddOutboxEvents defines the logic of writing the domain events to the outbox.
+func (m *MemoryRepo) AddOutboxEvents(ctx context.Context, events []entities.DomainEvent) error {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	for _, event := range events {
+		m.eventSeq++
+		event.ID = m.eventSeq
+		event.Payload = slices.Clone(event.Payload)
+
+		m.outbox[event.ID] = outboxModel{
+			event:         event,
+			nextAttemptAt: event.OccurredAt,
+		}
+	}
+
+	return nil
+}
+
+// ClaimOutboxEvents defines the logic of taking the unpublished events due by the now in their order.
+// The claimed events aren't given to the other dispatchers until the lockUntil.
+func (m *MemoryRepo) ClaimOutboxEvents(
+	ctx context.Context,
+	now time.Time,
+	lockUntil time.Time,
+	limit int,
+) ([]entities.DomainEvent, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	ids := make([]entities.EventID, 0, limit)
+	for id, model := range m.outbox {
+		if model.publishedAt == nil && !model.nextAttemptAt.After(now) {
+			ids = append(ids, id)
+		}
+	}
+
+	slices.SortFunc(ids, func(a, b entities.EventID) int {
+		return cmp.Compare(a, b)
+	})
+	ids = ids[:min(limit, len(ids))]
+
+	res := make([]entities.DomainEvent, 0, len(ids))
+	for _, id := range ids {
+		model := m.outbox[id]
+		model.attempts++
+		model.nextAttemptAt = lockUntil
+		m.outbox[id] = model
+
+		event := model.event
+		event.Payload = slices.Clone(event.Payload)
+		res = append(res, event)
+	}
+
+	return res, nil
+}
+
+// SetOutboxEventPublished defines the logic of marking the event as the published one.
+func (m *MemoryRepo) SetOutboxEventPublished(ctx context.Context, id entities.EventID, publishedAt time.Time) error {
+	const op = "memory.set-outbox-event-published"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	model, ok := m.outbox[id]
+	if !ok {
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	model.publishedAt = &publishedAt
+	model.lastError = ""
+	m.outbox[id] = model
+
+	return nil
+}
+
+// SetOutboxEventFailed defines the logic of keeping the reason of the event's failed publishing.
+// The event is published again after its claim expires.
+func (m *MemoryRepo) SetOutboxEventFailed(ctx context.Context, id entities.EventID, reason string) error {
+	const op = "memory.set-outbox-event-failed"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	model, ok := m.outbox[id]
+	if !ok {
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	model.lastError = reason
+	m.outbox[id] = model
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+)
+
+// ListPullRequests defines the logic of getting the page of the filtered PRs in the filter's order.
+// The filter's author, reviewer and team must exist.
+func (m *MemoryRepo) ListPullRequests(ctx context.Context, filter dto.PullRequestsFilterDTO) ([]dto.PullRequestDTO, error) {
+	const op = "memory.list-pull-requests"
+
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	for _, id := range []entities.UserID{filter.AuthorID, filter.ReviewerID} {
+		if _, ok := m.users[id]; len(id) != 0 && !ok {
+			return nil, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+		}
+	}
+
+	if _, ok := m.teams[filter.TeamName]; len(filter.TeamName) != 0 && !ok {
+		return nil, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	dir := 1
+	if filter.Order == dto.Desc {
+		dir = -1
+	}
+
+	compare := func(a, b dto.PullRequestDTO) int {
+		return dir * comparePullRequests(filter.SortBy, a, b)
+	}
+
+	var after dto.PullRequestDTO
+	if filter.After != nil {
+		after = dto.PullRequestDTO{ID: filter.After.ID, CreatedAt: filter.After.CreatedAt}
+	}
+
+	res := make([]dto.PullRequestDTO, 0, filter.Limit)
+	for _, pullReq := range m.prs {
+		if !m.isPullRequestListed(filter, pullReq) {
+			continue
+		} else if filter.After != nil && compare(pullReq, after) <= 0 {
+			continue
+		}
+
+		pullReq = copyPullRequest(pullReq)
+		pullReq.ReviewersCount = nil
+		pullReq.Decisions = nil
+
+		res = append(res, pullReq)
+	}
+
+	slices.SortFunc(res, compare)
+
+	return res[:min(filter.Limit, len(res))], nil
+}
+
+// isPullRequestListed checks whether the PR satisfies the listing's filter.
+// It must be called under the read lock.
+func (m *MemoryRepo) isPullRequestListed(filter dto.PullRequestsFilterDTO, pullReq dto.PullRequestDTO) bool {
+	if len(filter.AuthorID) != 0 && pullReq.AuthorID != filter.AuthorID {
+		return false
+	} else if len(filter.ReviewerID) != 0 && !slices.Contains(pullReq.Reviewers, filter.ReviewerID) {
+		return false
+	} else if len(filter.TeamName) != 0 && m.users[pullReq.AuthorID].TeamName != filter.TeamName {
+		return false
+	} else if len(filter.Status) != 0 && pullReq.Status != filter.Status {
+		return false
+	}
+
+	if filter.CreatedAfter != nil && (pullReq.CreatedAt == nil || pullReq.CreatedAt.Before(*filter.CreatedAfter)) {
+		return false
+	} else if filter.CreatedBefore != nil && (pullReq.CreatedAt == nil || !pullReq.CreatedAt.Before(*filter.CreatedBefore)) {
+		return false
+	}
+
+	return true
+}
+
+// comparePullRequests compares the PRs in the ascending order of the sorting's key.
+// The PRs without the created_at are the earliest ones.
+func comparePullRequests(sortBy dto.PullRequestsSortKey, a, b dto.PullRequestDTO) int {
+	if sortBy == dto.SortByID {
+		return cmp.Compare(a.ID, b.ID)
+	}
+
+	createdAt := func(pullReq dto.PullRequestDTO) time.Time {
+		if pullReq.CreatedAt == nil {
+			return time.Time{}
+		}
+		return *pullReq.CreatedAt
+	}
+
+	return cmp.Or(createdAt(a).Compare(createdAt(b)), cmp.Compare(a.ID, b.ID))
+}
@@ -0,0 +1,370 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+)
+
+// CreatePullRequest defines the logic of creating the pull request in the repo.
+func (m *MemoryRepo) CreatePullRequest(ctx context.Context, pullRequest dto.PullRequestDTO) error {
+	const op = "memory.create-pull-request"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if _, ok := m.prs[pullRequest.ID]; ok {
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelAlreadyExists)
+	}
+
+	if _, ok := m.users[pullRequest.AuthorID]; !ok {
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	for _, id := range pullRequest.Reviewers {
+		if _, ok := m.users[id]; !ok {
+			retErr := fmt.Errorf("error of the %s: %w: reviewer '%s'", op, repo.ErrDependModelsNotFound, id)
+			m.log.Warn(retErr.Error())
+			return retErr
+		}
+	}
+	m.prs[pullRequest.ID] = copyPullRequest(pullRequest)
+
+	return nil
+}
+
+// AddPullRequestReviewers defines the logic of adding the PR's reviewers to the already existing PR.
+func (m *MemoryRepo) AddPullRequestReviewers(ctx context.Context, pullReq dto.PullRequestDTO) error {
+	const op = "memory.add-pull-request-reviewers"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	res, ok := m.prs[pullReq.ID]
+	if !ok {
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	for _, id := range pullReq.Reviewers {
+		if _, ok := m.users[id]; !ok {
+			retErr := fmt.Errorf("error of the %s: %w: reviewer '%s'", op, repo.ErrDependModelsNotFound, id)
+			m.log.Warn(retErr.Error())
+			return retErr
+		}
+
+		if !slices.Contains(res.Reviewers, id) {
+			res.Reviewers = append(res.Reviewers, id)
+		}
+	}
+	m.prs[pullReq.ID] = res
+
+	return nil
+}
+
+// SetPullRequestStatus defines the logic of changing the PR's status.
+func (m *MemoryRepo) SetPullRequestStatus(
+	ctx context.Context,
+	status entities.PullRequestStatus,
+	pullReq dto.PullRequestDTO,
+) (dto.PullRequestDTO, error) {
+	const op = "memory.set-pull-request-status"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	res, ok := m.prs[pullReq.ID]
+	if !ok {
+		return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+	res.Status = status
+	res.MergedAt = copyPullRequest(pullReq).MergedAt
+	m.prs[pullReq.ID] = res
+
+	res = copyPullRequest(res)
+	res.Decisions = m.getLatestDecisions(res)
+
+	return res, nil
+}
+
+func (m *MemoryRepo) GetPullRequest(ctx context.Context, id entities.PullRequestID) (dto.PullRequestDTO, error) {
+	const op = "memory.get-pull-request"
+
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	res, ok := m.prs[id]
+	if !ok {
+		return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+	res = copyPullRequest(res)
+	res.Decisions = m.getLatestDecisions(res)
+
+	return res, nil
+}
+
+// GetUserPullRequests defines the logic of getting the PRs where the user is assigned as the reviewer.
+func (m *MemoryRepo) GetUserPullRequests(ctx context.Context, id entities.UserID) ([]dto.PullRequestDTOShort, error) {
+	const op = "memory.get-user-pull-requests"
+
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	if _, ok := m.users[id]; !ok {
+		return nil, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	res := make([]dto.PullRequestDTOShort, 0, 20)
+	for _, pullReq := range m.prs {
+		if slices.Contains(pullReq.Reviewers, id) {
+			short := dto.MakePullRequestDTOShort(pullReq)
+			short.Decision = m.getLatestDecisions(pullReq)[id]
+			res = append(res, short)
+		}
+	}
+	slices.SortFunc(res, func(a, b dto.PullRequestDTOShort) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return res, nil
+}
+
+// ChangeReviewer defines the logic of replacing the PR's reviewer.
+func (m *MemoryRepo) ChangeReviewer(
+	ctx context.Context,
+	lastID entities.UserID,
+	newID entities.UserID,
+	pullReq dto.PullRequestDTO,
+) error {
+	const op = "memory.change-reviewer"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	res, ok := m.prs[pullReq.ID]
+	if !ok {
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	pos := slices.Index(res.Reviewers, lastID)
+	if pos == -1 {
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	if _, ok := m.users[newID]; !ok {
+		retErr := fmt.Errorf("error of the %s: %w: reviewer '%s'", op, repo.ErrDependModelsNotFound, newID)
+		m.log.Warn(retErr.Error())
+		return retErr
+	}
+	res.Reviewers[pos] = newID
+
+	return nil
+}
+
+// ChangeReviewers defines the logic of the batch replacing or removing the PRs' reviewers.
+func (m *MemoryRepo) ChangeReviewers(ctx context.Context, changes []dto.ReviewerChangeDTO) error {
+	const op = "memory.change-reviewers-batch"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	for _, change := range changes {
+		res, ok := m.prs[change.ID]
+		if !ok {
+			continue
+		}
+
+		pos := slices.Index(res.Reviewers, change.OldReviewerID)
+		if pos == -1 {
+			continue
+		}
+
+		if len(change.NewReviewerID) == 0 {
+			res.Reviewers = slices.Delete(res.Reviewers, pos, pos+1)
+			m.prs[change.ID] = res
+			continue
+		}
+
+		if _, ok := m.users[change.NewReviewerID]; !ok {
+			retErr := fmt.Errorf("error of the %s: %w: reviewer '%s'", op, repo.ErrDependModelsNotFound, change.NewReviewerID)
+			m.log.Warn(retErr.Error())
+			return retErr
+		}
+		res.Reviewers[pos] = change.NewReviewerID
+	}
+
+	return nil
+}
+
+// GetReviewersOpenPullRequests defines the logic of getting the open PRs where any of the users is the reviewer.
+func (m *MemoryRepo) GetReviewersOpenPullRequests(
+	ctx context.Context,
+	ids []entities.UserID,
+) ([]dto.PullRequestDTO, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	reviewers := make(map[entities.UserID]struct{}, len(ids))
+	for _, id := range ids {
+		reviewers[id] = struct{}{}
+	}
+
+	res := make([]dto.PullRequestDTO, 0, 20)
+	for _, pullReq := range m.prs {
+		if pullReq.Status != entities.Open {
+			continue
+		}
+
+		if slices.ContainsFunc(pullReq.Reviewers, func(id entities.UserID) bool {
+			_, ok := reviewers[id]
+			return ok
+		}) {
+			res = append(res, copyPullRequest(pullReq))
+		}
+	}
+	slices.SortFunc(res, func(a, b dto.PullRequestDTO) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return res, nil
+}
+
+// AddAssignmentsHistory defines the logic of keeping the records of the PRs' reviewers changes.
+func (m *MemoryRepo) AddAssignmentsHistory(ctx context.Context, records []dto.ReviewerAssignmentDTO) error {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	m.history = append(m.history, records...)
+
+	return nil
+}
+
+// GetPullRequestHistory defines the logic of getting the PR's reviewers changes in the chronological order.
+func (m *MemoryRepo) GetPullRequestHistory(
+	ctx context.Context,
+	id entities.PullRequestID,
+) ([]dto.ReviewerAssignmentDTO, error) {
+	const op = "memory.get-pull-request-history"
+
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	if _, ok := m.prs[id]; !ok {
+		return nil, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	res := make([]dto.ReviewerAssignmentDTO, 0, 10)
+	for _, rec := range m.history {
+		if rec.ID == id {
+			res = append(res, rec)
+		}
+	}
+	slices.SortStableFunc(res, func(a, b dto.ReviewerAssignmentDTO) int {
+		return a.ChangedAt.Compare(b.ChangedAt)
+	})
+
+	return res, nil
+}
+
+// AddReviewDecision defines the logic of keeping the reviewer's decision on the PR.
+func (m *MemoryRepo) AddReviewDecision(ctx context.Context, review dto.PullRequestReviewDTO) error {
+	const op = "memory.add-review-decision"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if _, ok := m.prs[review.ID]; !ok {
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+	m.reviews = append(m.reviews, review)
+
+	return nil
+}
+
+// getLatestDecisions defines the logic of getting the latest decision of the PR's author and
+// every PR's current reviewer. The decisions made before the reviewer's latest assignment aren't taken into account.
+// It must be called under the read lock.
+func (m *MemoryRepo) getLatestDecisions(pullReq dto.PullRequestDTO) map[entities.UserID]entities.ReviewDecision {
+	var res map[entities.UserID]entities.ReviewDecision
+
+	assignedAt := make(map[entities.UserID]time.Time, len(pullReq.Reviewers))
+	for _, rec := range m.history {
+		if rec.ID == pullReq.ID && len(rec.NewReviewerID) != 0 && rec.ChangedAt.After(assignedAt[rec.NewReviewerID]) {
+			assignedAt[rec.NewReviewerID] = rec.ChangedAt
+		}
+	}
+
+	for _, review := range m.reviews {
+		isParticipant := review.ReviewerID == pullReq.AuthorID || slices.Contains(pullReq.Reviewers, review.ReviewerID)
+
+		if review.ID != pullReq.ID || !isParticipant || review.CreatedAt.Before(assignedAt[review.ReviewerID]) {
+			continue
+		}
+
+		if res == nil {
+			res = make(map[entities.UserID]entities.ReviewDecision, len(pullReq.Reviewers))
+		}
+		res[review.ReviewerID] = review.Decision
+	}
+
+	return res
+}
+
+// GetReviewersLoad defines the logic of counting the open PRs assigned to every team's member.
+func (m *MemoryRepo) GetReviewersLoad(ctx context.Context, teamName string) (entities.ReviewerLoad, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	team := m.teams[teamName]
+	res := make(entities.ReviewerLoad, len(team.members))
+
+	for _, id := range team.members {
+		res[id] = 0
+	}
+
+	for _, pullReq := range m.prs {
+		if pullReq.Status != entities.Open {
+			continue
+		}
+
+		for _, id := range pullReq.Reviewers {
+			if _, ok := res[id]; ok {
+				res[id]++
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// copyPullRequest defines the logic of the deep copying the PR so the stored object isn't shared.
+func copyPullRequest(pullReq dto.PullRequestDTO) dto.PullRequestDTO {
+	res := pullReq
+	res.Reviewers = slices.Clone(pullReq.Reviewers)
+
+	if res.Reviewers == nil {
+		res.Reviewers = make([]entities.UserID, 0)
+	}
+
+	if pullReq.CreatedAt != nil {
+		createdAt := *pullReq.CreatedAt
+		res.CreatedAt = &createdAt
+	}
+
+	if pullReq.MergedAt != nil {
+		mergedAt := *pullReq.MergedAt
+		res.MergedAt = &mergedAt
+	}
+
+	if pullReq.RequestedReviewers != nil {
+		requested := *pullReq.RequestedReviewers
+		res.RequestedReviewers = &requested
+	}
+
+	return res
+}
@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+)
+
+// statsCounter defines the accumulator of the review's statistics.
+type statsCounter struct {
+	stats      dto.ReviewStatsDTO
+	mergeTotal time.Duration
+}
+
+func (s *statsCounter) addPullRequest(pullReq dto.PullRequestDTO) {
+	s.stats.Assigned++
+
+	switch pullReq.Status {
+	case entities.Open:
+		s.stats.Open++
+	case entities.Merged:
+		s.stats.Merged++
+
+		if pullReq.CreatedAt != nil && pullReq.MergedAt != nil {
+			s.mergeTotal += pullReq.MergedAt.Sub(*pullReq.CreatedAt)
+		}
+	}
+}
+
+func (s *statsCounter) result() dto.ReviewStatsDTO {
+	res := s.stats
+
+	if res.Merged != 0 {
+		mean := s.mergeTotal.Seconds() / float64(res.Merged)
+		res.MeanMergeTime = &mean
+	}
+
+	return res
+}
+
+// GetUsersStats defines the logic of calculating the review's statistics for every reviewer.
+func (m *MemoryRepo) GetUsersStats(ctx context.Context, filter dto.StatsFilterDTO) ([]dto.UserStatsDTO, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	counters := make(map[entities.UserID]*statsCounter, len(m.users))
+	for id, user := range m.users {
+		if (len(filter.TeamName) == 0 || user.TeamName == filter.TeamName) &&
+			(len(filter.UserID) == 0 || id == filter.UserID) {
+			counters[id] = &statsCounter{}
+		}
+	}
+
+	for _, pullReq := range m.prs {
+		if !isWithinWindow(pullReq.CreatedAt, filter) {
+			continue
+		}
+
+		for _, id := range pullReq.Reviewers {
+			if counter, ok := counters[id]; ok {
+				counter.addPullRequest(pullReq)
+			}
+		}
+	}
+
+	for _, rec := range m.history {
+		if !isReassignment(rec) {
+			continue
+		}
+
+		if counter, ok := counters[rec.OldReviewerID]; ok && isWithinWindow(&rec.ChangedAt, filter) {
+			counter.stats.Reassignments++
+		}
+	}
+
+	res := make([]dto.UserStatsDTO, 0, len(counters))
+	for id, counter := range counters {
+		res = append(res, dto.UserStatsDTO{
+			ID:             id,
+			TeamName:       m.users[id].TeamName,
+			ReviewStatsDTO: counter.result(),
+		})
+	}
+	slices.SortFunc(res, func(a, b dto.UserStatsDTO) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return res, nil
+}
+
+// GetTeamsStats defines the logic of calculating the review's statistics for every team.
+func (m *MemoryRepo) GetTeamsStats(ctx context.Context, filter dto.StatsFilterDTO) ([]dto.TeamStatsDTO, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	counters := make(map[string]*statsCounter, len(m.teams))
+	for name := range m.teams {
+		if len(filter.TeamName) == 0 || name == filter.TeamName {
+			counters[name] = &statsCounter{}
+		}
+	}
+
+	for _, pullReq := range m.prs {
+		counter, ok := counters[m.users[pullReq.AuthorID].TeamName]
+		if ok && isWithinWindow(pullReq.CreatedAt, filter) {
+			counter.addPullRequest(pullReq)
+		}
+	}
+
+	for _, rec := range m.history {
+		if !isReassignment(rec) {
+			continue
+		}
+		teamName := m.users[m.prs[rec.ID].AuthorID].TeamName
+
+		if counter, ok := counters[teamName]; ok && isWithinWindow(&rec.ChangedAt, filter) {
+			counter.stats.Reassignments++
+		}
+	}
+
+	res := make([]dto.TeamStatsDTO, 0, len(counters))
+	for name, counter := range counters {
+		res = append(res, dto.TeamStatsDTO{
+			TeamName:       name,
+			ReviewStatsDTO: counter.result(),
+		})
+	}
+	slices.SortFunc(res, func(a, b dto.TeamStatsDTO) int {
+		return cmp.Compare(a.TeamName, b.TeamName)
+	})
+
+	return res, nil
+}
+
+// isReassignment checks whether the history's record is the replacement of the reviewer.
+func isReassignment(rec dto.ReviewerAssignmentDTO) bool {
+	return len(rec.OldReviewerID) != 0 && len(rec.NewReviewerID) != 0
+}
+
+// isWithinWindow checks whether the moment is within the filter's [From, To) window.
+func isWithinWindow(moment *time.Time, filter dto.StatsFilterDTO) bool {
+	if moment == nil {
+		return filter.From == nil && filter.To == nil
+	}
+
+	if filter.From != nil && moment.Before(*filter.From) {
+		return false
+	}
+
+	return filter.To == nil || moment.Before(*filter.To)
+}
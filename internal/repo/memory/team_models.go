@@ -0,0 +1,218 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/repo"
+)
+
+// teamModel defines the stored view of the 'Team' model.
+type teamModel struct {
+	id       entities.TeamID
+	name     string
+	members  []entities.UserID
+	strategy entities.ReviewerStrategy
+	policy   entities.ReviewerPolicy
+	merge    entities.MergePolicy
+}
+
+// GetTeam defines the logic of getting the team object for the current name.
+func (m *MemoryRepo) GetTeam(ctx context.Context, name string) (entities.Team, error) {
+	const op = "memory.get-team"
+
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	model, ok := m.teams[name]
+	if !ok {
+		return entities.Team{}, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	team := entities.NewTeam()
+	team.ID = model.id
+	team.Name = model.name
+	team.Members = m.getTeamMembers(model)
+	team.ReviewerStrategy = model.strategy
+	team.ReviewerPolicy = model.policy
+	team.MergePolicy = model.merge
+
+	return team, nil
+}
+
+// CreateTeam defines the logic of creating a new team.
+// If the team already exists its members are created and/or updated and
+// the repo.ErrModelAlreadyExists is returned.
+func (m *MemoryRepo) CreateTeam(ctx context.Context, team entities.Team) error {
+	const op = "memory.create-team"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if err := m.checkMembersUnique(team); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
+		m.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	if model, ok := m.teams[team.Name]; ok {
+		if len(team.ReviewerStrategy) != 0 {
+			model.strategy = team.ReviewerStrategy
+		}
+
+		if !team.ReviewerPolicy.IsZero() {
+			model.policy = team.ReviewerPolicy
+		}
+
+		if !team.MergePolicy.IsZero() {
+			model.merge = team.MergePolicy
+		}
+		m.teams[team.Name] = model
+		m.setMembers(model.name, team.Members)
+
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelAlreadyExists)
+	}
+
+	if len(team.ReviewerStrategy) == 0 {
+		team.ReviewerStrategy = entities.RandomStrategy
+	}
+
+	m.teamSeq++
+	m.teams[team.Name] = teamModel{
+		id:       m.teamSeq,
+		name:     team.Name,
+		members:  make([]entities.UserID, 0, len(team.Members)),
+		strategy: team.ReviewerStrategy,
+		policy:   team.GetReviewerPolicy(),
+		merge:    team.MergePolicy,
+	}
+	m.setMembers(team.Name, team.Members)
+
+	return nil
+}
+
+// SetTeamReviewerStrategy defines the logic of changing the team's strategy of choosing the reviewers.
+func (m *MemoryRepo) SetTeamReviewerStrategy(
+	ctx context.Context,
+	name string,
+	strategy entities.ReviewerStrategy,
+) (entities.Team, error) {
+	const op = "memory.set-team-reviewer-strategy"
+
+	m.mut.Lock()
+	model, ok := m.teams[name]
+	if ok {
+		model.strategy = strategy
+		m.teams[name] = model
+	}
+	m.mut.Unlock()
+
+	if !ok {
+		return entities.Team{}, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	return m.GetTeam(ctx, name)
+}
+
+// SetTeamReviewerPolicy defines the logic of changing the team's bounds of the PR's reviewers count.
+func (m *MemoryRepo) SetTeamReviewerPolicy(
+	ctx context.Context,
+	name string,
+	policy entities.ReviewerPolicy,
+) (entities.Team, error) {
+	const op = "memory.set-team-reviewer-policy"
+
+	m.mut.Lock()
+	model, ok := m.teams[name]
+	if ok {
+		model.policy = policy
+		m.teams[name] = model
+	}
+	m.mut.Unlock()
+
+	if !ok {
+		return entities.Team{}, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	return m.GetTeam(ctx, name)
+}
+
+// SetTeamMergePolicy defines the logic of changing the team's conditions of the PR's merge.
+func (m *MemoryRepo) SetTeamMergePolicy(
+	ctx context.Context,
+	name string,
+	policy entities.MergePolicy,
+) (entities.Team, error) {
+	const op = "memory.set-team-merge-policy"
+
+	m.mut.Lock()
+	model, ok := m.teams[name]
+	if ok {
+		model.merge = policy
+		m.teams[name] = model
+	}
+	m.mut.Unlock()
+
+	if !ok {
+		return entities.Team{}, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	return m.GetTeam(ctx, name)
+}
+
+// checkMembersUnique checks that every member is mentioned once and doesn't belong to the other team.
+func (m *MemoryRepo) checkMembersUnique(team entities.Team) error {
+	ids := make(map[entities.UserID]struct{}, len(team.Members))
+
+	for _, member := range team.Members {
+		if _, ok := ids[member.ID]; ok {
+			return fmt.Errorf("%w: user '%s' is duplicated", repo.ErrCreateMultipleUniqueModels, member.ID)
+		}
+		ids[member.ID] = struct{}{}
+
+		if user, ok := m.users[member.ID]; ok && user.TeamName != team.Name {
+			return fmt.Errorf("%w: user '%s' belongs to the team '%s'",
+				repo.ErrCreateMultipleUniqueModels, member.ID, user.TeamName)
+		}
+	}
+
+	return nil
+}
+
+// setMembers defines the logic of creating and/or updating the members of the current team.
+// The existing member without the role keeps the current one.
+// It must be called under the write lock.
+func (m *MemoryRepo) setMembers(teamName string, members []entities.User) {
+	team := m.teams[teamName]
+
+	for _, member := range members {
+		if user, ok := m.users[member.ID]; !ok {
+			team.members = append(team.members, member.ID)
+		} else if len(member.Role) == 0 {
+			member.Role = user.Role
+		}
+		member.TeamName = teamName
+		member.Role = member.GetRole()
+		m.users[member.ID] = userModel{member}
+	}
+	m.teams[teamName] = team
+}
+
+// getTeamMembers defines the logic of getting the members for the current team.
+// It must be called under the read lock.
+func (m *MemoryRepo) getTeamMembers(team teamModel) []entities.User {
+	res := make([]entities.User, 0, len(team.members))
+
+	for _, id := range team.members {
+		user := m.users[id].User
+		res = append(res, entities.User{
+			ID:       user.ID,
+			Name:     user.Name,
+			IsActive: user.IsActive,
+			Role:     user.Role,
+		})
+	}
+
+	return res
+}
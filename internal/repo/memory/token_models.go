@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/repo"
+)
+
+// tokenModel defines the stored API token with its secret's hash.
+type tokenModel struct {
+	entities.APIToken
+	hash string
+}
+
+// AddAPIToken defines the logic of storing the token by its secret's hash.
+// The token's user must exist.
+func (m *MemoryRepo) AddAPIToken(ctx context.Context, token entities.APIToken, hash string) (entities.APIToken, error) {
+	const op = "memory.add-api-token"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if _, ok := m.users[token.UserID]; len(token.UserID) != 0 && !ok {
+		return entities.APIToken{}, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	for _, stored := range m.tokens {
+		if stored.hash == hash {
+			return entities.APIToken{}, fmt.Errorf("error of the %s: %w", op, repo.ErrModelAlreadyExists)
+		}
+	}
+
+	m.tokenSeq++
+	token.ID = m.tokenSeq
+	token.Scopes = slices.Clone(token.Scopes)
+	m.tokens[token.ID] = tokenModel{APIToken: token, hash: hash}
+
+	return copyToken(token), nil
+}
+
+// RevokeAPIToken defines the logic of marking the token revoked.
+// The token's revocation time isn't changed if it's already revoked.
+func (m *MemoryRepo) RevokeAPIToken(ctx context.Context, id entities.TokenID, revokedAt time.Time) error {
+	const op = "memory.revoke-api-token"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	token, ok := m.tokens[id]
+	if !ok {
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	if !token.IsRevoked() {
+		token.RevokedAt = &revokedAt
+		m.tokens[id] = token
+	}
+
+	return nil
+}
+
+// GetAPITokens defines the logic of getting the tokens in the order of their creation.
+func (m *MemoryRepo) GetAPITokens(ctx context.Context) ([]entities.APIToken, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	res := make([]entities.APIToken, 0, len(m.tokens))
+	for _, token := range m.tokens {
+		res = append(res, copyToken(token.APIToken))
+	}
+
+	slices.SortFunc(res, func(a, b entities.APIToken) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return res, nil
+}
+
+// GetAPITokenByHash defines the logic of getting the token by its secret's hash.
+func (m *MemoryRepo) GetAPITokenByHash(ctx context.Context, hash string) (entities.APIToken, error) {
+	const op = "memory.get-api-token-by-hash"
+
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	for _, token := range m.tokens {
+		if token.hash == hash {
+			return copyToken(token.APIToken), nil
+		}
+	}
+
+	return entities.APIToken{}, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+}
+
+func copyToken(token entities.APIToken) entities.APIToken {
+	token.Scopes = slices.Clone(token.Scopes)
+
+	if token.RevokedAt != nil {
+		revokedAt := *token.RevokedAt
+		token.RevokedAt = &revokedAt
+	}
+	return token
+}
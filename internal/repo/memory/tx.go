@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+// txKey defines the ctx's key of the started transaction.
+type txKey struct{}
+
+// snapshot defines the copy of the storage's state used for the transaction's rollback.
+// The rollback replaces the whole state, so every writer of it must run inside the transaction:
+// the writes made outside of the transactions concurrently with the rolled back one would be lost.
+type snapshot struct {
+	teams   map[string]teamModel
+	users   map[entities.UserID]userModel
+	prs     map[entities.PullRequestID]dto.PullRequestDTO
+	teamSeq entities.TeamID
+
+	history []dto.ReviewerAssignmentDTO
+	reviews []dto.PullRequestReviewDTO
+
+	webhooks   map[entities.WebhookID]entities.Webhook
+	webhookSeq entities.WebhookID
+	eventSeq   entities.EventID
+}
+
+// WithinTx defines the logic of running the fn inside the single transaction.
+// The transactions are serialized, so every level is handled as the services.Serializable one.
+// The storage's state is restored if the fn returns the error.
+func (m *MemoryRepo) WithinTx(
+	ctx context.Context,
+	level services.IsolationLevel,
+	fn func(ctx context.Context) error,
+) error {
+	if _, ok := ctx.Value(txKey{}).(bool); ok {
+		return fn(ctx)
+	}
+
+	m.txMut.Lock()
+	defer m.txMut.Unlock()
+
+	state := m.snapshot()
+
+	if err := fn(context.WithValue(ctx, txKey{}, true)); err != nil {
+		m.restore(state)
+		return err
+	}
+
+	return nil
+}
+
+func (m *MemoryRepo) snapshot() snapshot {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	state := snapshot{
+		teams:   make(map[string]teamModel, len(m.teams)),
+		users:   maps.Clone(m.users),
+		prs:     make(map[entities.PullRequestID]dto.PullRequestDTO, len(m.prs)),
+		teamSeq: m.teamSeq,
+
+		history: slices.Clone(m.history),
+		reviews: slices.Clone(m.reviews),
+
+		webhooks:   maps.Clone(m.webhooks),
+		webhookSeq: m.webhookSeq,
+		eventSeq:   m.eventSeq,
+	}
+
+	for name, team := range m.teams {
+		team.members = slices.Clone(team.members)
+		state.teams[name] = team
+	}
+
+	for id, pullReq := range m.prs {
+		state.prs[id] = copyPullRequest(pullReq)
+	}
+
+	return state
+}
+
+func (m *MemoryRepo) restore(state snapshot) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	m.teams = state.teams
+	m.users = state.users
+	m.prs = state.prs
+	m.teamSeq = state.teamSeq
+	m.history = state.history
+	m.reviews = state.reviews
+	m.webhooks = state.webhooks
+	m.webhookSeq = state.webhookSeq
+
+	// The outbox's events are changed by the dispatchers outside of the transactions,
+	// so only the ones written by the rolled back transaction are removed.
+	for id := range m.outbox {
+		if id > state.eventSeq {
+			delete(m.outbox, id)
+		}
+	}
+	m.eventSeq = state.eventSeq
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+func TestMemoryRepoWithinTxRollback(t *testing.T) {
+	repository := makeTestRepo(t)
+	ctx := context.Background()
+
+	errFailed := errors.New("the transaction's failure")
+
+	err := repository.WithinTx(ctx, services.Serializable, func(ctx context.Context) error {
+		if err := repository.CreatePullRequest(ctx, makeTestPullRequest("pr-2", "bob")); err != nil {
+			return err
+		}
+
+		// The nested call joins the started transaction, so its changes are rolled back too.
+		err := repository.WithinTx(ctx, services.ReadCommitted, func(ctx context.Context) error {
+			pullReq := makeTestPullRequest("pr-1", "alice")
+			pullReq.Reviewers = append(pullReq.Reviewers, "bob")
+
+			if err := repository.AddPullRequestReviewers(ctx, pullReq); err != nil {
+				return err
+			}
+
+			return repository.AddAssignmentsHistory(ctx, []dto.ReviewerAssignmentDTO{
+				{ID: "pr-1", NewReviewerID: "bob", Reason: entities.AssignmentCreated, ChangedAt: time.Now()},
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := repository.AddOutboxEvents(ctx, []entities.DomainEvent{{Type: entities.ReviewerAssigned}}); err != nil {
+			return err
+		}
+		return errFailed
+	})
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("expected the fn's error returned, got %v", err)
+	}
+
+	if _, err := repository.GetPullRequest(ctx, "pr-2"); !errors.Is(err, repo.ErrModelNotFound) {
+		t.Fatalf("expected the rolled back PR not created, got %v", err)
+	}
+
+	if pullReq, err := repository.GetPullRequest(ctx, "pr-1"); err != nil || len(pullReq.Reviewers) != 0 {
+		t.Fatalf("expected the nested changes rolled back, got %+v %v", pullReq, err)
+	}
+
+	if history, err := repository.GetPullRequestHistory(ctx, "pr-1"); err != nil || len(history) != 0 {
+		t.Fatalf("expected the rolled back history empty, got %+v %v", history, err)
+	}
+
+	if events, err := repository.ClaimOutboxEvents(ctx, time.Now(), time.Now(), 10); err != nil || len(events) != 0 {
+		t.Fatalf("expected the rolled back events removed, got %+v %v", events, err)
+	}
+
+	err = repository.WithinTx(ctx, services.Serializable, func(ctx context.Context) error {
+		return repository.CreatePullRequest(ctx, makeTestPullRequest("pr-2", "bob"))
+	})
+	if err != nil {
+		t.Fatalf("couldn't create the PR inside the transaction: %v", err)
+	}
+
+	if _, err := repository.GetPullRequest(ctx, "pr-2"); err != nil {
+		t.Fatalf("expected the committed PR kept, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/repo"
+)
+
+// userModel defines the stored view of the 'User' model.
+type userModel struct {
+	entities.User
+}
+
+func (m *MemoryRepo) SetUserIsActive(ctx context.Context, isActive bool, id entities.UserID) (entities.User, error) {
+	const op = "memory.set-user-is-active"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	user, ok := m.users[id]
+	if !ok {
+		return entities.User{}, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+	user.IsActive = isActive
+	m.users[id] = user
+
+	return user.User, nil
+}
+
+func (m *MemoryRepo) GetUser(ctx context.Context, id entities.UserID) (entities.User, error) {
+	const op = "memory.get-user"
+
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	user, ok := m.users[id]
+	if !ok {
+		return entities.User{}, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	return user.User, nil
+}
+
+// GetUsers defines the logic of getting the existing users for the current ids.
+func (m *MemoryRepo) GetUsers(ctx context.Context, ids []entities.UserID) ([]entities.User, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	res := make([]entities.User, 0, len(ids))
+	for _, id := range ids {
+		if user, ok := m.users[id]; ok {
+			res = append(res, user.User)
+		}
+	}
+
+	return res, nil
+}
+
+// SetTeamUsersIsActive defines the logic of changing the activity of the team's members.
+// The repo.ErrModelNotFound is returned if any user isn't the team's member.
+func (m *MemoryRepo) SetTeamUsersIsActive(
+	ctx context.Context,
+	teamName string,
+	isActive bool,
+	ids []entities.UserID,
+) error {
+	const op = "memory.set-team-users-is-active"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	for _, id := range ids {
+		if user, ok := m.users[id]; !ok || user.TeamName != teamName {
+			return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+		}
+	}
+
+	for _, id := range ids {
+		user := m.users[id]
+		user.IsActive = isActive
+		m.users[id] = user
+	}
+
+	return nil
+}
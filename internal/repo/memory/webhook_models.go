@@ -0,0 +1,215 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+)
+
+// AddTeamWebhook defines the logic of registering the team's webhook.
+func (m *MemoryRepo) AddTeamWebhook(ctx context.Context, webhook entities.Webhook) (entities.Webhook, error) {
+	const op = "memory.add-team-webhook"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if _, ok := m.teams[webhook.TeamName]; !ok {
+		return entities.Webhook{}, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	m.webhookSeq++
+	webhook.ID = m.webhookSeq
+	m.webhooks[webhook.ID] = webhook
+
+	return webhook, nil
+}
+
+// DeleteTeamWebhook defines the logic of removing the team's webhook with its deliveries.
+func (m *MemoryRepo) DeleteTeamWebhook(ctx context.Context, teamName string, id entities.WebhookID) error {
+	const op = "memory.delete-team-webhook"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if webhook, ok := m.webhooks[id]; !ok || webhook.TeamName != teamName {
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+	delete(m.webhooks, id)
+
+	for deliveryID, delivery := range m.deliveries {
+		if delivery.WebhookID == id {
+			delete(m.deliveries, deliveryID)
+		}
+	}
+
+	return nil
+}
+
+// GetTeamWebhooks defines the logic of getting the team's webhooks in the order of their registration.
+func (m *MemoryRepo) GetTeamWebhooks(ctx context.Context, teamName string) ([]entities.Webhook, error) {
+	const op = "memory.get-team-webhooks"
+
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	if _, ok := m.teams[teamName]; !ok {
+		return nil, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	res := make([]entities.Webhook, 0, 5)
+	for _, webhook := range m.webhooks {
+		if webhook.TeamName == teamName {
+			res = append(res, webhook)
+		}
+	}
+
+	slices.SortFunc(res, func(a, b entities.Webhook) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return res, nil
+}
+
+// AddWebhookDeliveries defines the logic of queueing the webhooks' deliveries.
+// The delivery of the event already queued for the webhook is skipped.
+func (m *MemoryRepo) AddWebhookDeliveries(ctx context.Context, deliveries []entities.WebhookDelivery) error {
+	const op = "memory.add-webhook-deliveries"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	for _, delivery := range deliveries {
+		if _, ok := m.webhooks[delivery.WebhookID]; !ok {
+			return fmt.Errorf("error of the %s: %w: webhook %d", op, repo.ErrDependModelsNotFound, delivery.WebhookID)
+		}
+	}
+
+	for _, delivery := range deliveries {
+		if m.hasWebhookDelivery(delivery.WebhookID, delivery.EventID) {
+			continue
+		}
+
+		m.deliverySeq++
+		delivery.ID = m.deliverySeq
+		delivery.Payload = slices.Clone(delivery.Payload)
+		m.deliveries[delivery.ID] = delivery
+	}
+
+	return nil
+}
+
+// ClaimWebhookDeliveries defines the logic of taking the pending deliveries due by the now.
+// The claimed deliveries aren't given to the other senders until the lockUntil.
+func (m *MemoryRepo) ClaimWebhookDeliveries(
+	ctx context.Context,
+	now time.Time,
+	lockUntil time.Time,
+	limit int,
+) ([]entities.WebhookDelivery, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	res := make([]entities.WebhookDelivery, 0, limit)
+	for _, delivery := range m.deliveries {
+		if delivery.Status == entities.DeliveryPending && !delivery.NextAttemptAt.After(now) {
+			res = append(res, delivery)
+		}
+	}
+
+	slices.SortFunc(res, func(a, b entities.WebhookDelivery) int {
+		return cmp.Or(a.NextAttemptAt.Compare(b.NextAttemptAt), cmp.Compare(a.ID, b.ID))
+	})
+	res = res[:min(limit, len(res))]
+
+	for i, delivery := range res {
+		delivery.NextAttemptAt = lockUntil
+		m.deliveries[delivery.ID] = delivery
+
+		res[i] = m.getWebhookDelivery(delivery)
+	}
+
+	return res, nil
+}
+
+// UpdateWebhookDelivery defines the logic of keeping the result of the delivery's attempt.
+func (m *MemoryRepo) UpdateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) error {
+	const op = "memory.update-webhook-delivery"
+
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	stored, ok := m.deliveries[delivery.ID]
+	if !ok {
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	stored.Status = delivery.Status
+	stored.Attempts = delivery.Attempts
+	stored.NextAttemptAt = delivery.NextAttemptAt
+	stored.LastStatusCode = delivery.LastStatusCode
+	stored.LastError = delivery.LastError
+	stored.DeliveredAt = delivery.DeliveredAt
+	m.deliveries[delivery.ID] = stored
+
+	return nil
+}
+
+// GetWebhookDeliveries defines the logic of getting the filtered deliveries from the newest ones.
+func (m *MemoryRepo) GetWebhookDeliveries(
+	ctx context.Context,
+	filter dto.WebhookDeliveriesFilterDTO,
+) ([]entities.WebhookDelivery, error) {
+	const op = "memory.get-webhook-deliveries"
+
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+
+	if _, ok := m.teams[filter.TeamName]; len(filter.TeamName) != 0 && !ok {
+		return nil, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	res := make([]entities.WebhookDelivery, 0, filter.Limit)
+	for _, delivery := range m.deliveries {
+		if len(filter.TeamName) != 0 && delivery.TeamName != filter.TeamName {
+			continue
+		} else if len(filter.Status) != 0 && delivery.Status != filter.Status {
+			continue
+		}
+		res = append(res, m.getWebhookDelivery(delivery))
+	}
+
+	slices.SortFunc(res, func(a, b entities.WebhookDelivery) int {
+		return cmp.Compare(b.ID, a.ID)
+	})
+
+	return res[:min(filter.Limit, len(res))], nil
+}
+
+// getWebhookDelivery returns the copy of the delivery with its webhook's URL and secret.
+// It must be called under the read lock.
+func (m *MemoryRepo) getWebhookDelivery(delivery entities.WebhookDelivery) entities.WebhookDelivery {
+	webhook := m.webhooks[delivery.WebhookID]
+
+	delivery.URL = webhook.URL
+	delivery.Secret = webhook.Secret
+	delivery.Payload = slices.Clone(delivery.Payload)
+
+	return delivery
+}
+
+// hasWebhookDelivery checks whether the event's delivery to the webhook is already queued.
+// It must be called under the read lock.
+func (m *MemoryRepo) hasWebhookDelivery(webhookID entities.WebhookID, eventID entities.EventID) bool {
+	for _, delivery := range m.deliveries {
+		if delivery.WebhookID == webhookID && delivery.EventID == eventID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/jackc/pgx/v5"
+)
+
+const insertIdempotencyKey = `
+	INSERT INTO idempotent_responses (idempotency_key, fingerprint, created_at)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (idempotency_key) DO UPDATE
+	SET fingerprint=EXCLUDED.fingerprint, status_code=NULL, body=NULL, created_at=EXCLUDED.created_at
+	WHERE (idempotent_responses.status_code IS NULL AND idempotent_responses.created_at < $4)
+		OR idempotent_responses.created_at < $5
+	RETURNING idempotency_key
+`
+
+const selectIdempotentResponse = `
+	SELECT idempotency_key, fingerprint, status_code, body, created_at
+	FROM idempotent_responses
+	WHERE idempotency_key=$1
+`
+
+// ReserveIdempotencyKey defines the logic of reserving the key with the pending record.
+// The key is reserved if it's new, if its pending record was created before the staleBefore
+// or if its record was created before the expiredBefore. The stored record is returned otherwise.
+func (p *PostgreSQLRepo) ReserveIdempotencyKey(
+	ctx context.Context,
+	record dto.IdempotencyRecordDTO,
+	staleBefore time.Time,
+	expiredBefore time.Time,
+) (dto.IdempotencyRecordDTO, bool, error) {
+	const op = "postgres.reserve-idempotency-key"
+
+	var key string
+	err := p.conf.db(ctx).QueryRow(ctx, insertIdempotencyKey,
+		record.Key, record.Fingerprint, record.CreatedAt, staleBefore, expiredBefore).Scan(&key)
+	if err == nil {
+		return record, true, nil
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return dto.IdempotencyRecordDTO{}, false, retErr
+	}
+
+	var (
+		stored     dto.IdempotencyRecordDTO
+		statusCode *int32
+	)
+	err = p.conf.db(ctx).QueryRow(ctx, selectIdempotentResponse, record.Key).Scan(
+		&stored.Key, &stored.Fingerprint, &statusCode, &stored.Body, &stored.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.IdempotencyRecordDTO{}, false, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+		}
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+		p.conf.log.Warn(retErr.Error())
+		return dto.IdempotencyRecordDTO{}, false, retErr
+	}
+
+	if statusCode != nil {
+		stored.StatusCode = int(*statusCode)
+	}
+
+	return stored, false, nil
+}
+
+const updateIdempotentResponse = `
+	UPDATE idempotent_responses
+	SET status_code=$2, body=$3
+	WHERE idempotency_key=$1 AND fingerprint=$4
+`
+
+// SaveIdempotentResponse defines the logic of keeping the response for the reserved key.
+func (p *PostgreSQLRepo) SaveIdempotentResponse(ctx context.Context, record dto.IdempotencyRecordDTO) error {
+	const op = "postgres.save-idempotent-response"
+
+	tag, err := p.conf.db(ctx).Exec(ctx, updateIdempotentResponse,
+		record.Key, record.StatusCode, record.Body, record.Fingerprint)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	return nil
+}
+
+const deleteIdempotencyKey = `
+	DELETE FROM idempotent_responses
+	WHERE idempotency_key=$1 AND status_code IS NULL
+`
+
+// DeleteIdempotencyKey defines the logic of releasing the key's pending record.
+func (p *PostgreSQLRepo) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	const op = "postgres.delete-idempotency-key"
+
+	if _, err := p.conf.db(ctx).Exec(ctx, deleteIdempotencyKey, key); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	return nil
+}
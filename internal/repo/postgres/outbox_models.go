@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/repo"
+)
+
+const insertOutboxEvents = `
+	INSERT INTO outbox (event_type, pr_id, team_name, payload, occurred_at, next_attempt_at)
+	VALUES %s
+`
+
+// AddOutboxEvents defines the logic of writing the domain events to the outbox.
+func (p *PostgreSQLRepo) AddOutboxEvents(ctx context.Context, events []entities.DomainEvent) error {
+	const (
+		op      = "postgres.add-outbox-events"
+		columns = 6
+	)
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(events)*columns)
+	for _, event := range events {
+		args = append(args, event.Type, event.PullRequestID, event.TeamName, event.Payload,
+			event.OccurredAt, event.OccurredAt)
+	}
+
+	query := fmt.Sprintf(insertOutboxEvents, placeholders(len(events), columns))
+
+	if _, err := p.conf.db(ctx).Exec(ctx, query, args...); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	return nil
+}
+
+const claimOutboxEvents = `
+	UPDATE outbox
+	SET attempts=attempts + 1, next_attempt_at=$2
+	WHERE id IN (
+		SELECT id
+		FROM outbox
+		WHERE published_at IS NULL AND next_attempt_at <= $1
+		ORDER BY id
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING id, event_type, pr_id, team_name, payload, occurred_at
+`
+
+// ClaimOutboxEvents defines the logic of taking the unpublished events due by the now in their order.
+// The claimed events aren't given to the other dispatchers until the lockUntil.
+func (p *PostgreSQLRepo) ClaimOutboxEvents(
+	ctx context.Context,
+	now time.Time,
+	lockUntil time.Time,
+	limit int,
+) ([]entities.DomainEvent, error) {
+	const op = "postgres.claim-outbox-events"
+
+	rows, err := p.conf.db(ctx).Query(ctx, claimOutboxEvents, now, lockUntil, limit)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+	defer rows.Close()
+
+	res := make([]entities.DomainEvent, 0, limit)
+
+	for rows.Next() {
+		var event entities.DomainEvent
+
+		err := rows.Scan(&event.ID, &event.Type, &event.PullRequestID, &event.TeamName, &event.Payload, &event.OccurredAt)
+		if err != nil {
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+			p.conf.log.Warn(retErr.Error())
+			return nil, retErr
+		}
+		res = append(res, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	// The concurrent claims can return the events out of their order.
+	slices.SortFunc(res, func(a, b entities.DomainEvent) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return res, nil
+}
+
+const updateOutboxEventPublished = `
+	UPDATE outbox
+	SET published_at=$2, last_error=NULL
+	WHERE id=$1
+`
+
+// SetOutboxEventPublished defines the logic of marking the event as the published one.
+func (p *PostgreSQLRepo) SetOutboxEventPublished(ctx context.Context, id entities.EventID, publishedAt time.Time) error {
+	const op = "postgres.set-outbox-event-published"
+
+	if err := p.updateOutboxEvent(ctx, updateOutboxEventPublished, id, publishedAt); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
+		p.conf.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	return nil
+}
+
+const updateOutboxEventFailed = `
+	UPDATE outbox
+	SET last_error=$2
+	WHERE id=$1
+`
+
+// SetOutboxEventFailed defines the logic of keeping the reason of the event's failed publishing.
+// The event is published again after its claim expires.
+func (p *PostgreSQLRepo) SetOutboxEventFailed(ctx context.Context, id entities.EventID, reason string) error {
+	const op = "postgres.set-outbox-event-failed"
+
+	if err := p.updateOutboxEvent(ctx, updateOutboxEventFailed, id, reason); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
+		p.conf.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	return nil
+}
+
+// updateOutboxEvent defines the logic of running the query updating the single outbox's event.
+func (p *PostgreSQLRepo) updateOutboxEvent(ctx context.Context, query string, id entities.EventID, value any) error {
+	tag, err := p.conf.db(ctx).Exec(ctx, query, id, value)
+	if err != nil {
+		return fmt.Errorf("%w: %w", repo.ErrQueryExec, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return repo.ErrModelNotFound
+	}
+
+	return nil
+}
@@ -1,8 +1,13 @@
 package postgres
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 	"sync"
+
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // PostgreSQLRepo defines the logic of interaction with the PostgreSQL.
@@ -42,3 +47,18 @@ func (p *PostgreSQLRepo) Close() {
 		p.conf.close()
 	})
 }
+
+// Stat returns the statistics of the PostgreSQL connections' pool.
+func (p *PostgreSQLRepo) Stat() *pgxpool.Stat {
+	return p.conf.conn.Stat()
+}
+
+// Ping defines the logic of checking the connection to the PostgreSQL.
+func (p *PostgreSQLRepo) Ping(ctx context.Context) error {
+	const op = "postgres.ping"
+
+	if err := p.conf.conn.Ping(ctx); err != nil {
+		return fmt.Errorf("error of the %s: %w: %w", op, repo.ErrConnToRepository, err)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/jackc/pgx/v5"
+)
+
+// createdAtKey defines the PR's created_at used for the sorting: the PRs without it are the earliest ones.
+// The expression must be the same as in the indexes of the PRs' listing.
+const createdAtKey = `COALESCE(pr.created_at, '-infinity'::TIMESTAMP)`
+
+const selectPullRequestsPage = `
+	SELECT pr.id, pr.pr_name, pr.status, pr.created_at, pr.merged_at, pr.author_id,
+		ARRAY(SELECT ar.user_id FROM assigned_reviewers AS ar WHERE ar.pr_id=pr.id)
+	FROM pull_requests AS pr
+	WHERE %s
+	ORDER BY %s
+	LIMIT %s
+`
+
+// ListPullRequests defines the logic of getting the page of the filtered PRs in the filter's order.
+// The filter's author, reviewer and team must exist.
+func (p *PostgreSQLRepo) ListPullRequests(ctx context.Context, filter dto.PullRequestsFilterDTO) ([]dto.PullRequestDTO, error) {
+	const op = "postgres.list-pull-requests"
+
+	if err := p.checkListFilterModels(ctx, filter); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
+
+		if !errors.Is(err, repo.ErrModelNotFound) {
+			p.conf.log.Warn(retErr.Error())
+		}
+		return nil, retErr
+	}
+
+	query, args := makeListPullRequestsQuery(filter)
+
+	rows, err := p.conf.db(ctx).Query(ctx, query, args...)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	res, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (dto.PullRequestDTO, error) {
+		var (
+			pullReq   = dto.NewPullRequestDTO()
+			reviewers []string
+		)
+
+		err := row.Scan(&pullReq.ID, &pullReq.Name, &pullReq.Status,
+			&pullReq.CreatedAt, &pullReq.MergedAt, &pullReq.AuthorID, &reviewers)
+
+		for _, id := range reviewers {
+			pullReq.Reviewers = append(pullReq.Reviewers, entities.UserID(id))
+		}
+
+		return pullReq, err
+	})
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	return res, nil
+}
+
+// checkListFilterModels checks whether the users and the team the PRs are filtered by exist.
+func (p *PostgreSQLRepo) checkListFilterModels(ctx context.Context, filter dto.PullRequestsFilterDTO) error {
+	for _, id := range []entities.UserID{filter.AuthorID, filter.ReviewerID} {
+		if len(id) == 0 {
+			continue
+		}
+
+		if _, err := p.GetUser(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	if len(filter.TeamName) != 0 {
+		var id int64
+
+		if err := p.conf.db(ctx).QueryRow(ctx, selectTeamID, filter.TeamName).Scan(&id); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return repo.ErrModelNotFound
+			}
+			return fmt.Errorf("%w: %w", repo.ErrQueryExec, err)
+		}
+	}
+
+	return nil
+}
+
+// makeListPullRequestsQuery returns the query of the PRs' listing with only the filter's set conditions,
+// so the planner uses the indexes of the listing.
+func makeListPullRequestsQuery(filter dto.PullRequestsFilterDTO) (string, []any) {
+	var (
+		conds = make([]string, 0, 7)
+		args  = make([]any, 0, 8)
+	)
+
+	arg := func(val any) string {
+		args = append(args, val)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if len(filter.AuthorID) != 0 {
+		conds = append(conds, "pr.author_id="+arg(filter.AuthorID))
+	}
+
+	if len(filter.ReviewerID) != 0 {
+		conds = append(conds, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM assigned_reviewers AS ar WHERE ar.pr_id=pr.id AND ar.user_id=%s)",
+			arg(filter.ReviewerID)))
+	}
+
+	if len(filter.TeamName) != 0 {
+		conds = append(conds, fmt.Sprintf(
+			"pr.author_id IN (SELECT u.id FROM users AS u JOIN teams AS t ON t.id=u.team_id WHERE t.team_name=%s)",
+			arg(filter.TeamName)))
+	}
+
+	if len(filter.Status) != 0 {
+		conds = append(conds, "pr.status="+arg(filter.Status))
+	}
+
+	if filter.CreatedAfter != nil {
+		conds = append(conds, "pr.created_at >= "+arg(*filter.CreatedAfter))
+	}
+
+	if filter.CreatedBefore != nil {
+		conds = append(conds, "pr.created_at < "+arg(*filter.CreatedBefore))
+	}
+
+	cmp, dir := ">", "ASC"
+	if filter.Order == dto.Desc {
+		cmp, dir = "<", "DESC"
+	}
+
+	var order string
+	if filter.SortBy == dto.SortByID {
+		order = fmt.Sprintf("pr.id %s", dir)
+
+		if filter.After != nil {
+			conds = append(conds, fmt.Sprintf("pr.id %s %s", cmp, arg(filter.After.ID)))
+		}
+	} else {
+		order = fmt.Sprintf("%s %s, pr.id %s", createdAtKey, dir, dir)
+
+		if filter.After != nil {
+			conds = append(conds, fmt.Sprintf("(%s, pr.id) %s (COALESCE(%s::TIMESTAMP, '-infinity'::TIMESTAMP), %s)",
+				createdAtKey, cmp, arg(filter.After.CreatedAt), arg(filter.After.ID)))
+		}
+	}
+
+	where := "TRUE"
+	if len(conds) != 0 {
+		where = strings.Join(conds, " AND ")
+	}
+
+	return fmt.Sprintf(selectPullRequestsPage, where, order, arg(filter.Limit)), args
+}
@@ -1,14 +1,16 @@
 package postgres
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/MaKcm14/pr-service/internal/entities"
 	"github.com/MaKcm14/pr-service/internal/entities/dto"
 	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/MaKcm14/pr-service/internal/services"
+	"github.com/jackc/pgx/v5"
 )
 
 // pullRequestRepo defines the repo-object for interaction with the pull-requests models.
@@ -16,8 +18,14 @@ type pullRequestRepo struct {
 	conf *postgresConfig
 }
 
-// CreatePullRequest defines the logic of creating the pull request in the repo.
+// CreatePullRequest defines the logic of creating the pull request with its reviewers in the repo.
 func (p *PostgreSQLRepo) CreatePullRequest(ctx context.Context, pullRequest dto.PullRequestDTO) error {
+	return p.WithinTx(ctx, services.ReadCommitted, func(ctx context.Context) error {
+		return p.createPullRequest(ctx, pullRequest)
+	})
+}
+
+func (p *PostgreSQLRepo) createPullRequest(ctx context.Context, pullRequest dto.PullRequestDTO) error {
 	const op = "postgres.create-pull-request"
 
 	if err := p.prRepo.isPullRequestExists(ctx, pullRequest.ID); err == nil || !errors.Is(err, repo.ErrModelNotFound) {
@@ -58,6 +66,16 @@ func (p *PostgreSQLRepo) CreatePullRequest(ctx context.Context, pullRequest dto.
 	return nil
 }
 
+// AddPullRequestReviewers defines the logic of adding the PR's reviewers to the already existing PR.
+func (p *PostgreSQLRepo) AddPullRequestReviewers(ctx context.Context, pullReq dto.PullRequestDTO) error {
+	const op = "postgres.add-pull-request-reviewers"
+
+	if err := p.prRepo.setPullRequestReviewers(ctx, pullReq); err != nil {
+		return fmt.Errorf("error of the %s: %w", op, err)
+	}
+	return nil
+}
+
 const addPullRequestMembers = `
 	INSERT INTO assigned_reviewers (pr_id, user_id)
 	VALUES `
@@ -65,20 +83,18 @@ const addPullRequestMembers = `
 func (p pullRequestRepo) setPullRequestReviewers(ctx context.Context, pullReq dto.PullRequestDTO) error {
 	const op = "postgres.set-pull-request-reviewers"
 
-	query := bytes.Buffer{}
-	query.WriteString(addPullRequestMembers)
-
-	for idx, id := range pullReq.Reviewers {
-		query.WriteString(fmt.Sprintf("('%s', '%s')", pullReq.ID, id))
+	if len(pullReq.Reviewers) == 0 {
+		return nil
+	}
 
-		if idx != len(pullReq.Reviewers)-1 {
-			query.WriteString(",\n")
-		}
+	args := make([]any, 0, len(pullReq.Reviewers)*2)
+	for _, id := range pullReq.Reviewers {
+		args = append(args, pullReq.ID, id)
 	}
 
-	_, err := p.conf.conn.Exec(ctx, query.String())
+	_, err := p.conf.db(ctx).Exec(ctx, addPullRequestMembers+placeholders(len(pullReq.Reviewers), 2), args...)
 	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w, %s", op, repo.ErrQueryExec, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
 		p.conf.log.Warn(retErr.Error())
 		return retErr
 	}
@@ -87,8 +103,8 @@ func (p pullRequestRepo) setPullRequestReviewers(ctx context.Context, pullReq dt
 }
 
 const insertPullRequest = `
-	INSERT INTO pull_requests (id, pr_name, status, created_at, merged_at, author_id)
-	VALUES ($1, $2, $3, $4, $5, $6)
+	INSERT INTO pull_requests (id, pr_name, status, created_at, merged_at, author_id, requested_reviewers)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
 `
 
 func (p pullRequestRepo) createPullRequest(
@@ -97,7 +113,7 @@ func (p pullRequestRepo) createPullRequest(
 ) error {
 	const op = "postgres.create-pull-request-internal"
 
-	_, err := p.conf.conn.Exec(
+	_, err := p.conf.db(ctx).Exec(
 		ctx,
 		insertPullRequest,
 		pullRequest.ID,
@@ -106,10 +122,11 @@ func (p pullRequestRepo) createPullRequest(
 		pullRequest.CreatedAt,
 		pullRequest.MergedAt,
 		pullRequest.AuthorID,
+		pullRequest.RequestedReviewers,
 	)
 
 	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrQueryExec, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
 		p.conf.log.Warn(retErr.Error())
 		return retErr
 	}
@@ -126,9 +143,9 @@ const checkExisting = `
 func (p pullRequestRepo) isPullRequestExists(ctx context.Context, id entities.PullRequestID) error {
 	const op = "postgres.is-exists"
 
-	tag, err := p.conf.conn.Exec(ctx, checkExisting, id)
+	tag, err := p.conf.db(ctx).Exec(ctx, checkExisting, id)
 	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrQueryExec, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
 		p.conf.log.Warn(retErr.Error())
 		return retErr
 	}
@@ -150,6 +167,22 @@ func (p *PostgreSQLRepo) SetPullRequestStatus(
 	ctx context.Context,
 	status entities.PullRequestStatus,
 	pullReq dto.PullRequestDTO,
+) (dto.PullRequestDTO, error) {
+	var res dto.PullRequestDTO
+
+	err := p.WithinTx(ctx, services.ReadCommitted, func(ctx context.Context) error {
+		var err error
+		res, err = p.setPullRequestStatus(ctx, status, pullReq)
+		return err
+	})
+
+	return res, err
+}
+
+func (p *PostgreSQLRepo) setPullRequestStatus(
+	ctx context.Context,
+	status entities.PullRequestStatus,
+	pullReq dto.PullRequestDTO,
 ) (dto.PullRequestDTO, error) {
 	const op = "postgres.set-pull-request-to-merged"
 
@@ -164,11 +197,11 @@ func (p *PostgreSQLRepo) SetPullRequestStatus(
 		return dto.PullRequestDTO{}, retErr
 	}
 
-	_, err := p.conf.conn.Exec(ctx, updatePRStatus, status, pullReq.MergedAt, pullReq.ID)
+	_, err := p.conf.db(ctx).Exec(ctx, updatePRStatus, status, pullReq.MergedAt, pullReq.ID)
 	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrQueryExec, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
 		p.conf.log.Warn(retErr.Error())
-		return dto.PullRequestDTO{}, err
+		return dto.PullRequestDTO{}, retErr
 	}
 
 	res, err := p.prRepo.getPullRequest(ctx, pullReq.ID)
@@ -181,7 +214,7 @@ func (p *PostgreSQLRepo) SetPullRequestStatus(
 }
 
 const selectPullRequest = `
-	SELECT id, pr_name, status, created_at, merged_at, author_id
+	SELECT id, pr_name, status, created_at, merged_at, author_id, requested_reviewers
 	FROM pull_requests
 	WHERE id=$1
 `
@@ -219,9 +252,9 @@ func (p pullRequestRepo) getPullRequest(
 ) (dto.PullRequestDTO, error) {
 	const op = "postgres.get-pull-request"
 
-	rows, err := p.conf.conn.Query(ctx, selectPullRequest, id)
+	rows, err := p.conf.db(ctx).Query(ctx, selectPullRequest, id)
 	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrQueryExec, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
 		p.conf.log.Warn(retErr.Error())
 		return dto.PullRequestDTO{}, retErr
 	}
@@ -229,14 +262,16 @@ func (p pullRequestRepo) getPullRequest(
 
 	res := dto.NewPullRequestDTO()
 	if rows.Next() {
-		err := rows.Scan(&res.ID, &res.Name, &res.Status, &res.CreatedAt, &res.MergedAt, &res.AuthorID)
+		err := rows.Scan(
+			&res.ID, &res.Name, &res.Status, &res.CreatedAt, &res.MergedAt, &res.AuthorID, &res.RequestedReviewers,
+		)
 		if err != nil {
-			retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrResProcessing, err)
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
 			p.conf.log.Warn(retErr.Error())
 			return dto.PullRequestDTO{}, retErr
 		}
 	} else if rows.Err() != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrResProcessing, rows.Err())
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, rows.Err())
 		p.conf.log.Warn(retErr.Error())
 		return dto.PullRequestDTO{}, retErr
 	}
@@ -249,6 +284,73 @@ func (p pullRequestRepo) getPullRequest(
 	}
 	res.Reviewers = reviewers
 
+	decisions, err := p.getPullRequestDecisions(ctx, id)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
+		p.conf.log.Warn(retErr.Error())
+		return dto.PullRequestDTO{}, retErr
+	}
+	res.Decisions = decisions
+
+	return res, nil
+}
+
+const selectPRDecisions = `
+	SELECT DISTINCT ON (rd.reviewer_id) rd.reviewer_id, rd.decision
+	FROM review_decisions AS rd
+		JOIN pull_requests AS pr
+		ON pr.id=rd.pr_id
+		LEFT JOIN assigned_reviewers AS ar
+		ON ar.pr_id=rd.pr_id AND ar.user_id=rd.reviewer_id
+	WHERE rd.pr_id=$1 AND (ar.user_id IS NOT NULL OR rd.reviewer_id=pr.author_id) AND rd.created_at >= COALESCE((
+		SELECT MAX(h.changed_at)
+		FROM reviewer_assignments_history AS h
+		WHERE h.pr_id=rd.pr_id AND h.new_user_id=rd.reviewer_id
+	), '-infinity')
+	ORDER BY rd.reviewer_id, rd.created_at DESC, rd.id DESC
+`
+
+// getPullRequestDecisions defines the logic of getting the latest decision of the PR's author and
+// every PR's current reviewer. The decisions made before the reviewer's latest assignment aren't taken into account.
+func (p pullRequestRepo) getPullRequestDecisions(
+	ctx context.Context,
+	id entities.PullRequestID,
+) (map[entities.UserID]entities.ReviewDecision, error) {
+	const op = "postgres.get-pull-request-decisions"
+
+	rows, err := p.conf.db(ctx).Query(ctx, selectPRDecisions, id)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+	defer rows.Close()
+
+	var res map[entities.UserID]entities.ReviewDecision
+	for rows.Next() {
+		var (
+			reviewerID entities.UserID
+			decision   entities.ReviewDecision
+		)
+
+		if err := rows.Scan(&reviewerID, &decision); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+			p.conf.log.Warn(retErr.Error())
+			return nil, retErr
+		}
+
+		if res == nil {
+			res = make(map[entities.UserID]entities.ReviewDecision, 5)
+		}
+		res[reviewerID] = decision
+	}
+
+	if rows.Err() != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, rows.Err())
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
 	return res, nil
 }
 
@@ -266,9 +368,9 @@ func (p pullRequestRepo) getPullRequestReviewers(
 ) ([]entities.UserID, error) {
 	const op = "postgres.get-pull-request-reviewers"
 
-	rows, err := p.conf.conn.Query(ctx, selectPRReviewers, id)
+	rows, err := p.conf.db(ctx).Query(ctx, selectPRReviewers, id)
 	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrQueryExec, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
 		p.conf.log.Warn(retErr.Error())
 		return nil, retErr
 	}
@@ -277,12 +379,16 @@ func (p pullRequestRepo) getPullRequestReviewers(
 	res := make([]entities.UserID, 0, 5)
 	for rows.Next() {
 		var id entities.UserID
-		rows.Scan(&id)
+		if err := rows.Scan(&id); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+			p.conf.log.Warn(retErr.Error())
+			return nil, retErr
+		}
 		res = append(res, id)
 	}
 
 	if rows.Err() != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrResProcessing, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, rows.Err())
 		p.conf.log.Warn(retErr.Error())
 		return nil, retErr
 	}
@@ -291,11 +397,22 @@ func (p pullRequestRepo) getPullRequestReviewers(
 }
 
 const selectUserPRs = `
-	SELECT pr.id, pr.pr_name, pr.author_id, pr.status
+	SELECT pr.id, pr.pr_name, pr.author_id, pr.status, COALESCE(rd.decision, '')
 	FROM pull_requests AS pr
 	JOIN assigned_reviewers AS ar
 	ON pr.id=ar.pr_id
-	WHERE pr.author_id=$1
+	LEFT JOIN LATERAL (
+		SELECT decision
+		FROM review_decisions
+		WHERE pr_id=pr.id AND reviewer_id=ar.user_id AND created_at >= COALESCE((
+			SELECT MAX(h.changed_at)
+			FROM reviewer_assignments_history AS h
+			WHERE h.pr_id=pr.id AND h.new_user_id=ar.user_id
+		), '-infinity')
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	) AS rd ON TRUE
+	WHERE ar.user_id=$1
 `
 
 func (p *PostgreSQLRepo) GetUserPullRequests(ctx context.Context, id entities.UserID) ([]dto.PullRequestDTOShort, error) {
@@ -312,9 +429,9 @@ func (p *PostgreSQLRepo) GetUserPullRequests(ctx context.Context, id entities.Us
 		return nil, retErr
 	}
 
-	rows, err := p.conf.conn.Query(ctx, selectUserPRs, id)
+	rows, err := p.conf.db(ctx).Query(ctx, selectUserPRs, id)
 	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrQueryExec, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
 		p.conf.log.Warn(retErr.Error())
 		return nil, retErr
 	}
@@ -324,8 +441,8 @@ func (p *PostgreSQLRepo) GetUserPullRequests(ctx context.Context, id entities.Us
 	for rows.Next() {
 		pr := dto.PullRequestDTOShort{}
 
-		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status); err != nil {
-			retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrResProcessing, err)
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.Decision); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
 			p.conf.log.Warn(retErr.Error())
 			return nil, retErr
 		}
@@ -333,7 +450,7 @@ func (p *PostgreSQLRepo) GetUserPullRequests(ctx context.Context, id entities.Us
 	}
 
 	if rows.Err() != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrResProcessing, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, rows.Err())
 		p.conf.log.Warn(retErr.Error())
 		return nil, retErr
 	}
@@ -350,16 +467,276 @@ const changeReviewer = `
 func (p *PostgreSQLRepo) ChangeReviewer(ctx context.Context, lastID entities.UserID, newID entities.UserID, pullReq dto.PullRequestDTO) error {
 	const op = "postgres.change-reviewers"
 
-	tag, err := p.conf.conn.Exec(ctx, changeReviewer, newID, pullReq.ID, lastID)
+	tag, err := p.conf.db(ctx).Exec(ctx, changeReviewer, newID, pullReq.ID, lastID)
 
 	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrQueryExec, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
 		p.conf.log.Warn(retErr.Error())
 		return retErr
 	}
 
 	if tag.RowsAffected() == 0 {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrModelNotFound, err)
+		retErr := fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+		p.conf.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	return nil
+}
+
+const selectReviewersLoad = `
+	SELECT users.id, COUNT(pr.id)
+	FROM users
+		JOIN teams
+		ON users.team_id=teams.id
+		LEFT JOIN assigned_reviewers AS ar
+		ON ar.user_id=users.id
+		LEFT JOIN pull_requests AS pr
+		ON pr.id=ar.pr_id AND pr.status=$2
+	WHERE teams.team_name=$1
+	GROUP BY users.id
+`
+
+// GetReviewersLoad defines the logic of counting the open PRs assigned to every team's member.
+func (p *PostgreSQLRepo) GetReviewersLoad(ctx context.Context, teamName string) (entities.ReviewerLoad, error) {
+	const op = "postgres.get-reviewers-load"
+
+	rows, err := p.conf.db(ctx).Query(ctx, selectReviewersLoad, teamName, entities.Open)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+	defer rows.Close()
+
+	res := make(entities.ReviewerLoad, 250)
+	for rows.Next() {
+		var (
+			id    entities.UserID
+			count int
+		)
+
+		if err := rows.Scan(&id, &count); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+			p.conf.log.Warn(retErr.Error())
+			return nil, retErr
+		}
+		res[id] = count
+	}
+
+	if rows.Err() != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, rows.Err())
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	return res, nil
+}
+
+const (
+	updateReviewers = `
+		UPDATE assigned_reviewers AS ar
+		SET user_id=ch.new_id
+		FROM unnest($1::TEXT[], $2::TEXT[], $3::TEXT[]) AS ch(pr_id, old_id, new_id)
+		WHERE ar.pr_id=ch.pr_id AND ar.user_id=ch.old_id
+	`
+
+	deleteReviewers = `
+		DELETE FROM assigned_reviewers AS ar
+		USING unnest($1::TEXT[], $2::TEXT[]) AS ch(pr_id, old_id)
+		WHERE ar.pr_id=ch.pr_id AND ar.user_id=ch.old_id
+	`
+)
+
+// ChangeReviewers defines the logic of the batch replacing or removing the PRs' reviewers.
+func (p *PostgreSQLRepo) ChangeReviewers(ctx context.Context, changes []dto.ReviewerChangeDTO) error {
+	const op = "postgres.change-reviewers-batch"
+
+	var (
+		replaced = [3][]string{}
+		removed  = [2][]string{}
+	)
+
+	for _, change := range changes {
+		if len(change.NewReviewerID) == 0 {
+			removed[0] = append(removed[0], string(change.ID))
+			removed[1] = append(removed[1], string(change.OldReviewerID))
+			continue
+		}
+		replaced[0] = append(replaced[0], string(change.ID))
+		replaced[1] = append(replaced[1], string(change.OldReviewerID))
+		replaced[2] = append(replaced[2], string(change.NewReviewerID))
+	}
+
+	queries := []struct {
+		sql  string
+		args []any
+	}{
+		{sql: updateReviewers, args: []any{replaced[0], replaced[1], replaced[2]}},
+		{sql: deleteReviewers, args: []any{removed[0], removed[1]}},
+	}
+
+	for _, query := range queries {
+		if _, err := p.conf.db(ctx).Exec(ctx, query.sql, query.args...); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+			p.conf.log.Warn(retErr.Error())
+			return retErr
+		}
+	}
+
+	return nil
+}
+
+const selectReviewersOpenPRs = `
+	SELECT pr.id, pr.pr_name, pr.status, pr.created_at, pr.merged_at, pr.author_id, array_agg(ar.user_id)
+	FROM pull_requests AS pr
+		JOIN assigned_reviewers AS ar
+		ON ar.pr_id=pr.id
+	WHERE pr.status=$2 AND EXISTS (
+		SELECT 1
+		FROM assigned_reviewers
+		WHERE assigned_reviewers.pr_id=pr.id AND assigned_reviewers.user_id=ANY($1)
+	)
+	GROUP BY pr.id
+	ORDER BY pr.id
+`
+
+// GetReviewersOpenPullRequests defines the logic of getting the open PRs where any of the users is the reviewer.
+func (p *PostgreSQLRepo) GetReviewersOpenPullRequests(
+	ctx context.Context,
+	ids []entities.UserID,
+) ([]dto.PullRequestDTO, error) {
+	const op = "postgres.get-reviewers-open-pull-requests"
+
+	rows, err := p.conf.db(ctx).Query(ctx, selectReviewersOpenPRs, ids, entities.Open)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	res, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (dto.PullRequestDTO, error) {
+		var (
+			pullReq   = dto.NewPullRequestDTO()
+			reviewers []string
+		)
+
+		err := row.Scan(&pullReq.ID, &pullReq.Name, &pullReq.Status,
+			&pullReq.CreatedAt, &pullReq.MergedAt, &pullReq.AuthorID, &reviewers)
+
+		for _, id := range reviewers {
+			pullReq.Reviewers = append(pullReq.Reviewers, entities.UserID(id))
+		}
+
+		return pullReq, err
+	})
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	return res, nil
+}
+
+const insertAssignmentsHistory = `
+	INSERT INTO reviewer_assignments_history (pr_id, old_user_id, new_user_id, reason, actor_id, changed_at)
+	SELECT pr_id, NULLIF(old_id, ''), NULLIF(new_id, ''), reason, NULLIF(actor_id, ''), changed_at
+	FROM unnest($1::TEXT[], $2::TEXT[], $3::TEXT[], $4::TEXT[], $5::TEXT[], $6::TIMESTAMP[])
+		AS rec(pr_id, old_id, new_id, reason, actor_id, changed_at)
+`
+
+// AddAssignmentsHistory defines the logic of keeping the records of the PRs' reviewers changes.
+func (p *PostgreSQLRepo) AddAssignmentsHistory(ctx context.Context, records []dto.ReviewerAssignmentDTO) error {
+	const op = "postgres.add-assignments-history"
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	var (
+		columns   = [5][]string{}
+		changedAt = make([]time.Time, 0, len(records))
+	)
+
+	for _, rec := range records {
+		columns[0] = append(columns[0], string(rec.ID))
+		columns[1] = append(columns[1], string(rec.OldReviewerID))
+		columns[2] = append(columns[2], string(rec.NewReviewerID))
+		columns[3] = append(columns[3], string(rec.Reason))
+		columns[4] = append(columns[4], string(rec.ActorID))
+		changedAt = append(changedAt, rec.ChangedAt)
+	}
+
+	_, err := p.conf.db(ctx).Exec(ctx, insertAssignmentsHistory,
+		columns[0], columns[1], columns[2], columns[3], columns[4], changedAt)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	return nil
+}
+
+const selectPRHistory = `
+	SELECT pr_id, COALESCE(old_user_id, ''), COALESCE(new_user_id, ''), reason, COALESCE(actor_id, ''), changed_at
+	FROM reviewer_assignments_history
+	WHERE pr_id=$1
+	ORDER BY changed_at, id
+`
+
+// GetPullRequestHistory defines the logic of getting the PR's reviewers changes in the chronological order.
+func (p *PostgreSQLRepo) GetPullRequestHistory(
+	ctx context.Context,
+	id entities.PullRequestID,
+) ([]dto.ReviewerAssignmentDTO, error) {
+	const op = "postgres.get-pull-request-history"
+
+	if err := p.prRepo.isPullRequestExists(ctx, id); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return nil, retErr
+		}
+		p.conf.log.Warn(retErr.Error())
+
+		return nil, retErr
+	}
+
+	rows, err := p.conf.db(ctx).Query(ctx, selectPRHistory, id)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	res, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (dto.ReviewerAssignmentDTO, error) {
+		var rec dto.ReviewerAssignmentDTO
+		err := row.Scan(&rec.ID, &rec.OldReviewerID, &rec.NewReviewerID, &rec.Reason, &rec.ActorID, &rec.ChangedAt)
+		return rec, err
+	})
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	return res, nil
+}
+
+const insertReviewDecision = `
+	INSERT INTO review_decisions (pr_id, reviewer_id, decision, created_at)
+	VALUES ($1, $2, $3, $4)
+`
+
+// AddReviewDecision defines the logic of keeping the reviewer's decision on the PR.
+func (p *PostgreSQLRepo) AddReviewDecision(ctx context.Context, review dto.PullRequestReviewDTO) error {
+	const op = "postgres.add-review-decision"
+
+	_, err := p.conf.db(ctx).Exec(ctx, insertReviewDecision, review.ID, review.ReviewerID, review.Decision, review.CreatedAt)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
 		p.conf.log.Warn(retErr.Error())
 		return retErr
 	}
@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/jackc/pgx/v5"
+)
+
+const selectUsersStats = `
+	SELECT users.id, teams.team_name,
+		COUNT(pr.id),
+		COUNT(pr.id) FILTER (WHERE pr.status=$5),
+		COUNT(pr.id) FILTER (WHERE pr.status=$6),
+		COALESCE(rr.count, 0),
+		(AVG(EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)) FILTER (WHERE pr.status=$6))::FLOAT8
+	FROM users
+		JOIN teams
+		ON users.team_id=teams.id
+		LEFT JOIN assigned_reviewers AS ar
+		ON ar.user_id=users.id
+		LEFT JOIN pull_requests AS pr
+		ON pr.id=ar.pr_id
+			AND ($3::TIMESTAMP IS NULL OR pr.created_at >= $3)
+			AND ($4::TIMESTAMP IS NULL OR pr.created_at < $4)
+		LEFT JOIN (
+			SELECT old_user_id, COUNT(*) AS count
+			FROM reviewer_assignments_history
+			WHERE old_user_id IS NOT NULL AND new_user_id IS NOT NULL
+				AND ($3::TIMESTAMP IS NULL OR changed_at >= $3)
+				AND ($4::TIMESTAMP IS NULL OR changed_at < $4)
+			GROUP BY old_user_id
+		) AS rr
+		ON rr.old_user_id=users.id
+	WHERE ($1='' OR teams.team_name=$1) AND ($2='' OR users.id=$2)
+	GROUP BY users.id, teams.team_name, rr.count
+	ORDER BY users.id
+`
+
+// GetUsersStats defines the logic of calculating the review's statistics for every reviewer.
+func (p *PostgreSQLRepo) GetUsersStats(ctx context.Context, filter dto.StatsFilterDTO) ([]dto.UserStatsDTO, error) {
+	const op = "postgres.get-users-stats"
+
+	rows, err := p.conf.db(ctx).Query(ctx, selectUsersStats,
+		filter.TeamName, filter.UserID, filter.From, filter.To, entities.Open, entities.Merged)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	res, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (dto.UserStatsDTO, error) {
+		var stats dto.UserStatsDTO
+
+		err := row.Scan(&stats.ID, &stats.TeamName, &stats.Assigned, &stats.Open,
+			&stats.Merged, &stats.Reassignments, &stats.MeanMergeTime)
+
+		return stats, err
+	})
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	return res, nil
+}
+
+const selectTeamsStats = `
+	SELECT teams.team_name,
+		COUNT(pr.id),
+		COUNT(pr.id) FILTER (WHERE pr.status=$4),
+		COUNT(pr.id) FILTER (WHERE pr.status=$5),
+		COALESCE(rr.count, 0),
+		(AVG(EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)) FILTER (WHERE pr.status=$5))::FLOAT8
+	FROM teams
+		LEFT JOIN users
+		ON users.team_id=teams.id
+		LEFT JOIN pull_requests AS pr
+		ON pr.author_id=users.id
+			AND ($2::TIMESTAMP IS NULL OR pr.created_at >= $2)
+			AND ($3::TIMESTAMP IS NULL OR pr.created_at < $3)
+		LEFT JOIN (
+			SELECT authors.team_id, COUNT(*) AS count
+			FROM reviewer_assignments_history AS reassigns
+				JOIN pull_requests AS prs
+				ON prs.id=reassigns.pr_id
+				JOIN users AS authors
+				ON authors.id=prs.author_id
+			WHERE reassigns.old_user_id IS NOT NULL AND reassigns.new_user_id IS NOT NULL
+				AND ($2::TIMESTAMP IS NULL OR reassigns.changed_at >= $2)
+				AND ($3::TIMESTAMP IS NULL OR reassigns.changed_at < $3)
+			GROUP BY authors.team_id
+		) AS rr
+		ON rr.team_id=teams.id
+	WHERE $1='' OR teams.team_name=$1
+	GROUP BY teams.id, teams.team_name, rr.count
+	ORDER BY teams.team_name
+`
+
+// GetTeamsStats defines the logic of calculating the review's statistics for every team.
+func (p *PostgreSQLRepo) GetTeamsStats(ctx context.Context, filter dto.StatsFilterDTO) ([]dto.TeamStatsDTO, error) {
+	const op = "postgres.get-teams-stats"
+
+	rows, err := p.conf.db(ctx).Query(ctx, selectTeamsStats,
+		filter.TeamName, filter.From, filter.To, entities.Open, entities.Merged)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	res, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (dto.TeamStatsDTO, error) {
+		var stats dto.TeamStatsDTO
+
+		err := row.Scan(&stats.TeamName, &stats.Assigned, &stats.Open,
+			&stats.Merged, &stats.Reassignments, &stats.MeanMergeTime)
+
+		return stats, err
+	})
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	return res, nil
+}
@@ -0,0 +1,227 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/jackc/pgx/v5"
+)
+
+// testDSNKey defines the env's key of the DSN of the PostgreSQL used by the integration tests.
+const testDSNKey = "TEST_DSN"
+
+// makeTestRepo returns the repository over the new schema of the TEST_DSN's database
+// with every migration applied. The schema is dropped after the test.
+// The test is skipped if the TEST_DSN isn't set.
+func makeTestRepo(t *testing.T) *PostgreSQLRepo {
+	t.Helper()
+
+	dsn := os.Getenv(testDSNKey)
+	if len(dsn) == 0 {
+		t.Skipf("the %s isn't set", testDSNKey)
+	}
+
+	ctx := context.Background()
+	schema := fmt.Sprintf("pr_service_test_%d", time.Now().UnixNano())
+
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		t.Fatalf("couldn't connect to the test database: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := conn.Exec(ctx, "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Errorf("couldn't drop the test schema: %v", err)
+		}
+		conn.Close(ctx)
+	})
+
+	if _, err := conn.Exec(ctx, "CREATE SCHEMA "+schema+"; SET search_path TO "+schema); err != nil {
+		t.Fatalf("couldn't create the test schema: %v", err)
+	}
+
+	migrations, err := filepath.Glob(filepath.Join("..", "..", "..", "migrations", "postgres", "*.up.sql"))
+	if err != nil || len(migrations) == 0 {
+		t.Fatalf("couldn't find the migrations: %v", err)
+	}
+
+	for _, path := range migrations {
+		migration, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("couldn't read the migration: %v", err)
+		}
+
+		// The query without the arguments is sent with the simple protocol, so it may contain several statements.
+		if _, err := conn.Exec(ctx, string(migration)); err != nil {
+			t.Fatalf("couldn't apply the migration '%s': %v", filepath.Base(path), err)
+		}
+	}
+
+	repository, err := New(slog.New(slog.NewTextHandler(io.Discard, nil)), withTestSearchPath(dsn, schema))
+	if err != nil {
+		t.Fatalf("couldn't create the test repository: %v", err)
+	}
+	t.Cleanup(repository.Close)
+
+	return repository
+}
+
+// withTestSearchPath sets the search path of the DSN's connections to the schema.
+func withTestSearchPath(dsn string, schema string) string {
+	if dsnURL, err := url.Parse(dsn); err == nil && (dsnURL.Scheme == "postgres" || dsnURL.Scheme == "postgresql") {
+		query := dsnURL.Query()
+		query.Set("search_path", schema)
+		dsnURL.RawQuery = query.Encode()
+
+		return dsnURL.String()
+	}
+
+	return dsn + " search_path=" + schema
+}
+
+func TestStatsQueries(t *testing.T) {
+	repository := makeTestRepo(t)
+	ctx := context.Background()
+
+	start := time.Date(2025, time.January, 1, 10, 0, 0, 0, time.UTC)
+	at := func(offset time.Duration) *time.Time {
+		moment := start.Add(offset)
+		return &moment
+	}
+
+	for name, ids := range map[string][]entities.UserID{
+		"backend":  {"alice", "bob", "carol"},
+		"frontend": {"dave"},
+	} {
+		team := entities.Team{Name: name}
+		for _, id := range ids {
+			team.Members = append(team.Members, entities.User{ID: id, Name: string(id), IsActive: true})
+		}
+
+		if err := repository.CreateTeam(ctx, team); err != nil {
+			t.Fatalf("couldn't create the test team: %v", err)
+		}
+	}
+
+	for _, pullReq := range []dto.PullRequestDTO{
+		{
+			ID:        "pr-1",
+			Status:    entities.Merged,
+			CreatedAt: at(0),
+			MergedAt:  at(time.Hour),
+			AuthorID:  "alice",
+			Reviewers: []entities.UserID{"bob", "carol"},
+		},
+		{ID: "pr-2", Status: entities.Open, CreatedAt: at(2 * time.Hour), AuthorID: "alice", Reviewers: []entities.UserID{"bob"}},
+		{ID: "pr-3", Status: entities.Open, CreatedAt: at(3 * time.Hour), AuthorID: "dave"},
+	} {
+		pullReq.Name = string(pullReq.ID)
+
+		if err := repository.CreatePullRequest(ctx, pullReq); err != nil {
+			t.Fatalf("couldn't create the test PR: %v", err)
+		}
+	}
+
+	err := repository.AddAssignmentsHistory(ctx, []dto.ReviewerAssignmentDTO{
+		{ID: "pr-2", NewReviewerID: "carol", Reason: entities.AssignmentCreated, ChangedAt: *at(2 * time.Hour)},
+		{
+			ID:            "pr-2",
+			OldReviewerID: "carol",
+			NewReviewerID: "bob",
+			Reason:        entities.AssignmentReassigned,
+			ChangedAt:     *at(2*time.Hour + time.Minute),
+		},
+	})
+	if err != nil {
+		t.Fatalf("couldn't add the test history: %v", err)
+	}
+
+	hour := time.Hour.Seconds()
+	from, to := at(90*time.Minute), at(3*time.Hour)
+
+	tests := []struct {
+		name   string
+		filter dto.StatsFilterDTO
+		users  []dto.UserStatsDTO
+		teams  []dto.TeamStatsDTO
+	}{
+		{
+			name: "all",
+			users: []dto.UserStatsDTO{
+				{ID: "alice", TeamName: "backend"},
+				{ID: "bob", TeamName: "backend", ReviewStatsDTO: dto.ReviewStatsDTO{
+					Assigned: 2, Open: 1, Merged: 1, MeanMergeTime: &hour,
+				}},
+				{ID: "carol", TeamName: "backend", ReviewStatsDTO: dto.ReviewStatsDTO{
+					Assigned: 1, Merged: 1, Reassignments: 1, MeanMergeTime: &hour,
+				}},
+				{ID: "dave", TeamName: "frontend"},
+			},
+			teams: []dto.TeamStatsDTO{
+				{TeamName: "backend", ReviewStatsDTO: dto.ReviewStatsDTO{
+					Assigned: 2, Open: 1, Merged: 1, Reassignments: 1, MeanMergeTime: &hour,
+				}},
+				{TeamName: "frontend", ReviewStatsDTO: dto.ReviewStatsDTO{Assigned: 1, Open: 1}},
+			},
+		},
+		{
+			name:   "window",
+			filter: dto.StatsFilterDTO{From: from, To: to},
+			users: []dto.UserStatsDTO{
+				{ID: "alice", TeamName: "backend"},
+				{ID: "bob", TeamName: "backend", ReviewStatsDTO: dto.ReviewStatsDTO{Assigned: 1, Open: 1}},
+				{ID: "carol", TeamName: "backend", ReviewStatsDTO: dto.ReviewStatsDTO{Reassignments: 1}},
+				{ID: "dave", TeamName: "frontend"},
+			},
+			teams: []dto.TeamStatsDTO{
+				{TeamName: "backend", ReviewStatsDTO: dto.ReviewStatsDTO{Assigned: 1, Open: 1, Reassignments: 1}},
+				{TeamName: "frontend"},
+			},
+		},
+		{
+			name:   "team and user",
+			filter: dto.StatsFilterDTO{TeamName: "backend", UserID: "carol"},
+			users: []dto.UserStatsDTO{
+				{ID: "carol", TeamName: "backend", ReviewStatsDTO: dto.ReviewStatsDTO{
+					Assigned: 1, Merged: 1, Reassignments: 1, MeanMergeTime: &hour,
+				}},
+			},
+			teams: []dto.TeamStatsDTO{
+				{TeamName: "backend", ReviewStatsDTO: dto.ReviewStatsDTO{
+					Assigned: 2, Open: 1, Merged: 1, Reassignments: 1, MeanMergeTime: &hour,
+				}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			users, err := repository.GetUsersStats(ctx, test.filter)
+			if err != nil {
+				t.Fatalf("couldn't get the users' stats: %v", err)
+			}
+
+			if !reflect.DeepEqual(users, test.users) {
+				t.Fatalf("expected the users' stats %+v, got %+v", test.users, users)
+			}
+
+			teams, err := repository.GetTeamsStats(ctx, test.filter)
+			if err != nil {
+				t.Fatalf("couldn't get the teams' stats: %v", err)
+			}
+
+			if !reflect.DeepEqual(teams, test.teams) {
+				t.Fatalf("expected the teams' stats %+v, got %+v", test.teams, teams)
+			}
+		})
+	}
+}
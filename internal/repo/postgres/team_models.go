@@ -1,13 +1,13 @@
 package postgres
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
 
 	"github.com/MaKcm14/pr-service/internal/entities"
 	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/MaKcm14/pr-service/internal/services"
 )
 
 // teamsRepo defines the logic of interaction with the teams models.
@@ -19,7 +19,7 @@ type teamsRepo struct {
 func (p *PostgreSQLRepo) GetTeam(ctx context.Context, name string) (entities.Team, error) {
 	const op = "postgres.get-team"
 
-	_, err := p.teamsRepo.isTeamExists(ctx, name)
+	team, err := p.teamsRepo.isTeamExists(ctx, name)
 	if err != nil {
 		retErr := fmt.Errorf("error of the %s: %w", op, err)
 
@@ -31,9 +31,6 @@ func (p *PostgreSQLRepo) GetTeam(ctx context.Context, name string) (entities.Tea
 		return entities.Team{}, retErr
 	}
 
-	team := entities.NewTeam()
-	team.Name = name
-
 	members, err := p.teamsRepo.getTeamMembers(ctx, name)
 	if err != nil {
 		retErr := fmt.Errorf("error of the %s: %w", op, err)
@@ -46,15 +43,40 @@ func (p *PostgreSQLRepo) GetTeam(ctx context.Context, name string) (entities.Tea
 }
 
 // CreateTeam defines the logic of creating a new team.
+// If the team already exists its members are created and/or updated and
+// the repo.ErrModelAlreadyExists is returned after the changes are committed.
 func (p *PostgreSQLRepo) CreateTeam(ctx context.Context, team entities.Team) error {
+	var existsErr error
+
+	err := p.WithinTx(ctx, services.ReadCommitted, func(ctx context.Context) error {
+		existsErr = nil
+
+		if err := p.createTeam(ctx, team); errors.Is(err, repo.ErrModelAlreadyExists) {
+			existsErr = err
+		} else if err != nil {
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return existsErr
+}
+
+func (p *PostgreSQLRepo) createTeam(ctx context.Context, team entities.Team) error {
 	const op = "postgres.create-team"
 
-	team, err := p.teamsRepo.isTeamExists(ctx, team.Name)
+	existing, err := p.teamsRepo.isTeamExists(ctx, team.Name)
 	if err != nil && !errors.Is(err, repo.ErrModelNotFound) {
 		retErr := fmt.Errorf("error of the %s: %w", op, err)
 		p.conf.log.Warn(retErr.Error())
 		return retErr
 	} else if err == nil {
+		team.ID = existing.ID
+
 		if err := p.teamsRepo.updateTeam(ctx, team); err != nil {
 			retErr := fmt.Errorf("error of the %s: %w", op, err)
 			p.conf.log.Warn(retErr.Error())
@@ -75,7 +97,7 @@ func (t teamsRepo) updateTeam(ctx context.Context, team entities.Team) error {
 
 	members, err := t.getTeamMembers(ctx, team.Name)
 	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrQueryExec, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
 		t.conf.log.Warn(retErr.Error())
 		return retErr
 	}
@@ -109,15 +131,204 @@ func (t teamsRepo) updateTeam(ctx context.Context, team entities.Team) error {
 		return retErr
 	}
 
+	if len(team.ReviewerStrategy) != 0 {
+		if _, err := t.setReviewerStrategy(ctx, team.Name, team.ReviewerStrategy); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w", op, err)
+			return retErr
+		}
+	}
+
+	if !team.ReviewerPolicy.IsZero() {
+		if _, err := t.setReviewerPolicy(ctx, team.Name, team.ReviewerPolicy); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w", op, err)
+			return retErr
+		}
+	}
+
+	if !team.MergePolicy.IsZero() {
+		if _, err := t.setMergePolicy(ctx, team.Name, team.MergePolicy); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w", op, err)
+			return retErr
+		}
+	}
+
 	return nil
 }
 
+// SetTeamReviewerStrategy defines the logic of changing the team's strategy of choosing the reviewers.
+func (p *PostgreSQLRepo) SetTeamReviewerStrategy(
+	ctx context.Context,
+	name string,
+	strategy entities.ReviewerStrategy,
+) (entities.Team, error) {
+	const op = "postgres.set-team-reviewer-strategy"
+
+	var team entities.Team
+
+	err := p.WithinTx(ctx, services.ReadCommitted, func(ctx context.Context) error {
+		found, err := p.teamsRepo.setReviewerStrategy(ctx, name, strategy)
+		if err != nil {
+			retErr := fmt.Errorf("error of the %s: %w", op, err)
+			p.conf.log.Warn(retErr.Error())
+			return retErr
+		} else if !found {
+			return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+		}
+
+		team, err = p.GetTeam(ctx, name)
+		return err
+	})
+
+	if err != nil {
+		return entities.Team{}, err
+	}
+
+	return team, nil
+}
+
+// SetTeamReviewerPolicy defines the logic of changing the team's bounds of the PR's reviewers count.
+func (p *PostgreSQLRepo) SetTeamReviewerPolicy(
+	ctx context.Context,
+	name string,
+	policy entities.ReviewerPolicy,
+) (entities.Team, error) {
+	const op = "postgres.set-team-reviewer-policy"
+
+	var team entities.Team
+
+	err := p.WithinTx(ctx, services.ReadCommitted, func(ctx context.Context) error {
+		found, err := p.teamsRepo.setReviewerPolicy(ctx, name, policy)
+		if err != nil {
+			retErr := fmt.Errorf("error of the %s: %w", op, err)
+			p.conf.log.Warn(retErr.Error())
+			return retErr
+		} else if !found {
+			return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+		}
+
+		team, err = p.GetTeam(ctx, name)
+		return err
+	})
+
+	if err != nil {
+		return entities.Team{}, err
+	}
+
+	return team, nil
+}
+
+// SetTeamMergePolicy defines the logic of changing the team's conditions of the PR's merge.
+func (p *PostgreSQLRepo) SetTeamMergePolicy(
+	ctx context.Context,
+	name string,
+	policy entities.MergePolicy,
+) (entities.Team, error) {
+	const op = "postgres.set-team-merge-policy"
+
+	var team entities.Team
+
+	err := p.WithinTx(ctx, services.ReadCommitted, func(ctx context.Context) error {
+		found, err := p.teamsRepo.setMergePolicy(ctx, name, policy)
+		if err != nil {
+			retErr := fmt.Errorf("error of the %s: %w", op, err)
+			p.conf.log.Warn(retErr.Error())
+			return retErr
+		} else if !found {
+			return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+		}
+
+		team, err = p.GetTeam(ctx, name)
+		return err
+	})
+
+	if err != nil {
+		return entities.Team{}, err
+	}
+
+	return team, nil
+}
+
+const updateTeamStrategy = `
+	UPDATE teams
+	SET reviewer_strategy=$1
+	WHERE team_name=$2
+`
+
+// setReviewerStrategy defines the logic of updating the team's strategy and reports whether the team exists.
+func (t teamsRepo) setReviewerStrategy(
+	ctx context.Context,
+	name string,
+	strategy entities.ReviewerStrategy,
+) (bool, error) {
+	const op = "postgres.set-reviewer-strategy"
+
+	tag, err := t.conf.db(ctx).Exec(ctx, updateTeamStrategy, strategy, name)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		t.conf.log.Warn(retErr.Error())
+		return false, retErr
+	}
+
+	return tag.RowsAffected() != 0, nil
+}
+
+const updateTeamPolicy = `
+	UPDATE teams
+	SET min_reviewers=$1, max_reviewers=$2
+	WHERE team_name=$3
+`
+
+// setReviewerPolicy defines the logic of updating the team's policy and reports whether the team exists.
+func (t teamsRepo) setReviewerPolicy(
+	ctx context.Context,
+	name string,
+	policy entities.ReviewerPolicy,
+) (bool, error) {
+	const op = "postgres.set-reviewer-policy"
+
+	tag, err := t.conf.db(ctx).Exec(ctx, updateTeamPolicy, policy.MinReviewers, policy.MaxReviewers, name)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		t.conf.log.Warn(retErr.Error())
+		return false, retErr
+	}
+
+	return tag.RowsAffected() != 0, nil
+}
+
+const updateTeamMergePolicy = `
+	UPDATE teams
+	SET required_approvals=$1, require_resolved_changes=$2, allow_author_approval=$3
+	WHERE team_name=$4
+`
+
+// setMergePolicy defines the logic of updating the team's merge policy and reports whether the team exists.
+func (t teamsRepo) setMergePolicy(
+	ctx context.Context,
+	name string,
+	policy entities.MergePolicy,
+) (bool, error) {
+	const op = "postgres.set-merge-policy"
+
+	tag, err := t.conf.db(ctx).Exec(ctx, updateTeamMergePolicy,
+		policy.RequiredApprovals, policy.RequireResolvedChanges, policy.AllowAuthorApproval, name)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		t.conf.log.Warn(retErr.Error())
+		return false, retErr
+	}
+
+	return tag.RowsAffected() != 0, nil
+}
+
 const updateMembers = `
 	UPDATE users
-	SET username=$1, is_active=$2, team_id=$3
-	WHERE id = $4
+	SET username=$1, is_active=$2, team_id=$3, user_role=COALESCE(NULLIF($4, ''), user_role)
+	WHERE id = $5
 `
 
+// updateMembers defines the logic of updating the existing members of the current team.
+// The member without the role keeps the current one.
 func (t teamsRepo) updateMembers(
 	ctx context.Context,
 	members []entities.User,
@@ -126,11 +337,10 @@ func (t teamsRepo) updateMembers(
 	const op = "postgres.update-members"
 
 	for _, user := range members {
-		_, err := t.conf.conn.Exec(ctx, updateMembers, user.Name,
-			getSqlViewBool(user.IsActive), teamID, user.ID)
+		_, err := t.conf.db(ctx).Exec(ctx, updateMembers, user.Name, user.IsActive, teamID, string(user.Role), user.ID)
 
 		if err != nil {
-			retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrQueryExec, err)
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
 			t.conf.log.Warn(retErr.Error())
 			return retErr
 		}
@@ -140,8 +350,11 @@ func (t teamsRepo) updateMembers(
 }
 
 const insertTeam = `
-	INSERT INTO teams (team_name)
-	VALUES ($1)
+	INSERT INTO teams (
+		team_name, reviewer_strategy, min_reviewers, max_reviewers,
+		required_approvals, require_resolved_changes, allow_author_approval
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
 	RETURNING id
 `
 
@@ -149,22 +362,19 @@ const insertTeam = `
 func (t teamsRepo) createTeam(ctx context.Context, team entities.Team) error {
 	const op = "postgres.create-team"
 
-	rows, err := t.conf.conn.Query(ctx, insertTeam, team.Name)
+	if len(team.ReviewerStrategy) == 0 {
+		team.ReviewerStrategy = entities.RandomStrategy
+	}
+	policy := team.GetReviewerPolicy()
 
+	err := t.conf.db(ctx).QueryRow(ctx, insertTeam, team.Name, team.ReviewerStrategy,
+		policy.MinReviewers, policy.MaxReviewers, team.MergePolicy.RequiredApprovals,
+		team.MergePolicy.RequireResolvedChanges, team.MergePolicy.AllowAuthorApproval).Scan(&team.ID)
 	if err != nil {
 		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
 		t.conf.log.Warn(retErr.Error())
 		return retErr
 	}
-	defer rows.Close()
-
-	if rows.Next() {
-		rows.Scan(&team.ID)
-	} else if rows.Err() != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
-		t.conf.log.Warn(retErr.Error())
-		return retErr
-	}
 
 	if err := t.addMembersList(ctx, team.Members, team); err != nil {
 		return err
@@ -173,34 +383,25 @@ func (t teamsRepo) createTeam(ctx context.Context, team entities.Team) error {
 }
 
 const insertMembers = `
-INSERT INTO users (id, username, is_active, team_id)
+INSERT INTO users (id, username, is_active, team_id, user_role)
 VALUES `
 
 // addMembersList defines the logic of adding the members list for the current team.
 func (t teamsRepo) addMembersList(ctx context.Context, list []entities.User, team entities.Team) error {
 	const op = "postgres.add-members-list"
 
-	if len(team.Members) == 0 {
+	if len(list) == 0 {
 		return nil
 	}
 
-	query := bytes.Buffer{}
-	query.WriteString(insertMembers)
-	for idx, user := range list {
-		query.WriteString(fmt.Sprintf("('%s', '%s', %s, %d)", string(user.ID), user.Name,
-			getSqlViewBool(user.IsActive), team.ID))
-
-		if idx != len(list)-1 {
-			query.WriteString(",\n")
-		}
+	args := make([]any, 0, len(list)*5)
+	for _, user := range list {
+		args = append(args, user.ID, user.Name, user.IsActive, team.ID, user.GetRole())
 	}
 
-	_, err := t.conf.conn.Exec(ctx, query.String())
-
-	fmt.Println(query.String())
-
+	_, err := t.conf.db(ctx).Exec(ctx, insertMembers+placeholders(len(list), 5), args...)
 	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrQueryExec, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
 		t.conf.log.Warn(retErr.Error())
 		return retErr
 	}
@@ -209,10 +410,10 @@ func (t teamsRepo) addMembersList(ctx context.Context, list []entities.User, tea
 }
 
 const selectMembers = `
-	SELECT 
-		users.id, users.username, users.is_active
-	FROM users 
-		JOIN teams 
+	SELECT
+		users.id, users.username, users.is_active, users.user_role
+	FROM users
+		JOIN teams
 		ON users.team_id=teams.id
 	WHERE teams.team_name=$1
 `
@@ -221,7 +422,7 @@ const selectMembers = `
 func (t teamsRepo) getTeamMembers(ctx context.Context, name string) ([]entities.User, error) {
 	const op = "postgres.get-team-members"
 
-	rows, err := t.conf.conn.Query(ctx, selectMembers, name)
+	rows, err := t.conf.db(ctx).Query(ctx, selectMembers, name)
 
 	if err != nil {
 		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
@@ -233,12 +434,16 @@ func (t teamsRepo) getTeamMembers(ctx context.Context, name string) ([]entities.
 	res := make([]entities.User, 0, 250)
 	for rows.Next() {
 		user := entities.User{}
-		rows.Scan(&user.ID, &user.Name, &user.IsActive)
+		if err := rows.Scan(&user.ID, &user.Name, &user.IsActive, &user.Role); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+			t.conf.log.Warn(retErr.Error())
+			return nil, retErr
+		}
 		res = append(res, user)
 	}
 
 	if rows.Err() != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, rows.Err())
 		t.conf.log.Warn(retErr.Error())
 		return nil, retErr
 	}
@@ -246,7 +451,9 @@ func (t teamsRepo) getTeamMembers(ctx context.Context, name string) ([]entities.
 }
 
 const selectTeam = `
-	SELECT id, team_name
+	SELECT
+		id, team_name, reviewer_strategy, min_reviewers, max_reviewers,
+		required_approvals, require_resolved_changes, allow_author_approval
 	FROM teams
 	WHERE team_name=$1
 `
@@ -256,7 +463,7 @@ func (t teamsRepo) isTeamExists(ctx context.Context, name string) (entities.Team
 	const op = "postgres.is-team-exists"
 
 	res := entities.Team{}
-	rows, err := t.conf.conn.Query(ctx, selectTeam, name)
+	rows, err := t.conf.db(ctx).Query(ctx, selectTeam, name)
 	if err != nil {
 		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
 		t.conf.log.Warn(retErr.Error())
@@ -264,17 +471,20 @@ func (t teamsRepo) isTeamExists(ctx context.Context, name string) (entities.Team
 	}
 	defer rows.Close()
 
-	if rows.CommandTag().RowsAffected() != 0 {
-		if rows.Next() {
-			rows.Scan(&res.ID, &res.Name)
-		}
-
-		if rows.Err() != nil {
+	if rows.Next() {
+		err := rows.Scan(&res.ID, &res.Name, &res.ReviewerStrategy,
+			&res.ReviewerPolicy.MinReviewers, &res.ReviewerPolicy.MaxReviewers, &res.MergePolicy.RequiredApprovals,
+			&res.MergePolicy.RequireResolvedChanges, &res.MergePolicy.AllowAuthorApproval)
+		if err != nil {
 			retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
 			t.conf.log.Warn(retErr.Error())
 			return entities.Team{}, retErr
 		}
 		return res, nil
+	} else if rows.Err() != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, rows.Err())
+		t.conf.log.Warn(retErr.Error())
+		return entities.Team{}, retErr
 	}
 
 	return entities.Team{}, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
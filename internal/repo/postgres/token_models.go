@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/jackc/pgx/v5"
+)
+
+const insertAPIToken = `
+	INSERT INTO api_tokens (token_name, token_hash, scopes, user_id, created_at)
+	SELECT $1, $2, $3, NULLIF($4, ''), $5
+	WHERE $4='' OR EXISTS (SELECT 1 FROM users WHERE id=$4)
+	RETURNING id
+`
+
+// AddAPIToken defines the logic of storing the token by its secret's hash.
+// The token's user must exist.
+func (p *PostgreSQLRepo) AddAPIToken(ctx context.Context, token entities.APIToken, hash string) (entities.APIToken, error) {
+	const op = "postgres.add-api-token"
+
+	scopes := make([]string, 0, len(token.Scopes))
+	for _, scope := range token.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	err := p.conf.db(ctx).QueryRow(ctx, insertAPIToken,
+		token.Name, hash, scopes, token.UserID, token.CreatedAt).Scan(&token.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.APIToken{}, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+		}
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return entities.APIToken{}, retErr
+	}
+
+	return token, nil
+}
+
+const updateAPITokenRevoked = `
+	UPDATE api_tokens
+	SET revoked_at=COALESCE(revoked_at, $2)
+	WHERE id=$1
+`
+
+// RevokeAPIToken defines the logic of marking the token revoked.
+// The token's revocation time isn't changed if it's already revoked.
+func (p *PostgreSQLRepo) RevokeAPIToken(ctx context.Context, id entities.TokenID, revokedAt time.Time) error {
+	const op = "postgres.revoke-api-token"
+
+	tag, err := p.conf.db(ctx).Exec(ctx, updateAPITokenRevoked, id, revokedAt)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	return nil
+}
+
+const selectAPITokens = `
+	SELECT id, token_name, scopes, COALESCE(user_id, ''), created_at, revoked_at
+	FROM api_tokens
+	ORDER BY id
+`
+
+// GetAPITokens defines the logic of getting the tokens in the order of their creation.
+func (p *PostgreSQLRepo) GetAPITokens(ctx context.Context) ([]entities.APIToken, error) {
+	const op = "postgres.get-api-tokens"
+
+	rows, err := p.conf.db(ctx).Query(ctx, selectAPITokens)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	res, err := pgx.CollectRows(rows, scanAPIToken)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	return res, nil
+}
+
+const selectAPITokenByHash = `
+	SELECT id, token_name, scopes, COALESCE(user_id, ''), created_at, revoked_at
+	FROM api_tokens
+	WHERE token_hash=$1
+`
+
+// GetAPITokenByHash defines the logic of getting the token by its secret's hash.
+func (p *PostgreSQLRepo) GetAPITokenByHash(ctx context.Context, hash string) (entities.APIToken, error) {
+	const op = "postgres.get-api-token-by-hash"
+
+	rows, err := p.conf.db(ctx).Query(ctx, selectAPITokenByHash, hash)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return entities.APIToken{}, retErr
+	}
+
+	res, err := pgx.CollectExactlyOneRow(rows, scanAPIToken)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.APIToken{}, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+		}
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+		p.conf.log.Warn(retErr.Error())
+		return entities.APIToken{}, retErr
+	}
+
+	return res, nil
+}
+
+func scanAPIToken(row pgx.CollectableRow) (entities.APIToken, error) {
+	var (
+		token  entities.APIToken
+		scopes []string
+	)
+
+	if err := row.Scan(&token.ID, &token.Name, &scopes, &token.UserID, &token.CreatedAt, &token.RevokedAt); err != nil {
+		return entities.APIToken{}, err
+	}
+
+	token.Scopes = make([]entities.Scope, 0, len(scopes))
+	for _, scope := range scopes {
+		token.Scopes = append(token.Scopes, entities.Scope(scope))
+	}
+
+	return token, nil
+}
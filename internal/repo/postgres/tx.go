@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/MaKcm14/pr-service/internal/services"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	// txAttempts defines the max count of the transaction's runs in case of the serialization failures.
+	txAttempts = 3
+
+	serializationFailureCode = "40001"
+	deadlockDetectedCode     = "40P01"
+)
+
+// txKey defines the ctx's key of the started transaction.
+type txKey struct{}
+
+// WithinTx defines the logic of running the fn inside the single transaction with the current isolation level.
+// The transaction is rolled back if the fn returns the error and is retried on the serialization failures.
+func (p *PostgreSQLRepo) WithinTx(
+	ctx context.Context,
+	level services.IsolationLevel,
+	fn func(ctx context.Context) error,
+) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	return p.retryTx(func() error {
+		return p.runTx(ctx, level, fn)
+	})
+}
+
+// retryTx defines the logic of running the transaction again while it fails due to the concurrent transactions.
+// The transaction is run at most txAttempts times.
+func (p *PostgreSQLRepo) retryTx(run func() error) error {
+	var err error
+	for attempt := 0; attempt != txAttempts; attempt++ {
+		if err = run(); err == nil || !isRetryable(err) {
+			return err
+		}
+		p.conf.log.Info(fmt.Sprintf("retrying the transaction after the serialization failure: %s", err))
+	}
+
+	return err
+}
+
+func (p *PostgreSQLRepo) runTx(
+	ctx context.Context,
+	level services.IsolationLevel,
+	fn func(ctx context.Context) error,
+) error {
+	const op = "postgres.run-tx"
+
+	tx, err := p.conf.conn.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel: getTxIsoLevel(level),
+	})
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrStartTransaction, err)
+		p.conf.log.Warn(retErr.Error())
+		return retErr
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrCommitTransaction, err)
+		p.conf.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	return nil
+}
+
+// isRetryable checks whether the transaction failed due to the concurrent transactions and can be retried.
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == serializationFailureCode || pgErr.Code == deadlockDetectedCode
+}
+
+func getTxIsoLevel(level services.IsolationLevel) pgx.TxIsoLevel {
+	switch level {
+	case services.Serializable:
+		return pgx.Serializable
+
+	case services.RepeatableRead:
+		return pgx.RepeatableRead
+	}
+	return pgx.ReadCommitted
+}
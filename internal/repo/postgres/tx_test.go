@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/MaKcm14/pr-service/internal/services"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestRetryTx(t *testing.T) {
+	repository := &PostgreSQLRepo{conf: &postgresConfig{log: slog.New(slog.NewTextHandler(io.Discard, nil))}}
+
+	serializationErr := fmt.Errorf("error of the fn: %w", &pgconn.PgError{Code: serializationFailureCode})
+	deadlockErr := &pgconn.PgError{Code: deadlockDetectedCode}
+	uniqueErr := &pgconn.PgError{Code: "23505"}
+
+	tests := []struct {
+		name     string
+		errs     []error
+		runs     int
+		expected error
+	}{
+		{name: "committed", errs: []error{nil}, runs: 1},
+		{name: "committed after the failures", errs: []error{serializationErr, deadlockErr, nil}, runs: 3},
+		{name: "failed with the other error", errs: []error{serializationErr, uniqueErr}, runs: 2, expected: uniqueErr},
+		{name: "failed after the attempts", errs: []error{serializationErr, serializationErr, serializationErr, nil},
+			runs: txAttempts, expected: serializationErr},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runs := 0
+			err := repository.retryTx(func() error {
+				runs++
+				return test.errs[runs-1]
+			})
+
+			if !errors.Is(err, test.expected) || runs != test.runs {
+				t.Fatalf("expected %v after %d runs, got %v after %d runs", test.expected, test.runs, err, runs)
+			}
+		})
+	}
+}
+
+func TestGetTxIsoLevel(t *testing.T) {
+	levels := map[services.IsolationLevel]pgx.TxIsoLevel{
+		services.Serializable:   pgx.Serializable,
+		services.RepeatableRead: pgx.RepeatableRead,
+		services.ReadCommitted:  pgx.ReadCommitted,
+	}
+
+	for level, expected := range levels {
+		if res := getTxIsoLevel(level); res != expected {
+			t.Fatalf("expected the %s level for %v, got %s", expected, level, res)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/MaKcm14/pr-service/internal/entities"
 	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/jackc/pgx/v5"
 )
 
 // usersRepo defines the logic of interaction with the users models
@@ -22,7 +23,7 @@ const updateUser = `
 func (p *PostgreSQLRepo) SetUserIsActive(ctx context.Context, isActive bool, id entities.UserID) (entities.User, error) {
 	const op = "postgres.set-user-is-active"
 
-	tag, err := p.conf.conn.Exec(ctx, updateUser, isActive, id)
+	tag, err := p.conf.db(ctx).Exec(ctx, updateUser, isActive, id)
 	if err != nil {
 		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
 		p.conf.log.Warn(retErr.Error())
@@ -42,7 +43,7 @@ func (p *PostgreSQLRepo) SetUserIsActive(ctx context.Context, isActive bool, id
 }
 
 const selectUserTeamName = `
-	SELECT users.id, users.username, users.is_active, teams.team_name
+	SELECT users.id, users.username, users.is_active, teams.team_name, users.user_role
 	FROM users
 	JOIN teams ON users.team_id=teams.id
 	WHERE users.id=$1
@@ -51,9 +52,9 @@ const selectUserTeamName = `
 func (p *PostgreSQLRepo) GetUser(ctx context.Context, id entities.UserID) (entities.User, error) {
 	const op = "postgres.get-user-team-name"
 
-	rows, err := p.conf.conn.Query(ctx, selectUserTeamName, id)
+	rows, err := p.conf.db(ctx).Query(ctx, selectUserTeamName, id)
 	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrQueryExec, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
 		p.conf.log.Warn(retErr.Error())
 		return entities.User{}, retErr
 	}
@@ -61,13 +62,81 @@ func (p *PostgreSQLRepo) GetUser(ctx context.Context, id entities.UserID) (entit
 
 	user := entities.User{}
 	if rows.Next() {
-		rows.Scan(&user.ID, &user.Name, &user.IsActive, &user.TeamName)
+		if err := rows.Scan(&user.ID, &user.Name, &user.IsActive, &user.TeamName, &user.Role); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+			p.conf.log.Warn(retErr.Error())
+			return entities.User{}, retErr
+		}
 		return user, nil
 	} else if rows.Err() != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, repo.ErrResProcessing, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, rows.Err())
 		p.conf.log.Warn(retErr.Error())
 		return entities.User{}, retErr
 	}
 
 	return entities.User{}, repo.ErrModelNotFound
 }
+
+const selectUsersTeamName = `
+	SELECT users.id, users.username, users.is_active, teams.team_name, users.user_role
+	FROM users
+	JOIN teams ON users.team_id=teams.id
+	WHERE users.id=ANY($1)
+`
+
+// GetUsers defines the logic of getting the existing users for the current ids.
+func (p *PostgreSQLRepo) GetUsers(ctx context.Context, ids []entities.UserID) ([]entities.User, error) {
+	const op = "postgres.get-users"
+
+	rows, err := p.conf.db(ctx).Query(ctx, selectUsersTeamName, ids)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	res, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entities.User, error) {
+		var user entities.User
+		err := row.Scan(&user.ID, &user.Name, &user.IsActive, &user.TeamName, &user.Role)
+		return user, err
+	})
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	return res, nil
+}
+
+const updateTeamUsers = `
+	UPDATE users
+	SET is_active=$1
+	FROM teams
+	WHERE users.team_id=teams.id AND teams.team_name=$2 AND users.id=ANY($3)
+`
+
+// SetTeamUsersIsActive defines the logic of changing the activity of the team's members.
+// The repo.ErrModelNotFound is returned if any user isn't the team's member.
+// The ids must be unique and it must be called inside the transaction.
+func (p *PostgreSQLRepo) SetTeamUsersIsActive(
+	ctx context.Context,
+	teamName string,
+	isActive bool,
+	ids []entities.UserID,
+) error {
+	const op = "postgres.set-team-users-is-active"
+
+	tag, err := p.conf.db(ctx).Exec(ctx, updateTeamUsers, isActive, teamName, ids)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	if tag.RowsAffected() != int64(len(ids)) {
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	return nil
+}
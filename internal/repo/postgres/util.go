@@ -7,9 +7,19 @@ import (
 	"time"
 
 	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
 )
 
+// executor defines the common interface of the pool and the transaction used for running the queries.
+type executor interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
 // postgresConfig defines the PostgreSQL repo configuration object.
 type postgresConfig struct {
 	log  *slog.Logger
@@ -19,8 +29,18 @@ type postgresConfig struct {
 func newPostgresConfig(log *slog.Logger, dsn string) (*postgresConfig, error) {
 	const op = "postgres.new-config"
 
-	ctx, _ := context.WithTimeout(context.Background(), time.Second*5)
-	conn, err := pgxpool.New(ctx, dsn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	poolConf, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrConnToRepository, err)
+		log.Error(retErr.Error())
+		return nil, retErr
+	}
+	poolConf.ConnConfig.Tracer = queryTracer{tracer: otel.Tracer("github.com/MaKcm14/pr-service/internal/repo/postgres")}
+
+	conn, err := pgxpool.NewWithConfig(ctx, poolConf)
 	if err != nil {
 		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrConnToRepository, err)
 		log.Error(retErr.Error())
@@ -39,11 +59,35 @@ func newPostgresConfig(log *slog.Logger, dsn string) (*postgresConfig, error) {
 	}, nil
 }
 
-func getSqlViewBool(val bool) string {
-	if val {
-		return "TRUE"
+// db returns the transaction started for the ctx or the connections' pool otherwise.
+func (p postgresConfig) db(ctx context.Context) executor {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
 	}
-	return "FALSE"
+	return p.conn
+}
+
+// placeholders returns the list of the query's placeholders for the rows with
+// the current count of the columns, e.g. ($1, $2), ($3, $4).
+func placeholders(rows, columns int) string {
+	buff := make([]byte, 0, rows*columns*5)
+
+	for row := 0; row != rows; row++ {
+		if row != 0 {
+			buff = append(buff, ",\n"...)
+		}
+		buff = append(buff, '(')
+
+		for col := 0; col != columns; col++ {
+			if col != 0 {
+				buff = append(buff, ", "...)
+			}
+			buff = append(buff, fmt.Sprintf("$%d", row*columns+col+1)...)
+		}
+		buff = append(buff, ')')
+	}
+
+	return string(buff)
 }
 
 func (p postgresConfig) close() {
@@ -0,0 +1,304 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/jackc/pgx/v5"
+)
+
+const insertTeamWebhook = `
+	INSERT INTO team_webhooks (team_id, url, secret)
+	SELECT id, $2, $3
+	FROM teams
+	WHERE team_name=$1
+	RETURNING id
+`
+
+// AddTeamWebhook defines the logic of registering the team's webhook.
+func (p *PostgreSQLRepo) AddTeamWebhook(ctx context.Context, webhook entities.Webhook) (entities.Webhook, error) {
+	const op = "postgres.add-team-webhook"
+
+	err := p.conf.db(ctx).QueryRow(ctx, insertTeamWebhook, webhook.TeamName, webhook.URL, webhook.Secret).Scan(&webhook.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.Webhook{}, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+		}
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return entities.Webhook{}, retErr
+	}
+
+	return webhook, nil
+}
+
+const deleteTeamWebhook = `
+	DELETE FROM team_webhooks AS tw
+	USING teams AS t
+	WHERE tw.team_id=t.id AND t.team_name=$1 AND tw.id=$2
+`
+
+// DeleteTeamWebhook defines the logic of removing the team's webhook with its deliveries.
+func (p *PostgreSQLRepo) DeleteTeamWebhook(ctx context.Context, teamName string, id entities.WebhookID) error {
+	const op = "postgres.delete-team-webhook"
+
+	tag, err := p.conf.db(ctx).Exec(ctx, deleteTeamWebhook, teamName, id)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	return nil
+}
+
+const selectTeamWebhooks = `
+	SELECT tw.id, tw.url, tw.secret
+	FROM teams AS t
+		LEFT JOIN team_webhooks AS tw
+		ON tw.team_id=t.id
+	WHERE t.team_name=$1
+	ORDER BY tw.id
+`
+
+// GetTeamWebhooks defines the logic of getting the team's webhooks in the order of their registration.
+func (p *PostgreSQLRepo) GetTeamWebhooks(ctx context.Context, teamName string) ([]entities.Webhook, error) {
+	const op = "postgres.get-team-webhooks"
+
+	rows, err := p.conf.db(ctx).Query(ctx, selectTeamWebhooks, teamName)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+	defer rows.Close()
+
+	var (
+		res   = make([]entities.Webhook, 0, 5)
+		found bool
+	)
+
+	for rows.Next() {
+		var (
+			id          *int64
+			url, secret *string
+		)
+
+		if err := rows.Scan(&id, &url, &secret); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+			p.conf.log.Warn(retErr.Error())
+			return nil, retErr
+		}
+		found = true
+
+		if id != nil {
+			res = append(res, entities.Webhook{
+				ID:       entities.WebhookID(*id),
+				TeamName: teamName,
+				URL:      *url,
+				Secret:   *secret,
+			})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrResProcessing, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	if !found {
+		return nil, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	return res, nil
+}
+
+const insertWebhookDeliveries = `
+	INSERT INTO webhook_deliveries (webhook_id, event_id, event, payload, status, next_attempt_at, created_at)
+	VALUES %s
+	ON CONFLICT (webhook_id, event_id) DO NOTHING
+`
+
+// AddWebhookDeliveries defines the logic of queueing the webhooks' deliveries.
+// The delivery of the event already queued for the webhook is skipped.
+func (p *PostgreSQLRepo) AddWebhookDeliveries(ctx context.Context, deliveries []entities.WebhookDelivery) error {
+	const (
+		op      = "postgres.add-webhook-deliveries"
+		columns = 7
+	)
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(deliveries)*columns)
+	for _, delivery := range deliveries {
+		args = append(args, delivery.WebhookID, delivery.EventID, delivery.Event, delivery.Payload,
+			delivery.Status, delivery.NextAttemptAt, delivery.CreatedAt)
+	}
+
+	query := fmt.Sprintf(insertWebhookDeliveries, placeholders(len(deliveries), columns))
+
+	if _, err := p.conf.db(ctx).Exec(ctx, query, args...); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	return nil
+}
+
+const claimWebhookDeliveries = `
+	UPDATE webhook_deliveries AS wd
+	SET next_attempt_at=$2
+	FROM team_webhooks AS tw, teams AS t
+	WHERE wd.id IN (
+		SELECT id
+		FROM webhook_deliveries
+		WHERE status='PENDING' AND next_attempt_at <= $1
+		ORDER BY next_attempt_at, id
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED
+	) AND tw.id=wd.webhook_id AND t.id=tw.team_id
+	RETURNING
+		wd.id, wd.webhook_id, COALESCE(wd.event_id, 0), t.team_name, tw.url, tw.secret,
+		wd.event, wd.payload, wd.status, wd.attempts, wd.next_attempt_at,
+		COALESCE(wd.last_status_code, 0), COALESCE(wd.last_error, ''), wd.created_at, wd.delivered_at
+`
+
+// ClaimWebhookDeliveries defines the logic of taking the pending deliveries due by the now.
+// The claimed deliveries aren't given to the other senders until the lockUntil.
+func (p *PostgreSQLRepo) ClaimWebhookDeliveries(
+	ctx context.Context,
+	now time.Time,
+	lockUntil time.Time,
+	limit int,
+) ([]entities.WebhookDelivery, error) {
+	const op = "postgres.claim-webhook-deliveries"
+
+	res, err := p.queryWebhookDeliveries(ctx, claimWebhookDeliveries, now, lockUntil, limit)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	return res, nil
+}
+
+const updateWebhookDelivery = `
+	UPDATE webhook_deliveries
+	SET status=$2, attempts=$3, next_attempt_at=$4,
+		last_status_code=NULLIF($5, 0), last_error=NULLIF($6, ''), delivered_at=$7
+	WHERE id=$1
+`
+
+// UpdateWebhookDelivery defines the logic of keeping the result of the delivery's attempt.
+func (p *PostgreSQLRepo) UpdateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) error {
+	const op = "postgres.update-webhook-delivery"
+
+	tag, err := p.conf.db(ctx).Exec(ctx, updateWebhookDelivery, delivery.ID, delivery.Status, delivery.Attempts,
+		delivery.NextAttemptAt, delivery.LastStatusCode, delivery.LastError, delivery.DeliveredAt)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+		p.conf.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+	}
+
+	return nil
+}
+
+const selectTeamID = `
+	SELECT id
+	FROM teams
+	WHERE team_name=$1
+`
+
+const selectWebhookDeliveries = `
+	SELECT
+		wd.id, wd.webhook_id, COALESCE(wd.event_id, 0), t.team_name, tw.url, tw.secret,
+		wd.event, wd.payload, wd.status, wd.attempts, wd.next_attempt_at,
+		COALESCE(wd.last_status_code, 0), COALESCE(wd.last_error, ''), wd.created_at, wd.delivered_at
+	FROM webhook_deliveries AS wd
+		JOIN team_webhooks AS tw
+		ON tw.id=wd.webhook_id
+		JOIN teams AS t
+		ON t.id=tw.team_id
+	WHERE ($1='' OR t.team_name=$1) AND ($2='' OR wd.status=$2)
+	ORDER BY wd.id DESC
+	LIMIT $3
+`
+
+// GetWebhookDeliveries defines the logic of getting the filtered deliveries from the newest ones.
+func (p *PostgreSQLRepo) GetWebhookDeliveries(
+	ctx context.Context,
+	filter dto.WebhookDeliveriesFilterDTO,
+) ([]entities.WebhookDelivery, error) {
+	const op = "postgres.get-webhook-deliveries"
+
+	if len(filter.TeamName) != 0 {
+		var id int64
+
+		if err := p.conf.db(ctx).QueryRow(ctx, selectTeamID, filter.TeamName).Scan(&id); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("error of the %s: %w", op, repo.ErrModelNotFound)
+			}
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, repo.ErrQueryExec, err)
+			p.conf.log.Warn(retErr.Error())
+			return nil, retErr
+		}
+	}
+
+	res, err := p.queryWebhookDeliveries(ctx, selectWebhookDeliveries, filter.TeamName, filter.Status, filter.Limit)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
+		p.conf.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	return res, nil
+}
+
+// queryWebhookDeliveries defines the logic of running the query returning the deliveries with their webhooks.
+func (p *PostgreSQLRepo) queryWebhookDeliveries(ctx context.Context, query string, args ...any) ([]entities.WebhookDelivery, error) {
+	rows, err := p.conf.db(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repo.ErrQueryExec, err)
+	}
+	defer rows.Close()
+
+	res := make([]entities.WebhookDelivery, 0, 50)
+
+	for rows.Next() {
+		var delivery entities.WebhookDelivery
+
+		err := rows.Scan(&delivery.ID, &delivery.WebhookID, &delivery.EventID, &delivery.TeamName, &delivery.URL,
+			&delivery.Secret, &delivery.Event, &delivery.Payload, &delivery.Status, &delivery.Attempts,
+			&delivery.NextAttemptAt, &delivery.LastStatusCode, &delivery.LastError, &delivery.CreatedAt,
+			&delivery.DeliveredAt)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", repo.ErrResProcessing, err)
+		}
+		res = append(res, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", repo.ErrResProcessing, err)
+	}
+
+	return res, nil
+}
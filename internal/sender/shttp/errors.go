@@ -0,0 +1,9 @@
+package shttp
+
+import "errors"
+
+var (
+	ErrRequestCreation  = errors.New("shttp: error of the request's creation")
+	ErrRequestSending   = errors.New("shttp: error of sending the request")
+	ErrUnexpectedStatus = errors.New("shttp: error of the response's unexpected status code")
+)
@@ -0,0 +1,66 @@
+package shttp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+)
+
+// EventIDHeader defines the event sink's request header keeping the domain event's id.
+const EventIDHeader = "X-PR-Service-Event-ID"
+
+// EventSink defines the logic of publishing the domain events to the single HTTP endpoint.
+type EventSink struct {
+	log    *slog.Logger
+	client *http.Client
+	url    string
+}
+
+func NewEventSink(log *slog.Logger, url string) EventSink {
+	return EventSink{
+		log:    log,
+		client: newClient(),
+		url:    url,
+	}
+}
+
+// PublishEvent defines the logic of posting the event to the sink's URL.
+// The event is published only if the endpoint responded with the 2xx status code.
+func (e EventSink) PublishEvent(ctx context.Context, event entities.DomainEvent) error {
+	const op = "shttp.publish-event"
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error of the %s: %w: %w", op, ErrRequestCreation, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("error of the %s: %w: %w", op, ErrRequestCreation, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(event.Type))
+	req.Header.Set(EventIDHeader, strconv.FormatInt(int64(event.ID), 10))
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error of the %s: %w: %w", op, ErrRequestSending, err)
+	}
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("error of the %s: %w: %d", op, ErrUnexpectedStatus, resp.StatusCode)
+	}
+
+	return nil
+}
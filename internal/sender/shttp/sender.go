@@ -0,0 +1,103 @@
+package shttp
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+)
+
+const (
+	// EventHeader defines the webhook request's header keeping the event's type.
+	EventHeader = "X-PR-Service-Event"
+
+	// DeliveryHeader defines the webhook request's header keeping the delivery's id.
+	// The id is the same for every attempt of the delivery, so it can be used for the deduplication.
+	DeliveryHeader = "X-PR-Service-Delivery"
+
+	// TimestampHeader defines the webhook request's header keeping the Unix time the request was signed at.
+	// The receiver should reject the stale requests, so the captured delivery can't be replayed.
+	TimestampHeader = "X-PR-Service-Timestamp"
+
+	// SignatureHeader defines the webhook request's header keeping the HMAC-SHA256 signature
+	// of the timestamp and the payload made with the webhook's secret.
+	SignatureHeader = "X-PR-Service-Signature-256"
+
+	signaturePrefix = "sha256="
+
+	// clientTimeout defines the max duration of the single request including the response's reading.
+	clientTimeout = 10 * time.Second
+
+	// maxResponseBody defines the max size of the response's body read before closing the connection.
+	maxResponseBody = 64 << 10
+)
+
+// HttpSender defines the logic of sending the signed events to the teams' webhooks over HTTP.
+type HttpSender struct {
+	log    *slog.Logger
+	client *http.Client
+}
+
+func New(log *slog.Logger) HttpSender {
+	return HttpSender{
+		log:    log,
+		client: newClient(),
+	}
+}
+
+// newClient returns the HTTP client that doesn't follow the redirects, so the signed payload
+// is never sent to the host other than the registered one.
+func newClient() *http.Client {
+	return &http.Client{
+		Timeout: clientTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// SendWebhook defines the logic of posting the delivery's payload to the webhook's URL.
+// The response's status code is returned if the webhook responded, the redirects aren't followed.
+func (h HttpSender) SendWebhook(ctx context.Context, delivery entities.WebhookDelivery) (int, error) {
+	const op = "shttp.send-webhook"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("error of the %s: %w: %w", op, ErrRequestCreation, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(delivery.Event))
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(int64(delivery.ID), 10))
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error of the %s: %w: %w", op, ErrRequestSending, err)
+	}
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature of the "<timestamp>.<payload>" made with the secret in the SignatureHeader's format.
+func Sign(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,84 @@
+package shttp
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+)
+
+func TestSendWebhookSignsTimestamp(t *testing.T) {
+	const secret = "webhook-secret"
+	payload := []byte(`{"event":"pull_request.merged"}`)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		timestamp := req.Header.Get(TimestampHeader)
+
+		sec, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil || time.Since(time.Unix(sec, 0)) > time.Minute {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if req.Header.Get(SignatureHeader) != Sign(secret, timestamp, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	sender := New(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	code, err := sender.SendWebhook(context.Background(), entities.WebhookDelivery{
+		ID:      1,
+		URL:     receiver.URL,
+		Secret:  secret,
+		Payload: payload,
+	})
+	if err != nil || code != http.StatusNoContent {
+		t.Fatalf("expected the signed delivery accepted, got %d %v", code, err)
+	}
+
+	if Sign(secret, "1", payload) == Sign(secret, "2", payload) {
+		t.Fatalf("expected the signature depending on the timestamp")
+	}
+}
+
+func TestSendWebhookDoesntFollowRedirects(t *testing.T) {
+	redirected := false
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		redirected = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer target.Close()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Redirect(w, req, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer receiver.Close()
+
+	sender := New(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	code, err := sender.SendWebhook(context.Background(), entities.WebhookDelivery{
+		ID:      1,
+		URL:     receiver.URL,
+		Secret:  "webhook-secret",
+		Payload: []byte(`{}`),
+	})
+	if err != nil || code != http.StatusTemporaryRedirect {
+		t.Fatalf("expected the redirect's status code returned, got %d %v", code, err)
+	}
+
+	if redirected {
+		t.Fatalf("expected the signed payload not sent to the redirect's location")
+	}
+}
@@ -0,0 +1,29 @@
+package slogger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+)
+
+// LogSink defines the logic of publishing the domain events to the service's log.
+type LogSink struct {
+	log *slog.Logger
+}
+
+func New(log *slog.Logger) LogSink {
+	return LogSink{
+		log: log,
+	}
+}
+
+// PublishEvent defines the logic of writing the event with its payload to the log.
+func (l LogSink) PublishEvent(ctx context.Context, event entities.DomainEvent) error {
+	l.log.Info(fmt.Sprintf("the domain event %d %s of the PR %s of the team %s at %s: %s",
+		event.ID, event.Type, event.PullRequestID, event.TeamName, event.OccurredAt.Format(time.RFC3339), event.Payload))
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+)
+
+// actorKey defines the ctx's key of the user making the request.
+type actorKey struct{}
+
+// WithActor returns the ctx keeping the user making the request.
+func WithActor(ctx context.Context, id entities.UserID) context.Context {
+	return context.WithValue(ctx, actorKey{}, id)
+}
+
+// GetActor returns the user making the request or the empty id if it's unknown.
+func GetActor(ctx context.Context) entities.UserID {
+	id, _ := ctx.Value(actorKey{}).(entities.UserID)
+	return id
+}
+
+// principalKey defines the ctx's key of the authenticated maker of the request.
+type principalKey struct{}
+
+// Principal defines the authenticated maker of the request: the request's token, the token's user
+// and whether the token is the admin one.
+type Principal struct {
+	TokenID entities.TokenID
+	UserID  entities.UserID
+	IsAdmin bool
+}
+
+// WithPrincipal returns the ctx keeping the authenticated maker of the request.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// GetPrincipal returns the authenticated maker of the request.
+// The requests without it, e.g. with the disabled authentication, aren't checked by the policy.
+func GetPrincipal(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
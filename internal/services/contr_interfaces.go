@@ -13,6 +13,12 @@ type (
 		TeamInteractor
 		UserInteractor
 		PullRequestInteractor
+		HealthInteractor
+		StatsInteractor
+		IdempotencyInteractor
+		WebhookInteractor
+		ReviewStreamInteractor
+		AuthInteractor
 	}
 
 	// TeamInteractor defines the interface of the teams's use-cases abstraction.
@@ -20,7 +26,10 @@ type (
 		Closer
 
 		GetTeam(ctx context.Context, teamName string) (dto.TeamDTO, error)
-		CreateTeam(ctx context.Context, dto entities.Team) error
+		CreateTeam(ctx context.Context, team entities.Team) (dto.TeamDTO, error)
+		SetTeamReviewerStrategy(ctx context.Context, name string, strategy entities.ReviewerStrategy) (dto.TeamDTO, error)
+		SetTeamReviewerPolicy(ctx context.Context, name string, policy entities.ReviewerPolicy) (dto.TeamDTO, error)
+		SetTeamMergePolicy(ctx context.Context, name string, policy entities.MergePolicy) (dto.TeamDTO, error)
 	}
 
 	// UserInteractor defines the interface of the user's use-cases abstraction.
@@ -34,9 +43,54 @@ type (
 	PullRequestInteractor interface {
 		Closer
 
-		CreatePullRequest(ctx context.Context, pullReq dto.PullRequestDTO) error
+		CreatePullRequest(ctx context.Context, pullReq dto.PullRequestDTO) (dto.PullRequestDTO, error)
 		SetPullRequestStatus(ctx context.Context, status entities.PullRequestStatus, pullReq dto.PullRequestDTO) (dto.PullRequestDTO, error)
+		MarkPullRequestMerged(ctx context.Context, id entities.PullRequestID) (dto.PullRequestDTO, error)
 		GetUserPullRequests(ctx context.Context, id entities.UserID) ([]dto.PullRequestDTOShort, error)
 		ReassignUser(ctx context.Context, reassignData dto.PullRequestChangeReviewerDTO) (dto.PullRequestDTO, entities.UserID, error)
+		GetPullRequestHistory(ctx context.Context, id entities.PullRequestID) ([]dto.ReviewerAssignmentDTO, error)
+		DeactivateTeamUsers(ctx context.Context, deactivation dto.TeamDeactivateUsersDTO) (dto.TeamDeactivationReportDTO, error)
+		ReviewPullRequest(ctx context.Context, review dto.PullRequestReviewDTO) (dto.PullRequestDTO, error)
+		ListPullRequests(ctx context.Context, filter dto.PullRequestsFilterDTO) (dto.PullRequestsPageDTO, error)
+		GetPullRequest(ctx context.Context, id entities.PullRequestID) (dto.PullRequestDetailsDTO, error)
+	}
+
+	// StatsInteractor defines the interface of the review's statistics use-cases abstraction.
+	StatsInteractor interface {
+		GetStats(ctx context.Context, filter dto.StatsFilterDTO) (dto.StatsDTO, error)
+	}
+
+	// IdempotencyInteractor defines the interface of the idempotent requests' use-cases abstraction.
+	IdempotencyInteractor interface {
+		StartIdempotentRequest(ctx context.Context, key string, fingerprint string) (dto.IdempotencyRecordDTO, error)
+		SaveIdempotentResponse(ctx context.Context, record dto.IdempotencyRecordDTO) error
+		ReleaseIdempotencyKey(ctx context.Context, key string) error
+	}
+
+	// WebhookInteractor defines the interface of the teams' webhooks use-cases abstraction.
+	WebhookInteractor interface {
+		AddTeamWebhook(ctx context.Context, webhook entities.Webhook) (entities.Webhook, error)
+		RemoveTeamWebhook(ctx context.Context, teamName string, id entities.WebhookID) error
+		GetTeamWebhooks(ctx context.Context, teamName string) ([]entities.Webhook, error)
+		GetWebhookDeliveries(ctx context.Context, filter dto.WebhookDeliveriesFilterDTO) ([]entities.WebhookDelivery, error)
+	}
+
+	// ReviewStreamInteractor defines the interface of the reviewers' queues streaming use-cases abstraction.
+	ReviewStreamInteractor interface {
+		SubscribeReviewQueue(ctx context.Context, id entities.UserID) (<-chan dto.ReviewQueueEventDTO, func(), error)
+		CloseReviewStreams()
+	}
+
+	// AuthInteractor defines the interface of the API tokens' use-cases abstraction.
+	AuthInteractor interface {
+		CreateAPIToken(ctx context.Context, token entities.APIToken) (dto.APITokenCreatedDTO, error)
+		RevokeAPIToken(ctx context.Context, id entities.TokenID) error
+		GetAPITokens(ctx context.Context) ([]entities.APIToken, error)
+		AuthenticateToken(ctx context.Context, secret string) (entities.APIToken, error)
+	}
+
+	// HealthInteractor defines the interface of the service's health use-cases abstraction.
+	HealthInteractor interface {
+		CheckReadiness(ctx context.Context) (dto.HealthDTO, error)
 	}
 )
@@ -1,12 +1,43 @@
 package services
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+)
 
 var (
-	ErrRepositoryInteraction  = errors.New("services: error of the interaction with the repository")
-	ErrEntityNotFound         = errors.New("services: error of entity search: it wasn't found")
-	ErrEntityAlreadyExists    = errors.New("services: entitiy already exists")
-	ErrDomainRulesWithROState = errors.New("services: error of the domain's rules: can't complete the current operation due to its RO-state for this entity")
-	ErrDomainRulesNoCandidate = errors.New("services: error of the finding the needed candidates")
-	ErrWrongCandidate         = errors.New("services: error of using the current candidate")
+	ErrRepositoryInteraction   = errors.New("services: error of the interaction with the repository")
+	ErrEntityNotFound          = errors.New("services: error of entity search: it wasn't found")
+	ErrEntityAlreadyExists     = errors.New("services: entitiy already exists")
+	ErrDomainRulesWithROState  = errors.New("services: error of the domain's rules: can't complete the current operation due to its RO-state for this entity")
+	ErrDomainRulesNoCandidate  = errors.New("services: error of the finding the needed candidates")
+	ErrWrongCandidate          = errors.New("services: error of using the current candidate")
+	ErrDependencyUnavailable   = errors.New("services: error of the dependency's availability")
+	ErrDomainRulesTransition   = errors.New("services: error of the domain's rules: the PR's status transition isn't allowed")
+	ErrDomainRulesPolicy       = errors.New("services: error of the domain's rules: the request violates the team's policy")
+	ErrDomainRulesMergeBlocked = errors.New("services: error of the domain's rules: the PR doesn't satisfy the team's merge policy")
+	ErrIdempotencyKeyReused    = errors.New("services: error of the idempotency key's reuse with the other request")
+	ErrIdempotencyInProgress   = errors.New("services: error of the idempotency key's request: it's still being processed")
+	ErrUnauthenticated         = errors.New("services: error of the authentication: the token is unknown or revoked")
+	ErrForbidden               = errors.New("services: error of the authorization: the actor isn't allowed to perform the operation")
 )
+
+// MergeBlockedError defines the error of the merge rejected by the team's merge policy.
+// It keeps the policy's rules the PR doesn't satisfy.
+type MergeBlockedError struct {
+	Violations []entities.MergeRuleViolation
+}
+
+func (m *MergeBlockedError) Error() string {
+	rules := make([]entities.MergeRule, 0, len(m.Violations))
+	for _, violation := range m.Violations {
+		rules = append(rules, violation.Rule)
+	}
+	return fmt.Sprintf("%s: %v", ErrDomainRulesMergeBlocked, rules)
+}
+
+func (m *MergeBlockedError) Unwrap() error {
+	return ErrDomainRulesMergeBlocked
+}
@@ -0,0 +1,117 @@
+package iauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+// AuthUseCase defines the logic of the use-cases connected with the API tokens.
+type AuthUseCase struct {
+	log       *slog.Logger
+	tokenRepo services.TokenRepository
+}
+
+func NewAuthUseCase(log *slog.Logger, tokenRepo services.TokenRepository) *AuthUseCase {
+	return &AuthUseCase{
+		log:       log,
+		tokenRepo: tokenRepo,
+	}
+}
+
+// CreateAPIToken defines the logic of issuing the new token with the token's scopes.
+// The token's secret is returned only here: only its hash is stored.
+func (a *AuthUseCase) CreateAPIToken(ctx context.Context, token entities.APIToken) (dto.APITokenCreatedDTO, error) {
+	const op = "iauth.create-api-token"
+
+	secret, err := entities.NewTokenSecret()
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
+		a.log.Warn(retErr.Error())
+		return dto.APITokenCreatedDTO{}, retErr
+	}
+
+	token.CreatedAt = time.Now()
+	token.RevokedAt = nil
+
+	res, err := a.tokenRepo.AddAPIToken(ctx, token, entities.HashTokenSecret(secret))
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return dto.APITokenCreatedDTO{}, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+		}
+		a.log.Warn(retErr.Error())
+
+		return dto.APITokenCreatedDTO{}, retErr
+	}
+
+	return dto.APITokenCreatedDTO{
+		APIToken: res,
+		Token:    secret,
+	}, nil
+}
+
+// RevokeAPIToken defines the logic of revoking the token: the revoked token isn't accepted anymore.
+// The repeated revocation keeps the time of the first one.
+func (a *AuthUseCase) RevokeAPIToken(ctx context.Context, id entities.TokenID) error {
+	const op = "iauth.revoke-api-token"
+
+	if err := a.tokenRepo.RevokeAPIToken(ctx, id, time.Now()); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+		}
+		a.log.Warn(retErr.Error())
+
+		return retErr
+	}
+
+	return nil
+}
+
+// GetAPITokens defines the logic of getting the tokens in the order of their creation.
+func (a *AuthUseCase) GetAPITokens(ctx context.Context) ([]entities.APIToken, error) {
+	const op = "iauth.get-api-tokens"
+
+	res, err := a.tokenRepo.GetAPITokens(ctx)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		a.log.Warn(retErr.Error())
+		return nil, retErr
+	}
+
+	return res, nil
+}
+
+// AuthenticateToken defines the logic of getting the active token by its secret.
+// The services.ErrUnauthenticated is returned for the unknown and the revoked tokens.
+func (a *AuthUseCase) AuthenticateToken(ctx context.Context, secret string) (entities.APIToken, error) {
+	const op = "iauth.authenticate-token"
+
+	res, err := a.tokenRepo.GetAPITokenByHash(ctx, entities.HashTokenSecret(secret))
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return entities.APIToken{}, fmt.Errorf("error of the %s: %w", op, services.ErrUnauthenticated)
+		}
+		a.log.Warn(retErr.Error())
+
+		return entities.APIToken{}, retErr
+	}
+
+	if res.IsRevoked() {
+		return entities.APIToken{}, fmt.Errorf("error of the %s: %w", op, services.ErrUnauthenticated)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,58 @@
+package ihealth
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+// HealthUseCase defines the logic of the use-cases connected with the service's health.
+type HealthUseCase struct {
+	log  *slog.Logger
+	deps map[string]services.Pinger
+}
+
+func NewHealthUseCase(log *slog.Logger, deps map[string]services.Pinger) *HealthUseCase {
+	return &HealthUseCase{
+		log:  log,
+		deps: deps,
+	}
+}
+
+// CheckReadiness defines the logic of checking every service's dependency.
+// The services.ErrDependencyUnavailable is returned with the report if any dependency is down.
+func (h *HealthUseCase) CheckReadiness(ctx context.Context) (dto.HealthDTO, error) {
+	const op = "ihealth.check-readiness"
+
+	res := dto.HealthDTO{
+		Status:       dto.HealthUp,
+		Dependencies: make([]dto.DependencyHealthDTO, 0, len(h.deps)),
+	}
+
+	for _, name := range slices.SortedFunc(maps.Keys(h.deps), cmp.Compare) {
+		dep := dto.DependencyHealthDTO{
+			Name:   name,
+			Status: dto.HealthUp,
+		}
+
+		if err := h.deps[name].Ping(ctx); err != nil {
+			h.log.Warn(fmt.Sprintf("error of the %s: %s: %s", op, name, err))
+
+			dep.Status = dto.HealthDown
+			res.Status = dto.HealthDown
+		}
+		res.Dependencies = append(res.Dependencies, dep)
+	}
+
+	if res.Status == dto.HealthDown {
+		return res, fmt.Errorf("error of the %s: %w", op, services.ErrDependencyUnavailable)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,104 @@
+package iidem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+const (
+	// pendingTimeout defines the time after which the pending request is considered abandoned
+	// and its key can be reserved again.
+	pendingTimeout = time.Minute
+
+	// responseTTL defines the time the response is kept for the client's retries.
+	responseTTL = time.Hour * 24
+)
+
+// IdempotencyUseCase defines the logic of the use-cases connected with the idempotent requests.
+type IdempotencyUseCase struct {
+	log      *slog.Logger
+	idemRepo services.IdempotencyRepository
+}
+
+func NewIdempotencyUseCase(log *slog.Logger, idemRepo services.IdempotencyRepository) *IdempotencyUseCase {
+	return &IdempotencyUseCase{
+		log:      log,
+		idemRepo: idemRepo,
+	}
+}
+
+// StartIdempotentRequest defines the logic of reserving the key for the request with the current fingerprint.
+// The pending record is returned if the key was reserved, so the request must be processed.
+// The stored response is returned if the same request was already processed.
+func (i *IdempotencyUseCase) StartIdempotentRequest(
+	ctx context.Context,
+	key string,
+	fingerprint string,
+) (dto.IdempotencyRecordDTO, error) {
+	const op = "iidem.start-idempotent-request"
+
+	now := time.Now()
+	record := dto.IdempotencyRecordDTO{
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+	}
+
+	stored, reserved, err := i.idemRepo.ReserveIdempotencyKey(ctx, record, now.Add(-pendingTimeout), now.Add(-responseTTL))
+	if err != nil {
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return dto.IdempotencyRecordDTO{}, fmt.Errorf("error of the %s: %w: %w", op, services.ErrIdempotencyInProgress, err)
+		}
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		i.log.Warn(retErr.Error())
+		return dto.IdempotencyRecordDTO{}, retErr
+	}
+
+	if reserved {
+		return record, nil
+	}
+
+	if stored.Fingerprint != fingerprint {
+		return dto.IdempotencyRecordDTO{}, fmt.Errorf("error of the %s: %w", op, services.ErrIdempotencyKeyReused)
+	}
+
+	if stored.IsPending() {
+		return dto.IdempotencyRecordDTO{}, fmt.Errorf("error of the %s: %w", op, services.ErrIdempotencyInProgress)
+	}
+
+	return stored, nil
+}
+
+// SaveIdempotentResponse defines the logic of keeping the response of the processed request.
+func (i *IdempotencyUseCase) SaveIdempotentResponse(ctx context.Context, record dto.IdempotencyRecordDTO) error {
+	const op = "iidem.save-idempotent-response"
+
+	if err := i.idemRepo.SaveIdempotentResponse(ctx, record); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		i.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	return nil
+}
+
+// ReleaseIdempotencyKey defines the logic of releasing the key of the failed request,
+// so the client's retry is processed again.
+func (i *IdempotencyUseCase) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	const op = "iidem.release-idempotency-key"
+
+	if err := i.idemRepo.DeleteIdempotencyKey(ctx, key); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		i.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package ioutbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+const (
+	// eventBatchSize defines the max count of the events published at once.
+	eventBatchSize = 100
+
+	// eventLockTimeout defines the time the claimed event isn't given to the other dispatchers.
+	// The failed event is published again after the timeout.
+	eventLockTimeout = time.Minute
+
+	// publishTimeout defines the max duration of publishing the single event to the single sink.
+	publishTimeout = 10 * time.Second
+)
+
+// OutboxUseCase defines the logic of dispatching the outbox's domain events to the sinks.
+type OutboxUseCase struct {
+	log        *slog.Logger
+	outboxRepo services.OutboxRepository
+	sinks      []services.EventSink
+}
+
+func NewOutboxUseCase(
+	log *slog.Logger,
+	outboxRepo services.OutboxRepository,
+	sinks ...services.EventSink,
+) *OutboxUseCase {
+	return &OutboxUseCase{
+		log:        log,
+		outboxRepo: outboxRepo,
+		sinks:      sinks,
+	}
+}
+
+// RunOutboxDispatcher defines the logic of publishing the outbox's events every interval until the ctx is done.
+func (o *OutboxUseCase) RunOutboxDispatcher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			count, err := o.DispatchOutboxEvents(ctx)
+			if err != nil || count < eventBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOutboxEvents defines the logic of publishing the batch of the unpublished events in their order.
+// The event is published only if every sink accepted it, otherwise it's published again to every sink later.
+// The count of the claimed events is returned.
+func (o *OutboxUseCase) DispatchOutboxEvents(ctx context.Context) (int, error) {
+	const op = "ioutbox.dispatch-outbox-events"
+
+	now := time.Now()
+
+	events, err := o.outboxRepo.ClaimOutboxEvents(ctx, now, now.Add(eventLockTimeout), eventBatchSize)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		o.log.Warn(retErr.Error())
+		return 0, retErr
+	}
+
+	for _, event := range events {
+		o.publishEvent(ctx, event)
+	}
+
+	return len(events), nil
+}
+
+// publishEvent defines the logic of publishing the single event to every sink.
+func (o *OutboxUseCase) publishEvent(ctx context.Context, event entities.DomainEvent) {
+	const op = "ioutbox.publish-event"
+
+	errs := make([]error, 0, len(o.sinks))
+
+	for _, sink := range o.sinks {
+		sinkCtx, cancel := context.WithTimeout(ctx, publishTimeout)
+		err := sink.PublishEvent(sinkCtx, event)
+		cancel()
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%T: %w", sink, err))
+		}
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	if err := errors.Join(errs...); err != nil {
+		o.log.Warn(fmt.Sprintf("error of the %s: the event %d isn't published: %s", op, event.ID, err))
+
+		if err := o.outboxRepo.SetOutboxEventFailed(ctx, event.ID, err.Error()); err != nil {
+			o.log.Warn(fmt.Sprintf("error of the %s: %s: %s", op, services.ErrRepositoryInteraction, err))
+		}
+		return
+	}
+
+	if err := o.outboxRepo.SetOutboxEventPublished(ctx, event.ID, time.Now()); err != nil {
+		o.log.Warn(fmt.Sprintf("error of the %s: %s: %s", op, services.ErrRepositoryInteraction, err))
+	}
+}
@@ -0,0 +1,53 @@
+package ioutbox
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+)
+
+// EventHandler defines the in-process subscriber's handler of the domain event.
+type EventHandler func(ctx context.Context, event entities.DomainEvent) error
+
+// SubscriberSink defines the sink publishing the events to the in-process subscribers.
+// It's safe for the concurrent use.
+type SubscriberSink struct {
+	mut      sync.RWMutex
+	handlers map[entities.DomainEventType][]EventHandler
+}
+
+func NewSubscriberSink() *SubscriberSink {
+	return &SubscriberSink{
+		handlers: make(map[entities.DomainEventType][]EventHandler, 4),
+	}
+}
+
+// Subscribe defines the logic of subscribing the handler to the events of the types.
+func (s *SubscriberSink) Subscribe(handler EventHandler, types ...entities.DomainEventType) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	for _, eventType := range types {
+		s.handlers[eventType] = append(s.handlers[eventType], handler)
+	}
+}
+
+// PublishEvent defines the logic of handling the event by every subscriber of its type.
+// Every subscriber gets the event even if the other ones failed.
+func (s *SubscriberSink) PublishEvent(ctx context.Context, event entities.DomainEvent) error {
+	s.mut.RLock()
+	handlers := slices.Clone(s.handlers[event.Type])
+	s.mut.RUnlock()
+
+	errs := make([]error, 0, len(handlers))
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
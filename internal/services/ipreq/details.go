@@ -0,0 +1,103 @@
+package ipreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+// GetPullRequest defines the logic of getting the PR with its author's team, its reviewers' data and decisions
+// and the count of its reviewers' reassignments. Everything is read inside the single transaction,
+// so the details are consistent with each other.
+func (p *PullRequestUseCase) GetPullRequest(
+	ctx context.Context,
+	id entities.PullRequestID,
+) (_ dto.PullRequestDetailsDTO, err error) {
+	ctx, span := tracer.Start(ctx, "ipreq.get-pull-request")
+	defer func() { services.EndSpan(span, err) }()
+
+	var res dto.PullRequestDetailsDTO
+
+	err = p.tx.WithinTx(ctx, services.RepeatableRead, func(ctx context.Context) error {
+		var err error
+		res, err = p.getPullRequestDetails(ctx, id)
+		return err
+	})
+
+	if err != nil {
+		return dto.PullRequestDetailsDTO{}, err
+	}
+
+	return res, nil
+}
+
+func (p *PullRequestUseCase) getPullRequestDetails(ctx context.Context, id entities.PullRequestID) (dto.PullRequestDetailsDTO, error) {
+	const op = "ipreq.get-pull-request"
+
+	pullReq, err := p.prRepo.GetPullRequest(ctx, id)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return dto.PullRequestDetailsDTO{}, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+		}
+		p.log.Warn(retErr.Error())
+
+		return dto.PullRequestDetailsDTO{}, retErr
+	}
+
+	author, err := p.userRepo.GetUser(ctx, pullReq.AuthorID)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		p.log.Warn(retErr.Error())
+		return dto.PullRequestDetailsDTO{}, retErr
+	}
+
+	reviewers, err := p.userRepo.GetUsers(ctx, pullReq.Reviewers)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		p.log.Warn(retErr.Error())
+		return dto.PullRequestDetailsDTO{}, retErr
+	}
+
+	history, err := p.prRepo.GetPullRequestHistory(ctx, id)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		p.log.Warn(retErr.Error())
+		return dto.PullRequestDetailsDTO{}, retErr
+	}
+
+	res := dto.PullRequestDetailsDTO{
+		PullRequestDTO:   pullReq,
+		AuthorTeamName:   author.TeamName,
+		ReviewersDetails: make([]dto.ReviewerDetailsDTO, 0, len(reviewers)),
+	}
+
+	users := make(map[entities.UserID]entities.User, len(reviewers))
+	for _, user := range reviewers {
+		users[user.ID] = user
+	}
+
+	for _, id := range pullReq.Reviewers {
+		res.ReviewersDetails = append(res.ReviewersDetails, dto.ReviewerDetailsDTO{
+			ID:       id,
+			Name:     users[id].Name,
+			IsActive: users[id].IsActive,
+			Decision: pullReq.Decisions[id],
+		})
+	}
+
+	// The reviewer is reassigned if the history's record both removes and assigns the reviewer.
+	for _, rec := range history {
+		if len(rec.OldReviewerID) != 0 && len(rec.NewReviewerID) != 0 {
+			res.ReassignmentsCount++
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,174 @@
+package ipreq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+// makeDomainEvent defines the logic of making the event about the PR's change
+// made by the ctx's actor at the current moment.
+func (p *PullRequestUseCase) makeDomainEvent(
+	ctx context.Context,
+	eventType entities.DomainEventType,
+	teamName string,
+	payload dto.PullRequestEventDTO,
+) (entities.DomainEvent, error) {
+	const op = "ipreq.make-domain-event"
+
+	payload.ActorID = services.GetActor(ctx)
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return entities.DomainEvent{}, fmt.Errorf("error of the %s: %w", op, err)
+	}
+
+	return entities.DomainEvent{
+		Type:          eventType,
+		PullRequestID: payload.PullRequest.ID,
+		TeamName:      teamName,
+		Payload:       data,
+		OccurredAt:    time.Now(),
+	}, nil
+}
+
+// addDomainEvents defines the logic of writing the events to the outbox.
+// The events are written inside the caller's transaction, so they're published only if the change is kept.
+func (p *PullRequestUseCase) addDomainEvents(ctx context.Context, events ...entities.DomainEvent) error {
+	const op = "ipreq.add-domain-events"
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	if err := p.outboxRepo.AddOutboxEvents(ctx, events); err != nil {
+		return fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+	}
+
+	return nil
+}
+
+// addCreatedEvents defines the logic of writing the events about the PR's creation
+// and the reviewers assigned to it.
+func (p *PullRequestUseCase) addCreatedEvents(ctx context.Context, pullReq dto.PullRequestDTO) error {
+	const op = "ipreq.add-created-events"
+
+	teamName, err := p.getAuthorTeamName(ctx, pullReq.AuthorID)
+	if err != nil {
+		return fmt.Errorf("error of the %s: %w", op, err)
+	}
+
+	event, err := p.makeDomainEvent(ctx, entities.PullRequestCreated, teamName, dto.PullRequestEventDTO{
+		PullRequest: pullReq,
+	})
+	if err != nil {
+		return fmt.Errorf("error of the %s: %w", op, err)
+	}
+	events := []entities.DomainEvent{event}
+
+	if len(pullReq.Reviewers) != 0 {
+		event, err := p.makeAssignedEvent(ctx, teamName, pullReq)
+		if err != nil {
+			return fmt.Errorf("error of the %s: %w", op, err)
+		}
+		events = append(events, event)
+	}
+
+	if err := p.addDomainEvents(ctx, events...); err != nil {
+		return fmt.Errorf("error of the %s: %w", op, err)
+	}
+
+	return nil
+}
+
+// addAssignedEvent defines the logic of writing the event about the reviewers assigned to the PR.
+func (p *PullRequestUseCase) addAssignedEvent(ctx context.Context, pullReq dto.PullRequestDTO) error {
+	const op = "ipreq.add-assigned-event"
+
+	if len(pullReq.Reviewers) == 0 {
+		return nil
+	}
+
+	teamName, err := p.getAuthorTeamName(ctx, pullReq.AuthorID)
+	if err != nil {
+		return fmt.Errorf("error of the %s: %w", op, err)
+	}
+
+	event, err := p.makeAssignedEvent(ctx, teamName, pullReq)
+	if err != nil {
+		return fmt.Errorf("error of the %s: %w", op, err)
+	}
+
+	if err := p.addDomainEvents(ctx, event); err != nil {
+		return fmt.Errorf("error of the %s: %w", op, err)
+	}
+
+	return nil
+}
+
+// makeAssignedEvent defines the logic of making the event about the reviewers assigned to the PR.
+func (p *PullRequestUseCase) makeAssignedEvent(
+	ctx context.Context,
+	teamName string,
+	pullReq dto.PullRequestDTO,
+) (entities.DomainEvent, error) {
+	return p.makeDomainEvent(ctx, entities.ReviewerAssigned, teamName, dto.PullRequestEventDTO{
+		PullRequest: pullReq,
+		Reviewers:   pullReq.Reviewers,
+	})
+}
+
+// addMergedEvent defines the logic of writing the event about the PR's merge.
+func (p *PullRequestUseCase) addMergedEvent(ctx context.Context, pullReq dto.PullRequestDTO) error {
+	const op = "ipreq.add-merged-event"
+
+	teamName, err := p.getAuthorTeamName(ctx, pullReq.AuthorID)
+	if err != nil {
+		return fmt.Errorf("error of the %s: %w", op, err)
+	}
+
+	event, err := p.makeDomainEvent(ctx, entities.PullRequestMerged, teamName, dto.PullRequestEventDTO{
+		PullRequest: pullReq,
+	})
+	if err != nil {
+		return fmt.Errorf("error of the %s: %w", op, err)
+	}
+
+	if err := p.addDomainEvents(ctx, event); err != nil {
+		return fmt.Errorf("error of the %s: %w", op, err)
+	}
+
+	return nil
+}
+
+// makeReassignedEvent defines the logic of making the event about the PR's reviewer replaced with the other one.
+func (p *PullRequestUseCase) makeReassignedEvent(
+	ctx context.Context,
+	teamName string,
+	pullReq dto.PullRequestDTO,
+	oldID entities.UserID,
+	newID entities.UserID,
+) (entities.DomainEvent, error) {
+	return p.makeDomainEvent(ctx, entities.ReviewerReassigned, teamName, dto.PullRequestEventDTO{
+		PullRequest:   pullReq,
+		OldReviewerID: oldID,
+		NewReviewerID: newID,
+	})
+}
+
+// getAuthorTeamName defines the logic of getting the name of the PR author's team.
+func (p *PullRequestUseCase) getAuthorTeamName(ctx context.Context, authorID entities.UserID) (string, error) {
+	const op = "ipreq.get-author-team-name"
+
+	user, err := p.userRepo.GetUser(ctx, authorID)
+	if err != nil {
+		return "", fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+	}
+
+	return user.TeamName, nil
+}
@@ -0,0 +1,70 @@
+package ipreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+const (
+	// defaultListLimit defines the count of the PRs on the listing's page if it isn't requested.
+	defaultListLimit = 20
+
+	// maxListLimit defines the max count of the PRs on the listing's page.
+	maxListLimit = 100
+)
+
+// ListPullRequests defines the logic of getting the page of the filtered PRs.
+// The PRs are sorted by the created_at from the newest ones by default.
+// The next page's cursor is returned only if there are the PRs after the page.
+func (p *PullRequestUseCase) ListPullRequests(
+	ctx context.Context,
+	filter dto.PullRequestsFilterDTO,
+) (_ dto.PullRequestsPageDTO, err error) {
+	const op = "ipreq.list-pull-requests"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { services.EndSpan(span, err) }()
+
+	if len(filter.SortBy) == 0 {
+		filter.SortBy = dto.SortByCreatedAt
+	}
+
+	if len(filter.Order) == 0 {
+		filter.Order = dto.Desc
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	} else if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+	limit := filter.Limit
+
+	// The extra PR shows whether the page is the last one.
+	filter.Limit++
+
+	res, err := p.prRepo.ListPullRequests(ctx, filter)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return dto.PullRequestsPageDTO{}, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+		}
+		p.log.Warn(retErr.Error())
+
+		return dto.PullRequestsPageDTO{}, retErr
+	}
+
+	page := dto.PullRequestsPageDTO{PullRequests: res}
+	if len(res) > limit {
+		page.PullRequests = res[:limit]
+		page.NextCursor = dto.MakePullRequestsCursor(filter.SortBy, filter.Order, res[limit-1]).Encode()
+	}
+
+	return page, nil
+}
@@ -5,119 +5,433 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/MaKcm14/pr-service/internal/entities"
 	"github.com/MaKcm14/pr-service/internal/entities/dto"
 	"github.com/MaKcm14/pr-service/internal/repo"
 	"github.com/MaKcm14/pr-service/internal/services"
+	"go.opentelemetry.io/otel"
 )
 
+// tracer defines the tracer of the pull-requests' use-cases' spans.
+var tracer = otel.Tracer("github.com/MaKcm14/pr-service/internal/services/ipreq")
+
 // PullRequestUseCase defines the logic of the use-cases more connected with the pull-requests.
 type PullRequestUseCase struct {
-	log      *slog.Logger
-	prRepo   services.PullRequestRepository
-	userRepo services.UserRepository
-	teamRepo services.TeamRepository
+	log        *slog.Logger
+	tx         services.TxManager
+	prRepo     services.PullRequestRepository
+	userRepo   services.UserRepository
+	teamRepo   services.TeamRepository
+	roundRobin *entities.RoundRobinSelector
+
+	outboxRepo services.OutboxRepository
+	metrics    services.MetricsRecorder
 }
 
+// NewPullRequestUseCase returns the use-case recording the domain's metrics with the metrics if it isn't nil.
 func NewPullRequestUseCase(
 	log *slog.Logger,
+	tx services.TxManager,
 	prRepo services.PullRequestRepository,
 	userRepo services.UserRepository,
 	teamRepo services.TeamRepository,
+	outboxRepo services.OutboxRepository,
+	metrics services.MetricsRecorder,
 ) *PullRequestUseCase {
 	return &PullRequestUseCase{
-		log:      log,
-		prRepo:   prRepo,
-		userRepo: userRepo,
-		teamRepo: teamRepo,
+		log:        log,
+		tx:         tx,
+		prRepo:     prRepo,
+		userRepo:   userRepo,
+		teamRepo:   teamRepo,
+		roundRobin: entities.NewRoundRobinSelector(),
+
+		outboxRepo: outboxRepo,
+		metrics:    metrics,
 	}
 }
 
-// CreatePullRequest defines the logic of creating the pull-request.
-func (p *PullRequestUseCase) CreatePullRequest(ctx context.Context, pullRequest dto.PullRequestDTO) error {
-	const op = "ipreq.create-pull-request"
+// recordNoCandidate defines the logic of counting the operation failed without the reviewer's candidate.
+// It's called after the transaction, so the retried transactions are counted once.
+func (p *PullRequestUseCase) recordNoCandidate(operation string, err error) {
+	if p.metrics != nil && errors.Is(err, services.ErrDomainRulesNoCandidate) {
+		p.metrics.RecordNoCandidate(operation)
+	}
+}
 
-	user, err := p.userRepo.GetUser(ctx, pullRequest.AuthorID)
-	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, services.ErrRepositoryInteraction, err)
+// roundRobinTurnKey defines the ctx's key of the round-robin's turn of the transaction's attempt.
+type roundRobinTurnKey struct{}
 
-		if errors.Is(err, repo.ErrModelNotFound) {
-			return fmt.Errorf("error of the %s: %w: %s", op, services.ErrEntityNotFound, err)
-		}
-		p.log.Warn(retErr.Error())
+// withinTx defines the logic of running the fn inside the transaction with the round-robin's turn of its attempt.
+// The turn is committed only with the transaction, so the rolled back and the retried attempts
+// don't advance the teams' turns.
+func (p *PullRequestUseCase) withinTx(
+	ctx context.Context,
+	level services.IsolationLevel,
+	fn func(ctx context.Context) error,
+) error {
+	var turn *entities.RoundRobinTurn
 
-		return retErr
+	err := p.tx.WithinTx(ctx, level, func(ctx context.Context) error {
+		turn = p.roundRobin.Turn()
+		return fn(context.WithValue(ctx, roundRobinTurnKey{}, turn))
+	})
+
+	if err != nil {
+		return err
 	}
+	turn.Commit()
+
+	return nil
+}
+
+// CreatePullRequest defines the logic of creating the pull-request.
+// The author's team is read and the PR is stored with its reviewers inside the single transaction.
+// The count of the assigned reviewers is reported in the result.
+func (p *PullRequestUseCase) CreatePullRequest(
+	ctx context.Context,
+	pullRequest dto.PullRequestDTO,
+) (_ dto.PullRequestDTO, err error) {
+	ctx, span := tracer.Start(ctx, "ipreq.create-pull-request")
+	defer func() { services.EndSpan(span, err) }()
+
+	var res dto.PullRequestDTO
+
+	err = p.withinTx(ctx, services.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = p.createPullRequest(ctx, pullRequest)
+		return err
+	})
 
-	team, err := p.teamRepo.GetTeam(ctx, user.TeamName)
 	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, services.ErrRepositoryInteraction, err)
-		p.log.Warn(retErr.Error())
-		return retErr
+		p.recordNoCandidate("create", err)
+		return dto.PullRequestDTO{}, err
 	}
+
+	return res, nil
+}
+
+func (p *PullRequestUseCase) createPullRequest(ctx context.Context, pullRequest dto.PullRequestDTO) (dto.PullRequestDTO, error) {
+	const op = "ipreq.create-pull-request"
+
 	pullReq := dto.PullRequestDTOToPullRequest(pullRequest)
-	pullReq.SetReviewers(team)
+	pullReq.RequestedReviewers = pullRequest.ReviewersCount
+
+	if pullReq.Status != entities.Draft {
+		if err := p.setReviewers(ctx, &pullReq, pullReq.RequestedReviewers); err != nil {
+			return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w", op, err)
+		}
+	}
 	pullReq.SetCreatedAtNow()
 
-	pullRequest = dto.PullRequestToPullRequestDTO(pullReq)
+	res := dto.PullRequestToPullRequestDTO(pullReq)
 
-	if err := p.prRepo.CreatePullRequest(ctx, pullRequest); err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, services.ErrRepositoryInteraction, err)
+	if err := p.prRepo.CreatePullRequest(ctx, res); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
 
 		if errors.Is(err, repo.ErrModelNotFound) {
-			return fmt.Errorf("error of the %s: %w: %s", op, services.ErrEntityNotFound, err)
+			return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
 		} else if errors.Is(err, repo.ErrModelAlreadyExists) {
-			return fmt.Errorf("error of the %s: %w: %s", op, services.ErrEntityAlreadyExists, err)
+			return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityAlreadyExists, err)
 		}
 		p.log.Warn(retErr.Error())
 
-		return retErr
+		return dto.PullRequestDTO{}, retErr
 	}
 
-	return nil
+	if err := p.addAssignedHistory(ctx, res); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
+		p.log.Warn(retErr.Error())
+		return dto.PullRequestDTO{}, retErr
+	}
+
+	if err := p.addCreatedEvents(ctx, res); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
+		p.log.Warn(retErr.Error())
+		return dto.PullRequestDTO{}, retErr
+	}
+
+	count := len(res.Reviewers)
+	res.ReviewersCount = &count
+
+	return res, nil
 }
 
-// SetPullRequestStatus defines the logic of changing the status for the pull-request object.
+// SetPullRequestStatus defines the logic of changing the status for the pull-request object
+// according to the statuses' transitions. The reviewers are assigned to the PR becoming open without them,
+// e.g. leaving the DRAFT status, in the count requested at the PR's creation,
+// and the merge must satisfy the merge policy of the PR author's team.
+// The current PR's status must be equal to the pullReq's one if it's set.
+// The PR is read and changed inside the single serializable transaction.
 func (p *PullRequestUseCase) SetPullRequestStatus(
 	ctx context.Context,
 	status entities.PullRequestStatus,
 	pullReq dto.PullRequestDTO,
+) (_ dto.PullRequestDTO, err error) {
+	ctx, span := tracer.Start(ctx, "ipreq.set-pull-request-status")
+	defer func() { services.EndSpan(span, err) }()
+
+	var res dto.PullRequestDTO
+
+	err = p.withinTx(ctx, services.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = p.setPullRequestStatus(ctx, status, pullReq)
+		return err
+	})
+
+	if err != nil {
+		p.recordNoCandidate("set_status", err)
+		return dto.PullRequestDTO{}, err
+	}
+
+	return res, nil
+}
+
+func (p *PullRequestUseCase) setPullRequestStatus(
+	ctx context.Context,
+	status entities.PullRequestStatus,
+	expected dto.PullRequestDTO,
 ) (dto.PullRequestDTO, error) {
 	const op = "ipreq.set-pull-request-status"
 
+	pullReq, err := p.prRepo.GetPullRequest(ctx, expected.ID)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+		}
+		p.log.Warn(retErr.Error())
+
+		return dto.PullRequestDTO{}, retErr
+	}
+
+	if len(expected.Status) != 0 && expected.Status != pullReq.Status {
+		return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w: %w: the status isn't %s",
+			op, services.ErrDomainRulesTransition, entities.ErrStatusTransition, expected.Status)
+	}
+
+	prEnt := dto.PullRequestDTOToPullRequest(pullReq)
+
+	var authorTeam entities.Team
 	if status == entities.Merged {
-		pullReqEnt := dto.PullRequestDTOToPullRequest(pullReq)
-		pullReqEnt.SetMergedAtNow()
-		pullReq = dto.PullRequestToPullRequestDTO(pullReqEnt)
+		if authorTeam, err = p.getAuthorTeam(ctx, prEnt.Author.ID); err != nil {
+			return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w", op, err)
+		}
+
+		if err := services.AuthorizeMerge(ctx, prEnt, authorTeam); err != nil {
+			return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w", op, err)
+		}
+	}
+
+	if status == entities.Merged && pullReq.Status == entities.Merged {
+		return pullReq, nil
+	}
+
+	if err := prEnt.SetStatus(status); err != nil {
+		return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w: %w", op, services.ErrDomainRulesTransition, err)
 	}
 
-	res, err := p.prRepo.SetPullRequestStatus(ctx, status, pullReq)
+	if status == entities.Merged {
+		if err := p.checkMergePolicy(prEnt, authorTeam); err != nil {
+			return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w", op, err)
+		}
+	}
+
+	if status == entities.Open && len(prEnt.Reviewers) == 0 {
+		if err := p.setReviewers(ctx, &prEnt, prEnt.RequestedReviewers); err != nil {
+			return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w", op, err)
+		}
+		assigned := dto.PullRequestToPullRequestDTO(prEnt)
+
+		if err := p.prRepo.AddPullRequestReviewers(ctx, assigned); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+			p.log.Warn(retErr.Error())
+			return dto.PullRequestDTO{}, retErr
+		}
+
+		if err := p.addAssignedHistory(ctx, assigned); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w", op, err)
+			p.log.Warn(retErr.Error())
+			return dto.PullRequestDTO{}, retErr
+		}
+
+		if err := p.addAssignedEvent(ctx, assigned); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w", op, err)
+			p.log.Warn(retErr.Error())
+			return dto.PullRequestDTO{}, retErr
+		}
+	}
+
+	res, err := p.prRepo.SetPullRequestStatus(ctx, status, dto.PullRequestToPullRequestDTO(prEnt))
 	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, services.ErrRepositoryInteraction, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
 
 		if errors.Is(err, repo.ErrModelNotFound) {
-			return dto.PullRequestDTO{},
-				fmt.Errorf("error of the %s: %w: %s", op, services.ErrEntityNotFound, err)
+			return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
 		}
 		p.log.Warn(retErr.Error())
 
 		return dto.PullRequestDTO{}, retErr
 	}
 
+	if status == entities.Merged {
+		if err := p.addMergedEvent(ctx, res); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w", op, err)
+			p.log.Warn(retErr.Error())
+			return dto.PullRequestDTO{}, retErr
+		}
+	}
+
 	return res, nil
 }
 
-func (p *PullRequestUseCase) GetUserPullRequests(ctx context.Context, id entities.UserID) ([]dto.PullRequestDTOShort, error) {
+// MarkPullRequestMerged defines the logic of recording the PR's merge already made in the VCS.
+// The merge isn't checked against the statuses' transitions, the merge policy of the author's team
+// and the request's principal: the service follows the VCS, so the merged PR is never left open.
+func (p *PullRequestUseCase) MarkPullRequestMerged(
+	ctx context.Context,
+	id entities.PullRequestID,
+) (_ dto.PullRequestDTO, err error) {
+	ctx, span := tracer.Start(ctx, "ipreq.mark-pull-request-merged")
+	defer func() { services.EndSpan(span, err) }()
+
+	var res dto.PullRequestDTO
+
+	err = p.tx.WithinTx(ctx, services.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = p.markPullRequestMerged(ctx, id)
+		return err
+	})
+
+	if err != nil {
+		return dto.PullRequestDTO{}, err
+	}
+
+	return res, nil
+}
+
+func (p *PullRequestUseCase) markPullRequestMerged(ctx context.Context, id entities.PullRequestID) (dto.PullRequestDTO, error) {
+	const op = "ipreq.mark-pull-request-merged"
+
+	pullReq, err := p.prRepo.GetPullRequest(ctx, id)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+		}
+		p.log.Warn(retErr.Error())
+
+		return dto.PullRequestDTO{}, retErr
+	}
+
+	if pullReq.Status == entities.Merged {
+		return pullReq, nil
+	}
+
+	prEnt := dto.PullRequestDTOToPullRequest(pullReq)
+	prEnt.MarkMerged()
+
+	res, err := p.prRepo.SetPullRequestStatus(ctx, entities.Merged, dto.PullRequestToPullRequestDTO(prEnt))
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		p.log.Warn(retErr.Error())
+		return dto.PullRequestDTO{}, retErr
+	}
+
+	if err := p.addMergedEvent(ctx, res); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
+		p.log.Warn(retErr.Error())
+		return dto.PullRequestDTO{}, retErr
+	}
+
+	return res, nil
+}
+
+// getAuthorTeam defines the logic of getting the team of the PR's author.
+func (p *PullRequestUseCase) getAuthorTeam(ctx context.Context, authorID entities.UserID) (entities.Team, error) {
+	const op = "ipreq.get-author-team"
+
+	user, err := p.userRepo.GetUser(ctx, authorID)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return entities.Team{}, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+		}
+		p.log.Warn(retErr.Error())
+
+		return entities.Team{}, retErr
+	}
+
+	team, err := p.teamRepo.GetTeam(ctx, user.TeamName)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		p.log.Warn(retErr.Error())
+		return entities.Team{}, retErr
+	}
+
+	return team, nil
+}
+
+// setReviewers defines the logic of assigning the reviewers among the members of the PR author's team.
+func (p *PullRequestUseCase) setReviewers(ctx context.Context, pullReq *entities.PullRequest, requested *int) error {
+	const op = "ipreq.set-reviewers"
+
+	team, err := p.getAuthorTeam(ctx, pullReq.Author.ID)
+	if err != nil {
+		return fmt.Errorf("error of the %s: %w", op, err)
+	}
+
+	selector, err := p.getReviewerSelector(ctx, team)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
+		p.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	if err := pullReq.SetReviewers(team, selector, requested); err != nil {
+		if errors.Is(err, entities.ErrReviewersCount) {
+			return fmt.Errorf("error of the %s: %w: %w", op, services.ErrDomainRulesPolicy, err)
+		}
+		return fmt.Errorf("error of the %s: %w: %w", op, services.ErrDomainRulesNoCandidate, err)
+	}
+
+	return nil
+}
+
+// addAssignedHistory defines the logic of keeping the PR's reviewers assignment in the history.
+func (p *PullRequestUseCase) addAssignedHistory(ctx context.Context, pullReq dto.PullRequestDTO) error {
+	const op = "ipreq.add-assigned-history"
+
+	records := make([]dto.ReviewerAssignmentDTO, 0, len(pullReq.Reviewers))
+	for _, id := range pullReq.Reviewers {
+		records = append(records, p.makeAssignmentRecord(ctx, pullReq.ID, "", id, entities.AssignmentCreated))
+	}
+
+	if err := p.prRepo.AddAssignmentsHistory(ctx, records); err != nil {
+		return fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+	}
+
+	return nil
+}
+
+func (p *PullRequestUseCase) GetUserPullRequests(ctx context.Context, id entities.UserID) (_ []dto.PullRequestDTOShort, err error) {
 	const op = "ipreq.get-user-pull-requests"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { services.EndSpan(span, err) }()
+
 	res, err := p.prRepo.GetUserPullRequests(ctx, id)
 
 	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, services.ErrRepositoryInteraction, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
 
 		if errors.Is(err, repo.ErrModelNotFound) {
-			return nil, fmt.Errorf("error of the %s: %w: %s", op, services.ErrEntityNotFound, err)
+			return nil, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
 		}
 		p.log.Warn(retErr.Error())
 
@@ -127,15 +441,72 @@ func (p *PullRequestUseCase) GetUserPullRequests(ctx context.Context, id entitie
 	return res, nil
 }
 
-func (p *PullRequestUseCase) ReassignUser(ctx context.Context, reassignData dto.PullRequestChangeReviewerDTO) (dto.PullRequestDTO, entities.UserID, error) {
+// GetPullRequestHistory defines the logic of getting the PR's reviewers changes in the chronological order.
+func (p *PullRequestUseCase) GetPullRequestHistory(
+	ctx context.Context,
+	id entities.PullRequestID,
+) (_ []dto.ReviewerAssignmentDTO, err error) {
+	const op = "ipreq.get-pull-request-history"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { services.EndSpan(span, err) }()
+
+	res, err := p.prRepo.GetPullRequestHistory(ctx, id)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return nil, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+		}
+		p.log.Warn(retErr.Error())
+
+		return nil, retErr
+	}
+
+	return res, nil
+}
+
+// ReassignUser defines the logic of replacing the PR's reviewer with the other member of the reviewer's team.
+// The reviewer, the PR and the team's membership are read and the reviewer is changed inside
+// the single serializable transaction, so the choice is never made against the stale data.
+func (p *PullRequestUseCase) ReassignUser(
+	ctx context.Context,
+	reassignData dto.PullRequestChangeReviewerDTO,
+) (_ dto.PullRequestDTO, _ entities.UserID, err error) {
+	ctx, span := tracer.Start(ctx, "ipreq.reassign-user")
+	defer func() { services.EndSpan(span, err) }()
+
+	var (
+		res dto.PullRequestDTO
+		id  entities.UserID
+	)
+
+	err = p.withinTx(ctx, services.Serializable, func(ctx context.Context) error {
+		var err error
+		res, id, err = p.reassignUser(ctx, reassignData)
+		return err
+	})
+
+	if err != nil {
+		p.recordNoCandidate("reassign", err)
+		return dto.PullRequestDTO{}, "", err
+	}
+
+	return res, id, nil
+}
+
+func (p *PullRequestUseCase) reassignUser(
+	ctx context.Context,
+	reassignData dto.PullRequestChangeReviewerDTO,
+) (dto.PullRequestDTO, entities.UserID, error) {
 	const op = "ipreq.reassign-user"
 
 	user, err := p.userRepo.GetUser(ctx, reassignData.OldReviewerID)
 	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, services.ErrRepositoryInteraction, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
 
 		if errors.Is(err, repo.ErrModelNotFound) {
-			return dto.PullRequestDTO{}, "", fmt.Errorf("error of the %s: %w: %s", op, services.ErrEntityNotFound, err)
+			return dto.PullRequestDTO{}, "", fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
 		}
 		p.log.Warn(retErr.Error())
 
@@ -144,10 +515,10 @@ func (p *PullRequestUseCase) ReassignUser(ctx context.Context, reassignData dto.
 
 	pullReq, err := p.prRepo.GetPullRequest(ctx, reassignData.ID)
 	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, services.ErrRepositoryInteraction, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
 
 		if errors.Is(err, repo.ErrModelNotFound) {
-			return dto.PullRequestDTO{}, "", fmt.Errorf("error of the %s: %w: %s", op, services.ErrEntityNotFound, err)
+			return dto.PullRequestDTO{}, "", fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
 		}
 		p.log.Warn(retErr.Error())
 
@@ -156,39 +527,148 @@ func (p *PullRequestUseCase) ReassignUser(ctx context.Context, reassignData dto.
 
 	team, err := p.teamRepo.GetTeam(ctx, user.TeamName)
 	if err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, services.ErrRepositoryInteraction, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
 
 		if errors.Is(err, repo.ErrModelNotFound) {
-			return dto.PullRequestDTO{}, "", fmt.Errorf("error of the %s: %w: %s", op, services.ErrEntityNotFound, err)
+			return dto.PullRequestDTO{}, "", fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
 		}
 		p.log.Warn(retErr.Error())
 
 		return dto.PullRequestDTO{}, "", retErr
 	}
 
+	// The new reviewer is chosen among the members of the reviewer's team,
+	// but the lead of the author's team may reassign the PR's reviewers.
+	authorTeam, err := p.getAuthorTeam(ctx, pullReq.AuthorID)
+	if err != nil {
+		return dto.PullRequestDTO{}, "", fmt.Errorf("error of the %s: %w", op, err)
+	}
+
 	prEnt := dto.PullRequestDTOToPullRequest(pullReq)
-	id, err := prEnt.ReassignReviewer(user.ID, team)
+
+	if err := services.AuthorizeReassign(ctx, prEnt, authorTeam); err != nil {
+		return dto.PullRequestDTO{}, "", fmt.Errorf("error of the %s: %w", op, err)
+	}
+
+	selector, err := p.getReviewerSelector(ctx, team)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
+		p.log.Warn(retErr.Error())
+		return dto.PullRequestDTO{}, "", retErr
+	}
+
+	id, err := prEnt.ReassignReviewer(user.ID, team, selector)
 
 	if err != nil {
 		if errors.Is(err, entities.ErrStatusForReassign) {
-			return dto.PullRequestDTO{}, "", fmt.Errorf("error of the %s: %w: %s", op, services.ErrDomainRulesWithROState, err)
-		} else if errors.Is(err, entities.ErrReviewerAssign) {
-			return dto.PullRequestDTO{}, "", fmt.Errorf("error of the %s: %w: %s", op, services.ErrDomainRulesNoCandidate, err)
+			return dto.PullRequestDTO{}, "", fmt.Errorf("error of the %s: %w: %w", op, services.ErrDomainRulesWithROState, err)
+		} else if errors.Is(err, entities.ErrNoCandidate) {
+			return dto.PullRequestDTO{}, "", fmt.Errorf("error of the %s: %w: %w", op, services.ErrDomainRulesNoCandidate, err)
 		} else if errors.Is(err, entities.ErrReviewerIsWrong) {
-			return dto.PullRequestDTO{}, "", fmt.Errorf("error of the %s: %w: %s", op, services.ErrWrongCandidate, err)
+			return dto.PullRequestDTO{}, "", fmt.Errorf("error of the %s: %w: %w", op, services.ErrWrongCandidate, err)
 		}
+		return dto.PullRequestDTO{}, "", fmt.Errorf("error of the %s: %w", op, err)
 	}
 
-	// TODO: delete
-	fmt.Println("check_point", id, reassignData.OldReviewerID)
-
 	if err := p.prRepo.ChangeReviewer(ctx, reassignData.OldReviewerID, id, pullReq); err != nil {
-		retErr := fmt.Errorf("error of the %s: %w: %s", op, services.ErrRepositoryInteraction, err)
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		p.log.Warn(retErr.Error())
+		return dto.PullRequestDTO{}, "", retErr
+	}
+
+	record := p.makeAssignmentRecord(ctx, pullReq.ID, reassignData.OldReviewerID, id, entities.AssignmentReassigned)
+
+	if err := p.prRepo.AddAssignmentsHistory(ctx, []dto.ReviewerAssignmentDTO{record}); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		p.log.Warn(retErr.Error())
+		return dto.PullRequestDTO{}, "", retErr
+	}
+	res := dto.PullRequestToPullRequestDTO(prEnt)
+
+	event, err := p.makeReassignedEvent(ctx, team.Name, res, reassignData.OldReviewerID, id)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
+		p.log.Warn(retErr.Error())
+		return dto.PullRequestDTO{}, "", retErr
+	}
+
+	if err := p.addDomainEvents(ctx, event); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
 		p.log.Warn(retErr.Error())
 		return dto.PullRequestDTO{}, "", retErr
 	}
 
-	return dto.PullRequestToPullRequestDTO(prEnt), id, nil
+	return res, id, nil
+}
+
+// getReviewerSelector defines the logic of making the selector for the team's reviewer strategy.
+func (p *PullRequestUseCase) getReviewerSelector(ctx context.Context, team entities.Team) (entities.ReviewerSelector, error) {
+	const op = "ipreq.get-reviewer-selector"
+
+	load, err := p.getReviewersLoad(ctx, team)
+	if err != nil {
+		return nil, fmt.Errorf("error of the %s: %w", op, err)
+	}
+
+	return p.makeReviewerSelector(ctx, team.ReviewerStrategy, load), nil
+}
+
+// getReviewersLoad defines the logic of getting the team's reviewers load if its strategy uses it.
+func (p *PullRequestUseCase) getReviewersLoad(ctx context.Context, team entities.Team) (entities.ReviewerLoad, error) {
+	const op = "ipreq.get-reviewers-load"
+
+	if team.ReviewerStrategy != entities.LeastLoadedStrategy && team.ReviewerStrategy != entities.WeightedRandomStrategy {
+		return nil, nil
+	}
+
+	load, err := p.prRepo.GetReviewersLoad(ctx, team.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+	}
+
+	return load, nil
+}
+
+// makeReviewerSelector defines the logic of making the selector for the strategy with the reviewers' load.
+// The load is shared with the selector, so its changes are taken into account by the next choices.
+// The round-robin's choices are made with the ctx's turn if the transaction has it.
+func (p *PullRequestUseCase) makeReviewerSelector(
+	ctx context.Context,
+	strategy entities.ReviewerStrategy,
+	load entities.ReviewerLoad,
+) entities.ReviewerSelector {
+	switch strategy {
+	case entities.RoundRobinStrategy:
+		if turn, ok := ctx.Value(roundRobinTurnKey{}).(*entities.RoundRobinTurn); ok {
+			return turn
+		}
+		return p.roundRobin
+	case entities.LeastLoadedStrategy:
+		return entities.LeastLoadedSelector{Load: load}
+	case entities.WeightedRandomStrategy:
+		return entities.WeightedRandomSelector{Load: load}
+	}
+
+	return entities.RandomSelector{}
+}
+
+// makeAssignmentRecord defines the logic of making the history's record of the reviewers change
+// made by the ctx's actor at the current moment.
+func (p *PullRequestUseCase) makeAssignmentRecord(
+	ctx context.Context,
+	id entities.PullRequestID,
+	oldID entities.UserID,
+	newID entities.UserID,
+	reason entities.AssignmentReason,
+) dto.ReviewerAssignmentDTO {
+	return dto.ReviewerAssignmentDTO{
+		ID:            id,
+		OldReviewerID: oldID,
+		NewReviewerID: newID,
+		Reason:        reason,
+		ActorID:       services.GetActor(ctx),
+		ChangedAt:     time.Now(),
+	}
 }
 
 func (p *PullRequestUseCase) Close() {
@@ -0,0 +1,101 @@
+package ipreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+// ReviewPullRequest defines the logic of keeping the reviewer's decision on the open PR.
+// The PR's author can leave the decision only if the merge policy of the author's team allows it.
+// Only the reviewer or the admin may leave the reviewer's decision if the request has the principal.
+// The PR is read and the decision is kept inside the single serializable transaction,
+// so the decision is never kept for the reviewer that was already replaced.
+func (p *PullRequestUseCase) ReviewPullRequest(
+	ctx context.Context,
+	review dto.PullRequestReviewDTO,
+) (_ dto.PullRequestDTO, err error) {
+	ctx, span := tracer.Start(ctx, "ipreq.review-pull-request")
+	defer func() { services.EndSpan(span, err) }()
+
+	var res dto.PullRequestDTO
+
+	err = p.tx.WithinTx(ctx, services.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = p.reviewPullRequest(ctx, review)
+		return err
+	})
+
+	if err != nil {
+		return dto.PullRequestDTO{}, err
+	}
+
+	return res, nil
+}
+
+func (p *PullRequestUseCase) reviewPullRequest(
+	ctx context.Context,
+	review dto.PullRequestReviewDTO,
+) (dto.PullRequestDTO, error) {
+	const op = "ipreq.review-pull-request"
+
+	if err := services.AuthorizeReview(ctx, review.ReviewerID); err != nil {
+		return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w", op, err)
+	}
+
+	pullReq, err := p.prRepo.GetPullRequest(ctx, review.ID)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+		}
+		p.log.Warn(retErr.Error())
+
+		return dto.PullRequestDTO{}, retErr
+	}
+
+	var policy entities.MergePolicy
+	if review.ReviewerID == pullReq.AuthorID {
+		team, err := p.getAuthorTeam(ctx, pullReq.AuthorID)
+		if err != nil {
+			return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w", op, err)
+		}
+		policy = team.GetMergePolicy()
+	}
+
+	prEnt := dto.PullRequestDTOToPullRequest(pullReq)
+	if err := prEnt.SetReviewDecision(review.ReviewerID, review.Decision, policy); err != nil {
+		if errors.Is(err, entities.ErrStatusForReview) {
+			return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w: %w", op, services.ErrDomainRulesWithROState, err)
+		}
+		return dto.PullRequestDTO{}, fmt.Errorf("error of the %s: %w: %w", op, services.ErrWrongCandidate, err)
+	}
+	review.CreatedAt = time.Now()
+
+	if err := p.prRepo.AddReviewDecision(ctx, review); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		p.log.Warn(retErr.Error())
+		return dto.PullRequestDTO{}, retErr
+	}
+
+	return dto.PullRequestToPullRequestDTO(prEnt), nil
+}
+
+// checkMergePolicy defines the logic of checking whether the PR satisfies the merge policy
+// of the PR author's team. The services.MergeBlockedError with the unmet rules is returned otherwise.
+func (p *PullRequestUseCase) checkMergePolicy(pullReq entities.PullRequest, team entities.Team) error {
+	const op = "ipreq.check-merge-policy"
+
+	if violations := pullReq.CheckMergePolicy(team.GetMergePolicy()); len(violations) != 0 {
+		return fmt.Errorf("error of the %s: %w", op, &services.MergeBlockedError{Violations: violations})
+	}
+
+	return nil
+}
@@ -0,0 +1,226 @@
+package ipreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+// DeactivateTeamUsers defines the logic of deactivating the team's members and reassigning their open reviews
+// to the active members of the PRs' authors' teams. The reviewer is removed from the PR if there's no candidate.
+// Every change is made inside the single serializable transaction with the batch repositories' ops.
+// Only the team's lead or the admin may deactivate the members if the request has the principal.
+func (p *PullRequestUseCase) DeactivateTeamUsers(
+	ctx context.Context,
+	deactivation dto.TeamDeactivateUsersDTO,
+) (_ dto.TeamDeactivationReportDTO, err error) {
+	ctx, span := tracer.Start(ctx, "ipreq.deactivate-team-users")
+	defer func() { services.EndSpan(span, err) }()
+
+	var res dto.TeamDeactivationReportDTO
+
+	err = p.withinTx(ctx, services.Serializable, func(ctx context.Context) error {
+		var err error
+		res, err = p.deactivateTeamUsers(ctx, deactivation)
+		return err
+	})
+
+	if err != nil {
+		return dto.TeamDeactivationReportDTO{}, err
+	}
+
+	return res, nil
+}
+
+func (p *PullRequestUseCase) deactivateTeamUsers(
+	ctx context.Context,
+	deactivation dto.TeamDeactivateUsersDTO,
+) (dto.TeamDeactivationReportDTO, error) {
+	const op = "ipreq.deactivate-team-users"
+
+	if err := services.AuthorizeTeamEdit(ctx, p.teamRepo, deactivation.Name); err != nil {
+		return dto.TeamDeactivationReportDTO{}, fmt.Errorf("error of the %s: %w", op, err)
+	}
+
+	ids := slices.Clone(deactivation.UserIDs)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	res := dto.TeamDeactivationReportDTO{
+		Name:        deactivation.Name,
+		Deactivated: ids,
+		Reassigned:  make([]dto.ReviewerChangeDTO, 0, 50),
+		Unassigned:  make([]dto.ReviewerChangeDTO, 0, 10),
+	}
+
+	if err := p.userRepo.SetTeamUsersIsActive(ctx, deactivation.Name, false, ids); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return dto.TeamDeactivationReportDTO{}, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+		}
+		p.log.Warn(retErr.Error())
+
+		return dto.TeamDeactivationReportDTO{}, retErr
+	}
+
+	pullReqs, err := p.prRepo.GetReviewersOpenPullRequests(ctx, ids)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		p.log.Warn(retErr.Error())
+		return dto.TeamDeactivationReportDTO{}, retErr
+	}
+
+	if len(pullReqs) == 0 {
+		return res, nil
+	}
+
+	teams, err := p.getAuthorsTeams(ctx, pullReqs)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
+		p.log.Warn(retErr.Error())
+		return dto.TeamDeactivationReportDTO{}, retErr
+	}
+
+	events := make([]entities.DomainEvent, 0, len(pullReqs))
+
+	for _, pullReq := range pullReqs {
+		prEnt := dto.PullRequestDTOToPullRequest(pullReq)
+		reassigned := len(res.Reassigned)
+
+		team, ok := teams[pullReq.AuthorID]
+		if !ok {
+			return dto.TeamDeactivationReportDTO{}, fmt.Errorf("error of the %s: %w: author '%s'",
+				op, services.ErrEntityNotFound, pullReq.AuthorID)
+		}
+
+		for _, id := range pullReq.Reviewers {
+			if _, found := slices.BinarySearch(ids, id); !found {
+				continue
+			}
+
+			change := dto.ReviewerChangeDTO{
+				ID:            pullReq.ID,
+				OldReviewerID: id,
+			}
+
+			change.NewReviewerID, err = prEnt.ReassignReviewer(id, team.team, team.selector)
+			if errors.Is(err, entities.ErrNoCandidate) {
+				err = prEnt.UnassignReviewer(id)
+			}
+
+			if err != nil {
+				return dto.TeamDeactivationReportDTO{}, fmt.Errorf("error of the %s: %w", op, err)
+			}
+
+			if len(change.NewReviewerID) == 0 {
+				res.Unassigned = append(res.Unassigned, change)
+				continue
+			}
+			res.Reassigned = append(res.Reassigned, change)
+
+			if team.load != nil {
+				team.load[change.NewReviewerID]++
+			}
+		}
+
+		for _, change := range res.Reassigned[reassigned:] {
+			event, err := p.makeReassignedEvent(ctx, team.team.Name,
+				dto.PullRequestToPullRequestDTO(prEnt), change.OldReviewerID, change.NewReviewerID)
+			if err != nil {
+				return dto.TeamDeactivationReportDTO{}, fmt.Errorf("error of the %s: %w", op, err)
+			}
+			events = append(events, event)
+		}
+	}
+
+	changes := slices.Concat(res.Reassigned, res.Unassigned)
+
+	if err := p.prRepo.ChangeReviewers(ctx, changes); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		p.log.Warn(retErr.Error())
+		return dto.TeamDeactivationReportDTO{}, retErr
+	}
+
+	records := make([]dto.ReviewerAssignmentDTO, 0, len(changes))
+	for _, change := range changes {
+		records = append(records, p.makeAssignmentRecord(ctx,
+			change.ID, change.OldReviewerID, change.NewReviewerID, entities.AssignmentDeactivated))
+	}
+
+	if err := p.prRepo.AddAssignmentsHistory(ctx, records); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		p.log.Warn(retErr.Error())
+		return dto.TeamDeactivationReportDTO{}, retErr
+	}
+
+	if err := p.addDomainEvents(ctx, events...); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w", op, err)
+		p.log.Warn(retErr.Error())
+		return dto.TeamDeactivationReportDTO{}, retErr
+	}
+
+	return res, nil
+}
+
+// authorTeam defines the author's team with the selector used for choosing its reviewers.
+type authorTeam struct {
+	team     entities.Team
+	selector entities.ReviewerSelector
+	load     entities.ReviewerLoad
+}
+
+// getAuthorsTeams defines the logic of getting the teams of the PRs' authors.
+// Every team is read once, so the selector and its load are shared by every team's PR.
+func (p *PullRequestUseCase) getAuthorsTeams(
+	ctx context.Context,
+	pullReqs []dto.PullRequestDTO,
+) (map[entities.UserID]*authorTeam, error) {
+	const op = "ipreq.get-authors-teams"
+
+	ids := make([]entities.UserID, 0, len(pullReqs))
+	for _, pullReq := range pullReqs {
+		ids = append(ids, pullReq.AuthorID)
+	}
+	slices.Sort(ids)
+
+	authors, err := p.userRepo.GetUsers(ctx, slices.Compact(ids))
+	if err != nil {
+		return nil, fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+	}
+
+	res := make(map[entities.UserID]*authorTeam, len(authors))
+	teams := make(map[string]*authorTeam, 5)
+
+	for _, author := range authors {
+		if team, ok := teams[author.TeamName]; ok {
+			res[author.ID] = team
+			continue
+		}
+
+		team, err := p.teamRepo.GetTeam(ctx, author.TeamName)
+		if err != nil {
+			return nil, fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		}
+
+		load, err := p.getReviewersLoad(ctx, team)
+		if err != nil {
+			return nil, fmt.Errorf("error of the %s: %w", op, err)
+		}
+
+		teams[author.TeamName] = &authorTeam{
+			team:     team,
+			selector: p.makeReviewerSelector(ctx, team.ReviewerStrategy, load),
+			load:     load,
+		}
+		res[author.ID] = teams[author.TeamName]
+	}
+
+	return res, nil
+}
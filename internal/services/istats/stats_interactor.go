@@ -0,0 +1,114 @@
+package istats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+// StatsUseCase defines the logic of the use-cases connected with the review's statistics.
+type StatsUseCase struct {
+	log       *slog.Logger
+	tx        services.TxManager
+	statsRepo services.StatsRepository
+	teamRepo  services.TeamRepository
+	userRepo  services.UserRepository
+}
+
+func NewStatsUseCase(
+	log *slog.Logger,
+	tx services.TxManager,
+	statsRepo services.StatsRepository,
+	teamRepo services.TeamRepository,
+	userRepo services.UserRepository,
+) *StatsUseCase {
+	return &StatsUseCase{
+		log:       log,
+		tx:        tx,
+		statsRepo: statsRepo,
+		teamRepo:  teamRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// GetStats defines the logic of getting the review's statistics for the filter.
+// The teams' statistics are calculated only if the filter isn't set for the single user.
+// Every statistic is calculated inside the single transaction, so they're consistent with each other.
+func (s *StatsUseCase) GetStats(ctx context.Context, filter dto.StatsFilterDTO) (dto.StatsDTO, error) {
+	res := dto.StatsDTO{
+		From: filter.From,
+		To:   filter.To,
+	}
+
+	err := s.tx.WithinTx(ctx, services.RepeatableRead, func(ctx context.Context) error {
+		var err error
+		res.Users, res.Teams, err = s.getStats(ctx, filter)
+		return err
+	})
+
+	if err != nil {
+		return dto.StatsDTO{}, err
+	}
+
+	return res, nil
+}
+
+func (s *StatsUseCase) getStats(
+	ctx context.Context,
+	filter dto.StatsFilterDTO,
+) ([]dto.UserStatsDTO, []dto.TeamStatsDTO, error) {
+	const op = "istats.get-stats"
+
+	if err := s.checkFilterEntities(ctx, filter); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return nil, nil, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+		}
+		s.log.Warn(retErr.Error())
+
+		return nil, nil, retErr
+	}
+
+	users, err := s.statsRepo.GetUsersStats(ctx, filter)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		s.log.Warn(retErr.Error())
+		return nil, nil, retErr
+	}
+
+	if len(filter.UserID) != 0 {
+		return users, nil, nil
+	}
+
+	teams, err := s.statsRepo.GetTeamsStats(ctx, filter)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		s.log.Warn(retErr.Error())
+		return nil, nil, retErr
+	}
+
+	return users, teams, nil
+}
+
+// checkFilterEntities checks whether the filter's team and user exist.
+func (s *StatsUseCase) checkFilterEntities(ctx context.Context, filter dto.StatsFilterDTO) error {
+	if len(filter.TeamName) != 0 {
+		if _, err := s.teamRepo.GetTeam(ctx, filter.TeamName); err != nil {
+			return err
+		}
+	}
+
+	if len(filter.UserID) != 0 {
+		if _, err := s.userRepo.GetUser(ctx, filter.UserID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
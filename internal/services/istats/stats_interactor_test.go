@@ -0,0 +1,193 @@
+package istats
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo/memory"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+var testStatsStart = time.Date(2025, time.January, 1, 10, 0, 0, 0, time.UTC)
+
+func makeTestStatsTeam(name string, ids ...entities.UserID) entities.Team {
+	team := entities.Team{Name: name}
+
+	for _, id := range ids {
+		team.Members = append(team.Members, entities.User{ID: id, Name: string(id), IsActive: true})
+	}
+
+	return team
+}
+
+// makeTestStatsUseCase returns the use-case over the teams 'backend' of the 'alice', 'bob' and 'carol'
+// and 'frontend' of the 'dave' with the PRs:
+// 'pr-1' of the 'alice' reviewed by the 'bob' and 'carol' and merged in an hour;
+// 'pr-2' of the 'alice' created two hours later and reviewed by the 'bob' who replaced the 'carol';
+// 'pr-3' of the 'dave' created three hours later without the reviewers.
+func makeTestStatsUseCase(t *testing.T) *StatsUseCase {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repository := memory.New(log)
+	ctx := context.Background()
+
+	for _, team := range []entities.Team{
+		makeTestStatsTeam("backend", "alice", "bob", "carol"),
+		makeTestStatsTeam("frontend", "dave"),
+	} {
+		if err := repository.CreateTeam(ctx, team); err != nil {
+			t.Fatalf("couldn't create the test team: %v", err)
+		}
+	}
+
+	at := func(offset time.Duration) *time.Time {
+		moment := testStatsStart.Add(offset)
+		return &moment
+	}
+
+	pullReqs := []dto.PullRequestDTO{
+		{
+			ID:        "pr-1",
+			Status:    entities.Merged,
+			CreatedAt: at(0),
+			MergedAt:  at(time.Hour),
+			AuthorID:  "alice",
+			Reviewers: []entities.UserID{"bob", "carol"},
+		},
+		{ID: "pr-2", Status: entities.Open, CreatedAt: at(2 * time.Hour), AuthorID: "alice", Reviewers: []entities.UserID{"bob"}},
+		{ID: "pr-3", Status: entities.Open, CreatedAt: at(3 * time.Hour), AuthorID: "dave"},
+	}
+
+	for _, pullReq := range pullReqs {
+		pullReq.Name = string(pullReq.ID)
+
+		if err := repository.CreatePullRequest(ctx, pullReq); err != nil {
+			t.Fatalf("couldn't create the test PR: %v", err)
+		}
+	}
+
+	err := repository.AddAssignmentsHistory(ctx, []dto.ReviewerAssignmentDTO{
+		{ID: "pr-2", NewReviewerID: "carol", Reason: entities.AssignmentCreated, ChangedAt: testStatsStart.Add(2 * time.Hour)},
+		{
+			ID:            "pr-2",
+			OldReviewerID: "carol",
+			NewReviewerID: "bob",
+			Reason:        entities.AssignmentReassigned,
+			ChangedAt:     testStatsStart.Add(2*time.Hour + time.Minute),
+		},
+	})
+	if err != nil {
+		t.Fatalf("couldn't add the test history: %v", err)
+	}
+
+	return NewStatsUseCase(log, repository, repository, repository, repository)
+}
+
+func TestGetStats(t *testing.T) {
+	useCase := makeTestStatsUseCase(t)
+
+	hour := time.Hour.Seconds()
+	from := testStatsStart.Add(90 * time.Minute)
+	to := testStatsStart.Add(3 * time.Hour)
+
+	tests := []struct {
+		name   string
+		filter dto.StatsFilterDTO
+		users  []dto.UserStatsDTO
+		teams  []dto.TeamStatsDTO
+	}{
+		{
+			name: "all",
+			users: []dto.UserStatsDTO{
+				{ID: "alice", TeamName: "backend"},
+				{ID: "bob", TeamName: "backend", ReviewStatsDTO: dto.ReviewStatsDTO{
+					Assigned: 2, Open: 1, Merged: 1, MeanMergeTime: &hour,
+				}},
+				{ID: "carol", TeamName: "backend", ReviewStatsDTO: dto.ReviewStatsDTO{
+					Assigned: 1, Merged: 1, Reassignments: 1, MeanMergeTime: &hour,
+				}},
+				{ID: "dave", TeamName: "frontend"},
+			},
+			teams: []dto.TeamStatsDTO{
+				{TeamName: "backend", ReviewStatsDTO: dto.ReviewStatsDTO{
+					Assigned: 2, Open: 1, Merged: 1, Reassignments: 1, MeanMergeTime: &hour,
+				}},
+				{TeamName: "frontend", ReviewStatsDTO: dto.ReviewStatsDTO{Assigned: 1, Open: 1}},
+			},
+		},
+		{
+			name:   "window",
+			filter: dto.StatsFilterDTO{From: &from, To: &to},
+			users: []dto.UserStatsDTO{
+				{ID: "alice", TeamName: "backend"},
+				{ID: "bob", TeamName: "backend", ReviewStatsDTO: dto.ReviewStatsDTO{Assigned: 1, Open: 1}},
+				{ID: "carol", TeamName: "backend", ReviewStatsDTO: dto.ReviewStatsDTO{Reassignments: 1}},
+				{ID: "dave", TeamName: "frontend"},
+			},
+			teams: []dto.TeamStatsDTO{
+				{TeamName: "backend", ReviewStatsDTO: dto.ReviewStatsDTO{Assigned: 1, Open: 1, Reassignments: 1}},
+				{TeamName: "frontend"},
+			},
+		},
+		{
+			name:   "team",
+			filter: dto.StatsFilterDTO{TeamName: "frontend"},
+			users:  []dto.UserStatsDTO{{ID: "dave", TeamName: "frontend"}},
+			teams: []dto.TeamStatsDTO{
+				{TeamName: "frontend", ReviewStatsDTO: dto.ReviewStatsDTO{Assigned: 1, Open: 1}},
+			},
+		},
+		{
+			name:   "user without the teams",
+			filter: dto.StatsFilterDTO{UserID: "bob"},
+			users: []dto.UserStatsDTO{
+				{ID: "bob", TeamName: "backend", ReviewStatsDTO: dto.ReviewStatsDTO{
+					Assigned: 2, Open: 1, Merged: 1, MeanMergeTime: &hour,
+				}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stats, err := useCase.GetStats(context.Background(), test.filter)
+			if err != nil {
+				t.Fatalf("couldn't get the stats: %v", err)
+			}
+
+			if stats.From != test.filter.From || stats.To != test.filter.To {
+				t.Fatalf("expected the filter's window returned, got %v %v", stats.From, stats.To)
+			}
+
+			if !reflect.DeepEqual(stats.Users, test.users) {
+				t.Fatalf("expected the users' stats %+v, got %+v", test.users, stats.Users)
+			}
+
+			if !reflect.DeepEqual(stats.Teams, test.teams) {
+				t.Fatalf("expected the teams' stats %+v, got %+v", test.teams, stats.Teams)
+			}
+		})
+	}
+}
+
+func TestGetStatsUnknownEntities(t *testing.T) {
+	useCase := makeTestStatsUseCase(t)
+
+	for _, filter := range []dto.StatsFilterDTO{
+		{TeamName: "unknown"},
+		{UserID: "unknown"},
+		{TeamName: "backend", UserID: "unknown"},
+	} {
+		if _, err := useCase.GetStats(context.Background(), filter); !errors.Is(err, services.ErrEntityNotFound) {
+			t.Fatalf("expected the %v for the filter %+v, got %v", services.ErrEntityNotFound, filter, err)
+		}
+	}
+}
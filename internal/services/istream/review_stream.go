@@ -0,0 +1,180 @@
+package istream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+// subscriberBuffer defines the count of the events kept for the subscriber not reading them yet.
+// The subscriber is dropped if its buffer is full.
+const subscriberBuffer = 32
+
+// subscriber defines the single stream of the reviewer's queue changes.
+type subscriber struct {
+	events chan dto.ReviewQueueEventDTO
+}
+
+// ReviewStreamUseCase defines the logic of streaming the changes of the reviewers' queues.
+// The changes are taken from the outbox's events published by the current service's instance.
+type ReviewStreamUseCase struct {
+	log      *slog.Logger
+	userRepo services.UserRepository
+
+	mut         sync.Mutex
+	subscribers map[entities.UserID]map[*subscriber]struct{}
+}
+
+func NewReviewStreamUseCase(log *slog.Logger, userRepo services.UserRepository) *ReviewStreamUseCase {
+	return &ReviewStreamUseCase{
+		log:         log,
+		userRepo:    userRepo,
+		subscribers: make(map[entities.UserID]map[*subscriber]struct{}, 50),
+	}
+}
+
+// GetReviewStreamEventTypes returns the types of the domain events changing the reviewers' queues.
+func GetReviewStreamEventTypes() []entities.DomainEventType {
+	return []entities.DomainEventType{
+		entities.ReviewerAssigned,
+		entities.ReviewerReassigned,
+		entities.PullRequestMerged,
+	}
+}
+
+// SubscribeReviewQueue defines the logic of subscribing to the changes of the reviewer's queue.
+// The channel is closed if the subscriber doesn't read the changes in time or the streams are closed.
+// The returned func must be called when the changes aren't needed anymore.
+func (r *ReviewStreamUseCase) SubscribeReviewQueue(
+	ctx context.Context,
+	id entities.UserID,
+) (<-chan dto.ReviewQueueEventDTO, func(), error) {
+	const op = "istream.subscribe-review-queue"
+
+	if _, err := r.userRepo.GetUser(ctx, id); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return nil, nil, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+		}
+		r.log.Warn(retErr.Error())
+
+		return nil, nil, retErr
+	}
+
+	sub := &subscriber{
+		events: make(chan dto.ReviewQueueEventDTO, subscriberBuffer),
+	}
+
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if _, ok := r.subscribers[id]; !ok {
+		r.subscribers[id] = make(map[*subscriber]struct{}, 1)
+	}
+	r.subscribers[id][sub] = struct{}{}
+
+	return sub.events, func() {
+		r.mut.Lock()
+		defer r.mut.Unlock()
+
+		r.removeSubscriber(id, sub)
+	}, nil
+}
+
+// CloseReviewStreams defines the logic of closing every subscriber's stream.
+func (r *ReviewStreamUseCase) CloseReviewStreams() {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	for id, subs := range r.subscribers {
+		for sub := range subs {
+			r.removeSubscriber(id, sub)
+		}
+	}
+}
+
+// HandleDomainEvent defines the logic of pushing the event's changes to the streams of the affected reviewers.
+func (r *ReviewStreamUseCase) HandleDomainEvent(ctx context.Context, event entities.DomainEvent) error {
+	const op = "istream.handle-domain-event"
+
+	data := dto.PullRequestEventDTO{}
+	if err := json.Unmarshal(event.Payload, &data); err != nil {
+		retErr := fmt.Errorf("error of the %s: wrong event's payload: %w", op, err)
+		r.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	switch event.Type {
+	case entities.ReviewerAssigned:
+		for _, id := range data.Reviewers {
+			r.push(event.ID, entities.ReviewAssigned, id, data.PullRequest)
+		}
+
+	case entities.ReviewerReassigned:
+		r.push(event.ID, entities.ReviewReassigned, data.OldReviewerID, data.PullRequest)
+		r.push(event.ID, entities.ReviewAssigned, data.NewReviewerID, data.PullRequest)
+
+	case entities.PullRequestMerged:
+		for _, id := range data.PullRequest.Reviewers {
+			r.push(event.ID, entities.ReviewMerged, id, data.PullRequest)
+		}
+	}
+
+	return nil
+}
+
+// push defines the logic of sending the change to every stream of the reviewer.
+// It must be called under the lock.
+func (r *ReviewStreamUseCase) push(
+	eventID entities.EventID,
+	eventType entities.ReviewQueueEventType,
+	id entities.UserID,
+	pullReq dto.PullRequestDTO,
+) {
+	const op = "istream.push"
+
+	subs := r.subscribers[id]
+	if len(subs) == 0 {
+		return
+	}
+
+	short := dto.MakePullRequestDTOShort(pullReq)
+	short.Decision = pullReq.Decisions[id]
+
+	for sub := range subs {
+		select {
+		case sub.events <- dto.ReviewQueueEventDTO{EventID: eventID, Event: eventType, PullRequest: short}:
+		default:
+			r.log.Warn(fmt.Sprintf("error of the %s: the stream of the user %s is dropped: it's too slow", op, id))
+			r.removeSubscriber(id, sub)
+		}
+	}
+}
+
+// removeSubscriber defines the logic of closing the subscriber's stream if it's still open.
+// It must be called under the lock.
+func (r *ReviewStreamUseCase) removeSubscriber(id entities.UserID, sub *subscriber) {
+	subs := r.subscribers[id]
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	close(sub.events)
+
+	if len(subs) == 0 {
+		delete(r.subscribers, id)
+	}
+}
@@ -10,25 +10,34 @@ import (
 	"github.com/MaKcm14/pr-service/internal/entities/dto"
 	"github.com/MaKcm14/pr-service/internal/repo"
 	"github.com/MaKcm14/pr-service/internal/services"
+	"go.opentelemetry.io/otel"
 )
 
+// tracer defines the tracer of the teams' use-cases' spans.
+var tracer = otel.Tracer("github.com/MaKcm14/pr-service/internal/services/iteam")
+
 // TeamUseCase defines the logic of the use-cases more connected with the teams.
 type TeamUseCase struct {
 	log  *slog.Logger
+	tx   services.TxManager
 	repo services.TeamRepository
 }
 
-func NewTeamUseCase(log *slog.Logger, repo services.TeamRepository) *TeamUseCase {
+func NewTeamUseCase(log *slog.Logger, tx services.TxManager, repo services.TeamRepository) *TeamUseCase {
 	return &TeamUseCase{
 		log:  log,
+		tx:   tx,
 		repo: repo,
 	}
 }
 
 // GetTeam defines the logic of getting the team from the repository.
-func (t *TeamUseCase) GetTeam(ctx context.Context, teamName string) (dto.TeamDTO, error) {
+func (t *TeamUseCase) GetTeam(ctx context.Context, teamName string) (_ dto.TeamDTO, err error) {
 	const op = "iteam.get-team"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { services.EndSpan(span, err) }()
+
 	team, err := t.repo.GetTeam(ctx, teamName)
 	if err != nil {
 		retErr := fmt.Errorf("error of the %s: %w: %s", op, services.ErrRepositoryInteraction, err)
@@ -45,18 +54,201 @@ func (t *TeamUseCase) GetTeam(ctx context.Context, teamName string) (dto.TeamDTO
 }
 
 // CreateTeam defines the logic of creating the team in the repository.
-func (t *TeamUseCase) CreateTeam(ctx context.Context, dto entities.Team) error {
+// The members of the already existing team are updated anyway if the request's principal may change them.
+// The omitted member's role keeps the current one and only the admin may change the roles.
+// The team is returned as it was kept.
+func (t *TeamUseCase) CreateTeam(ctx context.Context, team entities.Team) (_ dto.TeamDTO, err error) {
 	const op = "iteam.create-team"
 
-	if err := t.repo.CreateTeam(ctx, dto); err != nil {
-		retErr := fmt.Errorf("error of the %s: %w", op, err)
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { services.EndSpan(span, err) }()
+
+	var (
+		res       entities.Team
+		existsErr error
+	)
+
+	err = t.tx.WithinTx(ctx, services.ReadCommitted, func(ctx context.Context) error {
+		existsErr = nil
+
+		if err := t.authorizeTeamCreation(ctx, team); err != nil {
+			return fmt.Errorf("error of the %s: %w", op, err)
+		}
+
+		if err := t.repo.CreateTeam(ctx, team); errors.Is(err, repo.ErrModelAlreadyExists) {
+			existsErr = fmt.Errorf("error of the %s: %w", op, err)
+		} else if err != nil {
+			retErr := fmt.Errorf("error of the %s: %w", op, err)
+			t.log.Warn(retErr.Error())
+			return retErr
+		}
+
+		var err error
+		if res, err = t.repo.GetTeam(ctx, team.Name); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+			t.log.Warn(retErr.Error())
+			return retErr
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return dto.TeamDTO{}, err
+	}
+
+	return dto.TeamToTeamDTO(res), existsErr
+}
+
+// authorizeTeamCreation checks the request's principal may create the team or change the members of the existing one
+// and may set the members' roles the request has.
+func (t *TeamUseCase) authorizeTeamCreation(ctx context.Context, team entities.Team) error {
+	const op = "iteam.authorize-team-creation"
+
+	if principal, ok := services.GetPrincipal(ctx); !ok || principal.IsAdmin {
+		return nil
+	}
+
+	current, err := t.repo.GetTeam(ctx, team.Name)
+	if err != nil && !errors.Is(err, repo.ErrModelNotFound) {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
 		t.log.Warn(retErr.Error())
 		return retErr
+	} else if err == nil {
+		if err := services.AuthorizeMembershipEdit(ctx, current); err != nil {
+			return fmt.Errorf("error of the %s: %w", op, err)
+		}
 	}
 
+	if err := services.AuthorizeRolesChange(ctx, current, team.Members); err != nil {
+		return fmt.Errorf("error of the %s: %w", op, err)
+	}
 	return nil
 }
 
+// SetTeamReviewerStrategy defines the logic of changing the team's strategy of choosing the reviewers.
+// Only the team's lead or the admin may change it if the request has the principal.
+func (t *TeamUseCase) SetTeamReviewerStrategy(
+	ctx context.Context,
+	name string,
+	strategy entities.ReviewerStrategy,
+) (_ dto.TeamDTO, err error) {
+	const op = "iteam.set-team-reviewer-strategy"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { services.EndSpan(span, err) }()
+
+	var team entities.Team
+
+	err = t.tx.WithinTx(ctx, services.ReadCommitted, func(ctx context.Context) error {
+		if err := services.AuthorizeTeamEdit(ctx, t.repo, name); err != nil {
+			return fmt.Errorf("error of the %s: %w", op, err)
+		}
+
+		var err error
+		if team, err = t.repo.SetTeamReviewerStrategy(ctx, name, strategy); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+			if errors.Is(err, repo.ErrModelNotFound) {
+				return fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+			}
+			t.log.Warn(retErr.Error())
+
+			return retErr
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return dto.TeamDTO{}, err
+	}
+
+	return dto.TeamToTeamDTO(team), nil
+}
+
+// SetTeamReviewerPolicy defines the logic of changing the team's bounds of the PR's reviewers count.
+// Only the team's lead or the admin may change them if the request has the principal.
+func (t *TeamUseCase) SetTeamReviewerPolicy(
+	ctx context.Context,
+	name string,
+	policy entities.ReviewerPolicy,
+) (_ dto.TeamDTO, err error) {
+	const op = "iteam.set-team-reviewer-policy"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { services.EndSpan(span, err) }()
+
+	var team entities.Team
+
+	err = t.tx.WithinTx(ctx, services.ReadCommitted, func(ctx context.Context) error {
+		if err := services.AuthorizeTeamEdit(ctx, t.repo, name); err != nil {
+			return fmt.Errorf("error of the %s: %w", op, err)
+		}
+
+		var err error
+		if team, err = t.repo.SetTeamReviewerPolicy(ctx, name, policy); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+			if errors.Is(err, repo.ErrModelNotFound) {
+				return fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+			}
+			t.log.Warn(retErr.Error())
+
+			return retErr
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return dto.TeamDTO{}, err
+	}
+
+	return dto.TeamToTeamDTO(team), nil
+}
+
+// SetTeamMergePolicy defines the logic of changing the team's conditions of the PR's merge.
+// Only the team's lead or the admin may change them if the request has the principal.
+func (t *TeamUseCase) SetTeamMergePolicy(
+	ctx context.Context,
+	name string,
+	policy entities.MergePolicy,
+) (_ dto.TeamDTO, err error) {
+	const op = "iteam.set-team-merge-policy"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { services.EndSpan(span, err) }()
+
+	var team entities.Team
+
+	err = t.tx.WithinTx(ctx, services.ReadCommitted, func(ctx context.Context) error {
+		if err := services.AuthorizeTeamEdit(ctx, t.repo, name); err != nil {
+			return fmt.Errorf("error of the %s: %w", op, err)
+		}
+
+		var err error
+		if team, err = t.repo.SetTeamMergePolicy(ctx, name, policy); err != nil {
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+			if errors.Is(err, repo.ErrModelNotFound) {
+				return fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+			}
+			t.log.Warn(retErr.Error())
+
+			return retErr
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return dto.TeamDTO{}, err
+	}
+
+	return dto.TeamToTeamDTO(team), nil
+}
+
 func (t *TeamUseCase) Close() {
 	t.repo.Close()
 }
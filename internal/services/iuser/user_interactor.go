@@ -9,36 +9,100 @@ import (
 	"github.com/MaKcm14/pr-service/internal/entities"
 	"github.com/MaKcm14/pr-service/internal/repo"
 	"github.com/MaKcm14/pr-service/internal/services"
+	"go.opentelemetry.io/otel"
 )
 
+// tracer defines the tracer of the users' use-cases' spans.
+var tracer = otel.Tracer("github.com/MaKcm14/pr-service/internal/services/iuser")
+
 type UserUseCase struct {
-	log  *slog.Logger
-	repo services.UserRepository
+	log      *slog.Logger
+	tx       services.TxManager
+	repo     services.UserRepository
+	teamRepo services.TeamRepository
 }
 
-func NewUserUseCase(log *slog.Logger, repo services.UserRepository) *UserUseCase {
+func NewUserUseCase(
+	log *slog.Logger,
+	tx services.TxManager,
+	repo services.UserRepository,
+	teamRepo services.TeamRepository,
+) *UserUseCase {
 	return &UserUseCase{
-		log:  log,
-		repo: repo,
+		log:      log,
+		tx:       tx,
+		repo:     repo,
+		teamRepo: teamRepo,
 	}
 }
 
-func (u *UserUseCase) SetUserIsActive(ctx context.Context, isActive bool, id entities.UserID) (entities.User, error) {
+// SetUserIsActive defines the logic of changing the user's activity.
+// Only the lead of the user's team or the admin may change it if the request has the principal.
+func (u *UserUseCase) SetUserIsActive(
+	ctx context.Context,
+	isActive bool,
+	id entities.UserID,
+) (_ entities.User, err error) {
 	const op = "iuser.set-user-is-active"
 
-	user, err := u.repo.SetUserIsActive(ctx, isActive, id)
+	ctx, span := tracer.Start(ctx, op)
+	defer func() { services.EndSpan(span, err) }()
+
+	var user entities.User
+
+	err = u.tx.WithinTx(ctx, services.ReadCommitted, func(ctx context.Context) error {
+		if err := u.authorizeUserEdit(ctx, id); err != nil {
+			return fmt.Errorf("error of the %s: %w", op, err)
+		}
+
+		var err error
+
+		user, err = u.repo.SetUserIsActive(ctx, isActive, id)
+		if err != nil {
+			if errors.Is(err, repo.ErrModelNotFound) {
+				return fmt.Errorf(
+					"error of the %s: %w: %w", op, services.ErrEntityNotFound, err,
+				)
+			}
+			retErr := fmt.Errorf("error of the %s: %w: %w", op, err, services.ErrRepositoryInteraction)
+			u.log.Warn(retErr.Error())
+			return retErr
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	return user, nil
+}
+
+// authorizeUserEdit checks the request's principal may change the user as the member of the user's team.
+func (u *UserUseCase) authorizeUserEdit(ctx context.Context, id entities.UserID) error {
+	const op = "iuser.authorize-user-edit"
+
+	if principal, ok := services.GetPrincipal(ctx); !ok || principal.IsAdmin {
+		return nil
+	}
+
+	user, err := u.repo.GetUser(ctx, id)
 	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
 		if errors.Is(err, repo.ErrModelNotFound) {
-			return entities.User{}, fmt.Errorf(
-				"error of the %s: %w: %w", op, services.ErrEntityNotFound, err,
-			)
+			return fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
 		}
-		retErr := fmt.Errorf("error of the %s: %w: %w", op, err, services.ErrRepositoryInteraction)
 		u.log.Warn(retErr.Error())
-		return entities.User{}, retErr
+
+		return retErr
 	}
 
-	return user, nil
+	if err := services.AuthorizeTeamEdit(ctx, u.teamRepo, user.TeamName); err != nil {
+		return fmt.Errorf("error of the %s: %w", op, err)
+	}
+	return nil
 }
 
 func (u *UserUseCase) Close() {
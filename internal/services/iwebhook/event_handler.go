@@ -0,0 +1,98 @@
+package iwebhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+// webhookEvents defines the webhooks' events sent for the domain events.
+var webhookEvents = map[entities.DomainEventType]entities.WebhookEventType{
+	entities.ReviewerAssigned:   entities.ReviewerAssignedEvent,
+	entities.ReviewerReassigned: entities.ReviewerReassignedEvent,
+	entities.PullRequestMerged:  entities.PullRequestMergedEvent,
+}
+
+// GetWebhookEventTypes returns the types of the domain events sent to the teams' webhooks.
+func GetWebhookEventTypes() []entities.DomainEventType {
+	return slices.Collect(maps.Keys(webhookEvents))
+}
+
+// HandleDomainEvent defines the logic of queueing the event's deliveries to every webhook of the event's team.
+// The repeated event doesn't queue the deliveries again.
+func (w *WebhookUseCase) HandleDomainEvent(ctx context.Context, event entities.DomainEvent) error {
+	const op = "iwebhook.handle-domain-event"
+
+	eventType, ok := webhookEvents[event.Type]
+	if !ok {
+		return nil
+	}
+
+	webhooks, err := w.webhookRepo.GetTeamWebhooks(ctx, event.TeamName)
+	if errors.Is(err, repo.ErrModelNotFound) {
+		return nil
+	} else if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		w.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	data := dto.PullRequestEventDTO{}
+	if err := json.Unmarshal(event.Payload, &data); err != nil {
+		retErr := fmt.Errorf("error of the %s: wrong event's payload: %w", op, err)
+		w.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	payload, err := json.Marshal(dto.WebhookEventDTO{
+		Event:         eventType,
+		TeamName:      event.TeamName,
+		PullRequest:   data.PullRequest,
+		Reviewers:     data.Reviewers,
+		OldReviewerID: data.OldReviewerID,
+		NewReviewerID: data.NewReviewerID,
+		ActorID:       data.ActorID,
+		OccurredAt:    event.OccurredAt,
+	})
+	if err != nil {
+		return fmt.Errorf("error of the %s: %w", op, err)
+	}
+
+	now := time.Now()
+
+	deliveries := make([]entities.WebhookDelivery, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		deliveries = append(deliveries, entities.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			TeamName:      webhook.TeamName,
+			URL:           webhook.URL,
+			Event:         eventType,
+			Payload:       payload,
+			Status:        entities.DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+
+	if err := w.webhookRepo.AddWebhookDeliveries(ctx, deliveries); err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		w.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	return nil
+}
@@ -0,0 +1,222 @@
+package iwebhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/entities/dto"
+	"github.com/MaKcm14/pr-service/internal/repo"
+	"github.com/MaKcm14/pr-service/internal/services"
+)
+
+const (
+	// deliveryBatchSize defines the max count of the deliveries sent at once.
+	deliveryBatchSize = 50
+
+	// deliveryLockTimeout defines the time the claimed delivery isn't given to the other senders.
+	// The delivery isn't lost if its sender fails: it's claimed again after the timeout.
+	deliveryLockTimeout = time.Minute
+
+	// sendTimeout defines the max duration of the single delivery's attempt.
+	sendTimeout = 10 * time.Second
+
+	// maxDeliveriesLimit defines the max count of the deliveries returned for the inspection.
+	maxDeliveriesLimit = 500
+)
+
+// WebhookUseCase defines the logic of the use-cases connected with the teams' webhooks.
+type WebhookUseCase struct {
+	log         *slog.Logger
+	tx          services.TxManager
+	webhookRepo services.WebhookRepository
+	sender      services.WebhookSender
+}
+
+func NewWebhookUseCase(
+	log *slog.Logger,
+	tx services.TxManager,
+	webhookRepo services.WebhookRepository,
+	sender services.WebhookSender,
+) *WebhookUseCase {
+	return &WebhookUseCase{
+		log:         log,
+		tx:          tx,
+		webhookRepo: webhookRepo,
+		sender:      sender,
+	}
+}
+
+// AddTeamWebhook defines the logic of registering the team's webhook.
+// The webhook's secret isn't returned.
+func (w *WebhookUseCase) AddTeamWebhook(ctx context.Context, webhook entities.Webhook) (entities.Webhook, error) {
+	const op = "iwebhook.add-team-webhook"
+
+	var res entities.Webhook
+
+	err := w.tx.WithinTx(ctx, services.ReadCommitted, func(ctx context.Context) error {
+		var err error
+		res, err = w.webhookRepo.AddTeamWebhook(ctx, webhook)
+		return err
+	})
+
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return entities.Webhook{}, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+		}
+		w.log.Warn(retErr.Error())
+
+		return entities.Webhook{}, retErr
+	}
+	res.Secret = ""
+
+	return res, nil
+}
+
+// RemoveTeamWebhook defines the logic of removing the team's webhook with its deliveries.
+func (w *WebhookUseCase) RemoveTeamWebhook(ctx context.Context, teamName string, id entities.WebhookID) error {
+	const op = "iwebhook.remove-team-webhook"
+
+	err := w.tx.WithinTx(ctx, services.ReadCommitted, func(ctx context.Context) error {
+		return w.webhookRepo.DeleteTeamWebhook(ctx, teamName, id)
+	})
+
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+		}
+		w.log.Warn(retErr.Error())
+
+		return retErr
+	}
+
+	return nil
+}
+
+// GetTeamWebhooks defines the logic of getting the team's webhooks without their secrets.
+func (w *WebhookUseCase) GetTeamWebhooks(ctx context.Context, teamName string) ([]entities.Webhook, error) {
+	const op = "iwebhook.get-team-webhooks"
+
+	res, err := w.webhookRepo.GetTeamWebhooks(ctx, teamName)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return nil, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+		}
+		w.log.Warn(retErr.Error())
+
+		return nil, retErr
+	}
+
+	for i := range res {
+		res[i].Secret = ""
+	}
+
+	return res, nil
+}
+
+// GetWebhookDeliveries defines the logic of getting the webhooks' deliveries from the newest ones.
+func (w *WebhookUseCase) GetWebhookDeliveries(
+	ctx context.Context,
+	filter dto.WebhookDeliveriesFilterDTO,
+) ([]entities.WebhookDelivery, error) {
+	const op = "iwebhook.get-webhook-deliveries"
+
+	if filter.Limit <= 0 || filter.Limit > maxDeliveriesLimit {
+		filter.Limit = maxDeliveriesLimit
+	}
+
+	res, err := w.webhookRepo.GetWebhookDeliveries(ctx, filter)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+
+		if errors.Is(err, repo.ErrModelNotFound) {
+			return nil, fmt.Errorf("error of the %s: %w: %w", op, services.ErrEntityNotFound, err)
+		}
+		w.log.Warn(retErr.Error())
+
+		return nil, retErr
+	}
+
+	return res, nil
+}
+
+// RunWebhookDeliveries defines the logic of sending the queued deliveries every interval until the ctx is done.
+func (w *WebhookUseCase) RunWebhookDeliveries(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			count, err := w.SendWebhookDeliveries(ctx)
+			if err != nil || count < deliveryBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendWebhookDeliveries defines the logic of sending the batch of the deliveries due by now.
+// The failed delivery is scheduled for the next attempt or becomes dead.
+// The count of the claimed deliveries is returned.
+func (w *WebhookUseCase) SendWebhookDeliveries(ctx context.Context) (int, error) {
+	const op = "iwebhook.send-webhook-deliveries"
+
+	now := time.Now()
+
+	deliveries, err := w.webhookRepo.ClaimWebhookDeliveries(ctx, now, now.Add(deliveryLockTimeout), deliveryBatchSize)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, services.ErrRepositoryInteraction, err)
+		w.log.Warn(retErr.Error())
+		return 0, retErr
+	}
+
+	for _, delivery := range deliveries {
+		w.sendWebhookDelivery(ctx, delivery)
+	}
+
+	return len(deliveries), nil
+}
+
+// sendWebhookDelivery defines the logic of the single delivery's attempt.
+// The delivery is successful only if the webhook responded with the 2xx status code.
+func (w *WebhookUseCase) sendWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) {
+	const op = "iwebhook.send-webhook-delivery"
+
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	code, err := w.sender.SendWebhook(sendCtx, delivery)
+
+	if err != nil {
+		delivery.SetFailed(time.Now(), code, err.Error())
+	} else if code < http.StatusOK || code >= http.StatusMultipleChoices {
+		delivery.SetFailed(time.Now(), code, fmt.Sprintf("unexpected status code %d", code))
+	} else {
+		delivery.SetDelivered(time.Now(), code)
+	}
+
+	if delivery.Status == entities.DeliveryDead {
+		w.log.Warn(fmt.Sprintf("error of the %s: the delivery %d to the %s is dead after %d attempts: %s",
+			op, delivery.ID, delivery.URL, delivery.Attempts, delivery.LastError))
+	}
+
+	if err := w.webhookRepo.UpdateWebhookDelivery(context.WithoutCancel(ctx), delivery); err != nil {
+		w.log.Warn(fmt.Sprintf("error of the %s: %s: %s", op, services.ErrRepositoryInteraction, err))
+	}
+}
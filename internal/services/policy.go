@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/MaKcm14/pr-service/internal/entities"
+	"github.com/MaKcm14/pr-service/internal/repo"
+)
+
+// AuthorizeMerge checks the request's principal may merge the PR: only the PR's author,
+// the lead of the author's team or the admin may do it.
+func AuthorizeMerge(ctx context.Context, pullReq entities.PullRequest, authorTeam entities.Team) error {
+	principal, ok := GetPrincipal(ctx)
+	if !ok || principal.IsAdmin {
+		return nil
+	}
+
+	if isAuthor(principal, pullReq) || isLead(principal, authorTeam) {
+		return nil
+	}
+	return fmt.Errorf("%w: only the author, the team's lead or the admin may merge the PR", ErrForbidden)
+}
+
+// AuthorizeReassign checks the request's principal may reassign the PR's reviewer: only the PR's reviewer,
+// the PR's author, the lead of the author's team or the admin may do it.
+func AuthorizeReassign(ctx context.Context, pullReq entities.PullRequest, authorTeam entities.Team) error {
+	principal, ok := GetPrincipal(ctx)
+	if !ok || principal.IsAdmin {
+		return nil
+	}
+
+	if _, isReviewer := pullReq.Reviewers[principal.UserID]; (isReviewer && len(principal.UserID) != 0) ||
+		isAuthor(principal, pullReq) || isLead(principal, authorTeam) {
+		return nil
+	}
+	return fmt.Errorf("%w: only the reviewer, the author, the team's lead or the admin may reassign the PR", ErrForbidden)
+}
+
+// AuthorizeReview checks the request's principal may leave the decision on behalf of the reviewer:
+// only the reviewer or the admin may do it.
+func AuthorizeReview(ctx context.Context, reviewerID entities.UserID) error {
+	principal, ok := GetPrincipal(ctx)
+	if !ok || principal.IsAdmin {
+		return nil
+	}
+
+	if len(principal.UserID) != 0 && principal.UserID == reviewerID {
+		return nil
+	}
+	return fmt.Errorf("%w: only the reviewer or the admin may leave the reviewer's decision", ErrForbidden)
+}
+
+// AuthorizeMembershipEdit checks the request's principal may change the members or the settings
+// of the existing team: only the team's lead or the admin may do it.
+func AuthorizeMembershipEdit(ctx context.Context, team entities.Team) error {
+	principal, ok := GetPrincipal(ctx)
+	if !ok || principal.IsAdmin {
+		return nil
+	}
+
+	if isLead(principal, team) {
+		return nil
+	}
+	return fmt.Errorf("%w: only the team's lead or the admin may change the team", ErrForbidden)
+}
+
+// AuthorizeRolesChange checks the request's principal may set the roles of the team's members:
+// only the admin may change them. The member without the role keeps the current one and
+// the current role of the user that isn't the team's member yet is the member's one.
+func AuthorizeRolesChange(ctx context.Context, current entities.Team, members []entities.User) error {
+	principal, ok := GetPrincipal(ctx)
+	if !ok || principal.IsAdmin {
+		return nil
+	}
+
+	for _, member := range members {
+		if len(member.Role) == 0 {
+			continue
+		}
+
+		role := entities.MemberRole
+		if i := slices.IndexFunc(current.Members, func(user entities.User) bool {
+			return user.ID == member.ID
+		}); i != -1 {
+			role = current.Members[i].GetRole()
+		}
+
+		if member.Role != role {
+			return fmt.Errorf("%w: only the admin may change the members' roles", ErrForbidden)
+		}
+	}
+
+	return nil
+}
+
+// AuthorizeTeamEdit checks the request's principal may change the members or the settings of the team
+// read from the repository by its name. The ErrEntityNotFound is returned for the unknown team.
+func AuthorizeTeamEdit(ctx context.Context, teams TeamRepository, name string) error {
+	if principal, ok := GetPrincipal(ctx); !ok || principal.IsAdmin {
+		return nil
+	}
+
+	team, err := teams.GetTeam(ctx, name)
+	if errors.Is(err, repo.ErrModelNotFound) {
+		return fmt.Errorf("%w: %w", ErrEntityNotFound, err)
+	} else if err != nil {
+		return fmt.Errorf("%w: %w", ErrRepositoryInteraction, err)
+	}
+
+	return AuthorizeMembershipEdit(ctx, team)
+}
+
+func isAuthor(principal Principal, pullReq entities.PullRequest) bool {
+	return len(principal.UserID) != 0 && principal.UserID == pullReq.Author.ID
+}
+
+func isLead(principal Principal, team entities.Team) bool {
+	return len(principal.UserID) != 0 && team.IsLead(principal.UserID)
+}
@@ -2,18 +2,39 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/MaKcm14/pr-service/internal/entities"
 	"github.com/MaKcm14/pr-service/internal/entities/dto"
 )
 
+const (
+	ReadCommitted  IsolationLevel = "READ COMMITTED"
+	RepeatableRead IsolationLevel = "REPEATABLE READ"
+	Serializable   IsolationLevel = "SERIALIZABLE"
+)
+
+// IsolationLevel defines the transaction's isolation level.
+type IsolationLevel string
+
 type (
+	// TxManager defines the abstraction of running the group of the repositories' ops
+	// inside the single transaction. The transaction is passed through the ctx given to the fn,
+	// so every repository's op called with it is the part of the transaction.
+	// Nested calls join the already started transaction.
+	TxManager interface {
+		WithinTx(ctx context.Context, level IsolationLevel, fn func(ctx context.Context) error) error
+	}
+
 	// TeamRepository defines the abstraction of the team's model ops interaction.
 	TeamRepository interface {
 		Closer
 
 		GetTeam(ctx context.Context, name string) (entities.Team, error)
 		CreateTeam(ctx context.Context, team entities.Team) error
+		SetTeamReviewerStrategy(ctx context.Context, name string, strategy entities.ReviewerStrategy) (entities.Team, error)
+		SetTeamReviewerPolicy(ctx context.Context, name string, policy entities.ReviewerPolicy) (entities.Team, error)
+		SetTeamMergePolicy(ctx context.Context, name string, policy entities.MergePolicy) (entities.Team, error)
 	}
 
 	// UserRepository defines the abstraction of the user's model ops interaction.
@@ -22,8 +43,11 @@ type (
 
 		SetUserIsActive(ctx context.Context, isActive bool, id entities.UserID) (entities.User, error)
 		GetUser(ctx context.Context, id entities.UserID) (entities.User, error)
+		GetUsers(ctx context.Context, ids []entities.UserID) ([]entities.User, error)
+		SetTeamUsersIsActive(ctx context.Context, teamName string, isActive bool, ids []entities.UserID) error
 	}
 
+	// PullRequestRepository defines the abstraction of the pull-request's model ops interaction.
 	PullRequestRepository interface {
 		Closer
 
@@ -32,6 +56,82 @@ type (
 		GetUserPullRequests(ctx context.Context, id entities.UserID) ([]dto.PullRequestDTOShort, error)
 		GetPullRequest(ctx context.Context, id entities.PullRequestID) (dto.PullRequestDTO, error)
 		ChangeReviewer(ctx context.Context, lastID entities.UserID, newID entities.UserID, pullReq dto.PullRequestDTO) error
+		AddPullRequestReviewers(ctx context.Context, pullReq dto.PullRequestDTO) error
+		ChangeReviewers(ctx context.Context, changes []dto.ReviewerChangeDTO) error
+		AddAssignmentsHistory(ctx context.Context, records []dto.ReviewerAssignmentDTO) error
+		GetPullRequestHistory(ctx context.Context, id entities.PullRequestID) ([]dto.ReviewerAssignmentDTO, error)
+		GetReviewersLoad(ctx context.Context, teamName string) (entities.ReviewerLoad, error)
+		GetReviewersOpenPullRequests(ctx context.Context, ids []entities.UserID) ([]dto.PullRequestDTO, error)
+		AddReviewDecision(ctx context.Context, review dto.PullRequestReviewDTO) error
+		ListPullRequests(ctx context.Context, filter dto.PullRequestsFilterDTO) ([]dto.PullRequestDTO, error)
+	}
+
+	// StatsRepository defines the abstraction of the review's statistics calculation.
+	StatsRepository interface {
+		GetUsersStats(ctx context.Context, filter dto.StatsFilterDTO) ([]dto.UserStatsDTO, error)
+		GetTeamsStats(ctx context.Context, filter dto.StatsFilterDTO) ([]dto.TeamStatsDTO, error)
+	}
+
+	// IdempotencyRepository defines the abstraction of keeping the responses for the clients' idempotency keys.
+	IdempotencyRepository interface {
+		ReserveIdempotencyKey(
+			ctx context.Context,
+			record dto.IdempotencyRecordDTO,
+			staleBefore time.Time,
+			expiredBefore time.Time,
+		) (dto.IdempotencyRecordDTO, bool, error)
+		SaveIdempotentResponse(ctx context.Context, record dto.IdempotencyRecordDTO) error
+		DeleteIdempotencyKey(ctx context.Context, key string) error
+	}
+
+	// WebhookRepository defines the abstraction of the teams' webhooks and their deliveries' queue.
+	WebhookRepository interface {
+		AddTeamWebhook(ctx context.Context, webhook entities.Webhook) (entities.Webhook, error)
+		DeleteTeamWebhook(ctx context.Context, teamName string, id entities.WebhookID) error
+		GetTeamWebhooks(ctx context.Context, teamName string) ([]entities.Webhook, error)
+		AddWebhookDeliveries(ctx context.Context, deliveries []entities.WebhookDelivery) error
+		ClaimWebhookDeliveries(ctx context.Context, now time.Time, lockUntil time.Time, limit int) ([]entities.WebhookDelivery, error)
+		UpdateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery) error
+		GetWebhookDeliveries(ctx context.Context, filter dto.WebhookDeliveriesFilterDTO) ([]entities.WebhookDelivery, error)
+	}
+
+	// WebhookSender defines the abstraction of sending the event's delivery to the webhook's URL.
+	// The response's status code is returned if the webhook responded.
+	WebhookSender interface {
+		SendWebhook(ctx context.Context, delivery entities.WebhookDelivery) (int, error)
+	}
+
+	// OutboxRepository defines the abstraction of the domain events' outbox.
+	// The events are added inside the transaction of the change they describe.
+	OutboxRepository interface {
+		AddOutboxEvents(ctx context.Context, events []entities.DomainEvent) error
+		ClaimOutboxEvents(ctx context.Context, now time.Time, lockUntil time.Time, limit int) ([]entities.DomainEvent, error)
+		SetOutboxEventPublished(ctx context.Context, id entities.EventID, publishedAt time.Time) error
+		SetOutboxEventFailed(ctx context.Context, id entities.EventID, reason string) error
+	}
+
+	// TokenRepository defines the abstraction of the API tokens stored by their secrets' hashes.
+	TokenRepository interface {
+		AddAPIToken(ctx context.Context, token entities.APIToken, hash string) (entities.APIToken, error)
+		RevokeAPIToken(ctx context.Context, id entities.TokenID, revokedAt time.Time) error
+		GetAPITokens(ctx context.Context) ([]entities.APIToken, error)
+		GetAPITokenByHash(ctx context.Context, hash string) (entities.APIToken, error)
+	}
+
+	// EventSink defines the abstraction of the destination the outbox's events are published to.
+	// The event can be published more than once, so the sink must tolerate the duplicates.
+	EventSink interface {
+		PublishEvent(ctx context.Context, event entities.DomainEvent) error
+	}
+
+	// MetricsRecorder defines the abstraction of recording the domain's metrics of the use-cases.
+	MetricsRecorder interface {
+		RecordNoCandidate(operation string)
+	}
+
+	// Pinger defines the abstraction of checking the dependency's availability.
+	Pinger interface {
+		Ping(ctx context.Context) error
 	}
 
 	Closer interface {
@@ -4,27 +4,71 @@ import (
 	"log/slog"
 
 	"github.com/MaKcm14/pr-service/internal/services"
+	"github.com/MaKcm14/pr-service/internal/services/iauth"
+	"github.com/MaKcm14/pr-service/internal/services/ihealth"
+	"github.com/MaKcm14/pr-service/internal/services/iidem"
+	"github.com/MaKcm14/pr-service/internal/services/ioutbox"
 	"github.com/MaKcm14/pr-service/internal/services/ipreq"
+	"github.com/MaKcm14/pr-service/internal/services/istats"
+	"github.com/MaKcm14/pr-service/internal/services/istream"
 	"github.com/MaKcm14/pr-service/internal/services/iteam"
 	"github.com/MaKcm14/pr-service/internal/services/iuser"
+	"github.com/MaKcm14/pr-service/internal/services/iwebhook"
 )
 
 type UseCase struct {
 	*ipreq.PullRequestUseCase
 	*iteam.TeamUseCase
 	*iuser.UserUseCase
+	*istats.StatsUseCase
+	*ihealth.HealthUseCase
+	*iidem.IdempotencyUseCase
+	*iwebhook.WebhookUseCase
+	*ioutbox.OutboxUseCase
+	*istream.ReviewStreamUseCase
+	*iauth.AuthUseCase
 }
 
-func NewUseCase(
-	log *slog.Logger,
-	teamRepo services.TeamRepository,
-	prRepo services.PullRequestRepository,
-	userRepo services.UserRepository,
-) UseCase {
+// Deps defines the dependencies of the use-cases.
+// The Sinks, the Metrics and the Pingers are optional: without them the outbox's events
+// are published only to the in-process subscribers, the metrics aren't recorded
+// and the readiness doesn't check any dependency.
+type Deps struct {
+	Tx          services.TxManager
+	TeamRepo    services.TeamRepository
+	PrRepo      services.PullRequestRepository
+	UserRepo    services.UserRepository
+	StatsRepo   services.StatsRepository
+	IdemRepo    services.IdempotencyRepository
+	WebhookRepo services.WebhookRepository
+	OutboxRepo  services.OutboxRepository
+	TokenRepo   services.TokenRepository
+	Sender      services.WebhookSender
+	Sinks       []services.EventSink
+	Metrics     services.MetricsRecorder
+	Pingers     map[string]services.Pinger
+}
+
+func NewUseCase(log *slog.Logger, deps Deps) UseCase {
+	webhookUseCase := iwebhook.NewWebhookUseCase(log, deps.Tx, deps.WebhookRepo, deps.Sender)
+	streamUseCase := istream.NewReviewStreamUseCase(log, deps.UserRepo)
+
+	// The teams' webhooks and the reviewers' streams are the in-process subscribers of the outbox's events.
+	subscribers := ioutbox.NewSubscriberSink()
+	subscribers.Subscribe(webhookUseCase.HandleDomainEvent, iwebhook.GetWebhookEventTypes()...)
+	subscribers.Subscribe(streamUseCase.HandleDomainEvent, istream.GetReviewStreamEventTypes()...)
+
 	return UseCase{
-		PullRequestUseCase: ipreq.NewPullRequestUseCase(log, prRepo, userRepo, teamRepo),
-		TeamUseCase:        iteam.NewTeamUseCase(log, teamRepo),
-		UserUseCase:        iuser.NewUserUseCase(log, userRepo),
+		PullRequestUseCase:  ipreq.NewPullRequestUseCase(log, deps.Tx, deps.PrRepo, deps.UserRepo, deps.TeamRepo, deps.OutboxRepo, deps.Metrics),
+		TeamUseCase:         iteam.NewTeamUseCase(log, deps.Tx, deps.TeamRepo),
+		UserUseCase:         iuser.NewUserUseCase(log, deps.Tx, deps.UserRepo, deps.TeamRepo),
+		StatsUseCase:        istats.NewStatsUseCase(log, deps.Tx, deps.StatsRepo, deps.TeamRepo, deps.UserRepo),
+		HealthUseCase:       ihealth.NewHealthUseCase(log, deps.Pingers),
+		IdempotencyUseCase:  iidem.NewIdempotencyUseCase(log, deps.IdemRepo),
+		WebhookUseCase:      webhookUseCase,
+		OutboxUseCase:       ioutbox.NewOutboxUseCase(log, deps.OutboxRepo, append([]services.EventSink{subscribers}, deps.Sinks...)...),
+		ReviewStreamUseCase: streamUseCase,
+		AuthUseCase:         iauth.NewAuthUseCase(log, deps.TokenRepo),
 	}
 }
 
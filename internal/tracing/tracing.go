@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// serviceName defines the name of the service the spans are exported by.
+const serviceName = "pr-service"
+
+var ErrExporter = errors.New("tracing: error of the spans' exporter")
+
+// Provider defines the service's tracer provider exporting the spans in batches.
+// It's installed as the global one so every layer starts its spans with the otel.Tracer.
+type Provider struct {
+	log      *slog.Logger
+	provider *sdktrace.TracerProvider
+	file     *os.File
+}
+
+// New creates the tracer provider with the exporter given by its name: "otlp" or "stdout".
+// The stdout exporter writes the spans to the file if the path isn't empty.
+// The W3C trace context's propagator is installed as the global one as well.
+func New(ctx context.Context, log *slog.Logger, exporter string, path string) (*Provider, error) {
+	const op = "tracing.new"
+
+	p := &Provider{log: log}
+
+	spanExporter, err := p.newExporter(ctx, exporter, path)
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, ErrExporter, err)
+		log.Error(retErr.Error())
+		return nil, retErr
+	}
+
+	p.provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(serviceName),
+		)),
+	)
+
+	otel.SetTracerProvider(p.provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return p, nil
+}
+
+func (p *Provider) newExporter(ctx context.Context, exporter string, path string) (sdktrace.SpanExporter, error) {
+	switch exporter {
+	case "otlp":
+		return otlptracegrpc.New(ctx)
+
+	case "stdout":
+		var out io.Writer = os.Stdout
+		if len(path) != 0 {
+			file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if err != nil {
+				return nil, err
+			}
+			p.file = file
+			out = file
+		}
+		return stdouttrace.New(stdouttrace.WithWriter(out))
+	}
+
+	return nil, fmt.Errorf("unknown exporter '%s'", exporter)
+}
+
+// Shutdown flushes the buffered spans and stops the exporter.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	const op = "tracing.shutdown"
+
+	err := p.provider.Shutdown(ctx)
+	if p.file != nil {
+		err = errors.Join(err, p.file.Close())
+	}
+
+	if err != nil {
+		retErr := fmt.Errorf("error of the %s: %w: %w", op, ErrExporter, err)
+		p.log.Warn(retErr.Error())
+		return retErr
+	}
+
+	return nil
+}
@@ -0,0 +1,3 @@
+-- Deleting the team's strategy of choosing the reviewers.
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS reviewer_strategy;
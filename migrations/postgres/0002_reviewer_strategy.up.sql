@@ -0,0 +1,4 @@
+-- Adding the team's strategy of choosing the reviewers.
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS reviewer_strategy TEXT NOT NULL DEFAULT 'RANDOM'
+    CHECK (reviewer_strategy in ('RANDOM', 'ROUND_ROBIN', 'LEAST_LOADED', 'WEIGHTED_RANDOM'));
@@ -0,0 +1,5 @@
+-- Deleting the team's bounds of the PR's reviewers count.
+ALTER TABLE teams
+    DROP CONSTRAINT IF EXISTS teams_reviewer_policy_check,
+    DROP COLUMN IF EXISTS max_reviewers,
+    DROP COLUMN IF EXISTS min_reviewers;
@@ -0,0 +1,6 @@
+-- Adding the team's bounds of the PR's reviewers count.
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS min_reviewers INT NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS max_reviewers INT NOT NULL DEFAULT 2,
+    ADD CONSTRAINT teams_reviewer_policy_check
+        CHECK (min_reviewers >= 0 AND max_reviewers >= 1 AND min_reviewers <= max_reviewers);
@@ -0,0 +1,3 @@
+-- Deleting the relation keeping the PR's reviewers reassignments.
+DROP INDEX IF EXISTS assigned_reviewers_user_idx;
+DROP TABLE IF EXISTS reviewer_reassignments;
@@ -0,0 +1,12 @@
+-- Adding the relation keeping the PR's reviewers reassignments.
+CREATE TABLE IF NOT EXISTS reviewer_reassignments (
+    id SERIAL PRIMARY KEY,
+    pr_id TEXT NOT NULL REFERENCES pull_requests(id) ON UPDATE CASCADE,
+    old_user_id TEXT NOT NULL REFERENCES users(id) ON UPDATE CASCADE,
+    new_user_id TEXT NOT NULL REFERENCES users(id) ON UPDATE CASCADE,
+    reassigned_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Adding the indexes used for the reviewers' statistics.
+CREATE INDEX IF NOT EXISTS reviewer_reassignments_old_user_idx ON reviewer_reassignments (old_user_id, reassigned_at);
+CREATE INDEX IF NOT EXISTS assigned_reviewers_user_idx ON assigned_reviewers (user_id);
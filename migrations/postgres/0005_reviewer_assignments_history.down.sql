@@ -0,0 +1,19 @@
+-- Restoring the relation keeping the PR's reviewers reassignments.
+CREATE TABLE IF NOT EXISTS reviewer_reassignments (
+    id SERIAL PRIMARY KEY,
+    pr_id TEXT NOT NULL REFERENCES pull_requests(id) ON UPDATE CASCADE,
+    old_user_id TEXT NOT NULL REFERENCES users(id) ON UPDATE CASCADE,
+    new_user_id TEXT NOT NULL REFERENCES users(id) ON UPDATE CASCADE,
+    reassigned_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS reviewer_reassignments_old_user_idx ON reviewer_reassignments (old_user_id, reassigned_at);
+
+INSERT INTO reviewer_reassignments (pr_id, old_user_id, new_user_id, reassigned_at)
+SELECT pr_id, old_user_id, new_user_id, changed_at
+FROM reviewer_assignments_history
+WHERE old_user_id IS NOT NULL AND new_user_id IS NOT NULL
+ORDER BY id;
+
+-- Deleting the relation keeping every change of the PR's reviewers.
+DROP TABLE IF EXISTS reviewer_assignments_history;
@@ -0,0 +1,22 @@
+-- Adding the relation keeping every change of the PR's reviewers.
+CREATE TABLE IF NOT EXISTS reviewer_assignments_history (
+    id SERIAL PRIMARY KEY,
+    pr_id TEXT NOT NULL REFERENCES pull_requests(id) ON UPDATE CASCADE,
+    old_user_id TEXT REFERENCES users(id) ON UPDATE CASCADE,
+    new_user_id TEXT REFERENCES users(id) ON UPDATE CASCADE,
+    reason TEXT NOT NULL CHECK (reason IN ('CREATED', 'REASSIGNED', 'DEACTIVATED')),
+    actor_id TEXT,
+    changed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (old_user_id IS NOT NULL OR new_user_id IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS reviewer_assignments_history_pr_idx ON reviewer_assignments_history (pr_id, changed_at);
+CREATE INDEX IF NOT EXISTS reviewer_assignments_history_old_user_idx ON reviewer_assignments_history (old_user_id, changed_at);
+
+-- Moving the already kept reassignments to the history.
+INSERT INTO reviewer_assignments_history (pr_id, old_user_id, new_user_id, reason, changed_at)
+SELECT pr_id, old_user_id, new_user_id, 'REASSIGNED', reassigned_at
+FROM reviewer_reassignments
+ORDER BY id;
+
+DROP TABLE IF EXISTS reviewer_reassignments;
@@ -0,0 +1,8 @@
+-- Deleting the 'DRAFT' and 'CLOSED' PR's statuses: such PRs are considered open.
+UPDATE pull_requests
+SET status='OPEN'
+WHERE status IN ('DRAFT', 'CLOSED');
+
+ALTER TABLE pull_requests
+    DROP CONSTRAINT IF EXISTS pull_requests_status_check,
+    ADD CONSTRAINT pull_requests_status_check CHECK (status IN ('OPEN', 'MERGED'));
@@ -0,0 +1,4 @@
+-- Adding the 'DRAFT' and 'CLOSED' PR's statuses.
+ALTER TABLE pull_requests
+    DROP CONSTRAINT IF EXISTS pull_requests_status_check,
+    ADD CONSTRAINT pull_requests_status_check CHECK (status IN ('DRAFT', 'OPEN', 'CLOSED', 'MERGED'));
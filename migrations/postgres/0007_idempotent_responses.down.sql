@@ -0,0 +1,2 @@
+-- Deleting the relation keeping the responses for the clients' idempotency keys.
+DROP TABLE IF EXISTS idempotent_responses;
@@ -0,0 +1,9 @@
+-- Adding the relation keeping the responses for the clients' idempotency keys.
+-- The record is pending while the status_code is NULL.
+CREATE TABLE IF NOT EXISTS idempotent_responses (
+    idempotency_key TEXT PRIMARY KEY,
+    fingerprint TEXT NOT NULL,
+    status_code INT,
+    body BYTEA,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
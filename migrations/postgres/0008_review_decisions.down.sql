@@ -0,0 +1,7 @@
+-- Deleting the team's merge policy.
+ALTER TABLE teams
+    DROP CONSTRAINT IF EXISTS teams_merge_policy_check,
+    DROP COLUMN IF EXISTS required_approvals;
+
+-- Deleting the relation keeping the reviewers' decisions.
+DROP TABLE IF EXISTS review_decisions;
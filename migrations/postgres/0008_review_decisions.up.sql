@@ -0,0 +1,15 @@
+-- Adding the relation keeping every reviewer's decision on the PR.
+CREATE TABLE IF NOT EXISTS review_decisions (
+    id SERIAL PRIMARY KEY,
+    pr_id TEXT NOT NULL REFERENCES pull_requests(id) ON UPDATE CASCADE,
+    reviewer_id TEXT NOT NULL REFERENCES users(id) ON UPDATE CASCADE,
+    decision TEXT NOT NULL CHECK (decision IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS review_decisions_pr_reviewer_idx ON review_decisions (pr_id, reviewer_id, created_at);
+
+-- Adding the team's merge policy: the approvals the PR must have before the merge.
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS required_approvals INT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT teams_merge_policy_check CHECK (required_approvals >= 0);
@@ -0,0 +1,4 @@
+-- Deleting the team's merge policy rules: the resolved change requests and the author's approval.
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS allow_author_approval,
+    DROP COLUMN IF EXISTS require_resolved_changes;
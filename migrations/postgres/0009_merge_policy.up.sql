@@ -0,0 +1,4 @@
+-- Adding the team's merge policy rules: the resolved change requests and the author's approval.
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS require_resolved_changes BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS allow_author_approval BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,5 @@
+-- Deleting the queue of the events' deliveries to the webhooks.
+DROP TABLE IF EXISTS webhook_deliveries;
+
+-- Deleting the relation keeping the teams' webhooks.
+DROP TABLE IF EXISTS team_webhooks;
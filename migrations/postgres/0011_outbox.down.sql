@@ -0,0 +1,7 @@
+-- Deleting the domain event of the webhooks' deliveries.
+DROP INDEX IF EXISTS webhook_deliveries_event_idx;
+
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS event_id;
+
+-- Deleting the outbox of the domain events.
+DROP TABLE IF EXISTS outbox;
@@ -0,0 +1,11 @@
+-- Deleting the indexes used for filtering the PRs by the reviewer and by the author's team
+-- and for getting the PRs' reviewers.
+DROP INDEX IF EXISTS users_team_idx;
+DROP INDEX IF EXISTS assigned_reviewers_pr_idx;
+DROP INDEX IF EXISTS assigned_reviewers_user_pr_idx;
+CREATE INDEX IF NOT EXISTS assigned_reviewers_user_idx ON assigned_reviewers (user_id);
+
+-- Deleting the indexes used for the PRs' listing.
+DROP INDEX IF EXISTS pull_requests_status_created_idx;
+DROP INDEX IF EXISTS pull_requests_author_created_idx;
+DROP INDEX IF EXISTS pull_requests_created_idx;
@@ -0,0 +1,12 @@
+-- Adding the indexes used for the PRs' listing: the PRs are sorted by the created_at and the id
+-- with the PRs without the created_at being the earliest ones.
+CREATE INDEX IF NOT EXISTS pull_requests_created_idx ON pull_requests ((COALESCE(created_at, '-infinity'::TIMESTAMP)), id);
+CREATE INDEX IF NOT EXISTS pull_requests_author_created_idx ON pull_requests (author_id, (COALESCE(created_at, '-infinity'::TIMESTAMP)), id);
+CREATE INDEX IF NOT EXISTS pull_requests_status_created_idx ON pull_requests (status, (COALESCE(created_at, '-infinity'::TIMESTAMP)), id);
+
+-- Adding the indexes used for filtering the PRs by the reviewer and by the author's team
+-- and for getting the PRs' reviewers.
+DROP INDEX IF EXISTS assigned_reviewers_user_idx;
+CREATE INDEX IF NOT EXISTS assigned_reviewers_user_pr_idx ON assigned_reviewers (user_id, pr_id);
+CREATE INDEX IF NOT EXISTS assigned_reviewers_pr_idx ON assigned_reviewers (pr_id);
+CREATE INDEX IF NOT EXISTS users_team_idx ON users (team_id);
@@ -0,0 +1,2 @@
+-- Deleting the relation keeping the API tokens.
+DROP TABLE IF EXISTS api_tokens;
@@ -0,0 +1,11 @@
+-- Adding the relation keeping the API tokens by their secrets' hashes: the secrets themselves aren't stored.
+-- The token of the user makes the user the actor of the requests.
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    token_name TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    user_id TEXT REFERENCES users(id) ON UPDATE CASCADE,
+    created_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP
+);
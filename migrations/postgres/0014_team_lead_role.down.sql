@@ -0,0 +1,4 @@
+-- Deleting the user's role in his team.
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_user_role_check,
+    DROP COLUMN IF EXISTS user_role;
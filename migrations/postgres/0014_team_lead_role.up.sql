@@ -0,0 +1,4 @@
+-- Adding the user's role in his team: the team's leads manage its membership and its PRs.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS user_role TEXT NOT NULL DEFAULT 'member',
+    ADD CONSTRAINT users_user_role_check CHECK (user_role IN ('member', 'team_lead'));
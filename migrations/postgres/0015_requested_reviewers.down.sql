@@ -0,0 +1,3 @@
+-- Deleting the reviewers count requested at the PR's creation.
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS requested_reviewers;
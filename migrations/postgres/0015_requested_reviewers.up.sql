@@ -0,0 +1,4 @@
+-- Adding the reviewers count requested at the PR's creation: it's used for the reviewers assigned
+-- when the PR leaves the DRAFT status, NULL means the team's reviewer policy's maximum.
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS requested_reviewers INT;